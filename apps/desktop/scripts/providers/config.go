@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigFileName is the repo-relative (to apps/desktop) config file that
+// selects which ModelProvider to use.
+const ConfigFileName = "model-provider.toml"
+
+// LocalConfig configures the local llama-server provider. A zero value is
+// fine - Prepare falls back to ManifestFileName.
+type LocalConfig struct {
+	// ManifestPath overrides where the {os, arch} -> download manifest is
+	// read from (see manifest.go); defaults to ManifestFileName.
+	ManifestPath string `toml:"manifest_path"`
+}
+
+// OllamaConfig configures the Ollama provider.
+type OllamaConfig struct {
+	BaseURL string `toml:"base_url"`
+	Model   string `toml:"model"`
+}
+
+// OpenAIConfig configures an OpenAI-compatible HTTP endpoint.
+type OpenAIConfig struct {
+	BaseURL string `toml:"base_url"`
+	APIKey  string `toml:"api_key"`
+	Model   string `toml:"model"`
+}
+
+// Config is the parsed form of model-provider.toml.
+type Config struct {
+	// Provider selects which provider New builds: "local" (the default),
+	// "ollama", or "openai".
+	Provider string       `toml:"provider"`
+	Local    LocalConfig  `toml:"local"`
+	Ollama   OllamaConfig `toml:"ollama"`
+	OpenAI   OpenAIConfig `toml:"openai"`
+}
+
+// Default returns the configuration used when apps/desktop has no
+// model-provider.toml: the local llama-server provider, reading
+// ManifestFileName for its download.
+func Default() *Config {
+	return &Config{Provider: "local"}
+}
+
+// Load reads model-provider.toml from rootDir and merges it over Default().
+// A missing file is not an error - it just means "use the local provider".
+func Load(rootDir string) (*Config, error) {
+	cfg := Default()
+
+	path := filepath.Join(rootDir, ConfigFileName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.Provider == "" {
+		cfg.Provider = "local"
+	}
+	return cfg, nil
+}
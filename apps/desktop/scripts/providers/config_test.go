@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_MissingFileReturnsLocalDefault(t *testing.T) {
+	tmp := t.TempDir()
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Provider != "local" {
+		t.Errorf("expected default provider %q, got %q", "local", cfg.Provider)
+	}
+}
+
+func TestLoad_SelectsOllamaProvider(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+provider = "ollama"
+
+[ollama]
+base_url = "http://example.com:11434"
+model = "llama3"
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Provider != "ollama" {
+		t.Errorf("expected provider %q, got %q", "ollama", cfg.Provider)
+	}
+	if cfg.Ollama.BaseURL != "http://example.com:11434" {
+		t.Errorf("unexpected base url: %q", cfg.Ollama.BaseURL)
+	}
+	if cfg.Ollama.Model != "llama3" {
+		t.Errorf("unexpected model: %q", cfg.Ollama.Model)
+	}
+}
+
+func TestLoad_SelectsOpenAIProvider(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+provider = "openai"
+
+[openai]
+base_url = "https://api.openai.com"
+api_key = "sk-test"
+model = "gpt-4o-mini"
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.OpenAI.BaseURL != "https://api.openai.com" {
+		t.Errorf("unexpected base url: %q", cfg.OpenAI.BaseURL)
+	}
+	if cfg.OpenAI.APIKey != "sk-test" {
+		t.Errorf("unexpected api key: %q", cfg.OpenAI.APIKey)
+	}
+}
+
+func TestLoad_InvalidTOMLReturnsError(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `this is not valid toml`)
+
+	if _, err := Load(tmp); err == nil {
+		t.Fatal("expected an error for invalid TOML")
+	}
+}
@@ -0,0 +1,192 @@
+package providers
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// downloadFile downloads the first URL in urls that succeeds to destPath,
+// falling back to the next URL on a network error or non-2xx response -
+// e.g. a primary GitHub release URL followed by self-hosted mirrors for
+// air-gapped environments. It resumes from destPath+".tmp" if a previous
+// attempt left one behind, via an HTTP Range request. Returns the SHA-256
+// of the completed file.
+func downloadFile(ctx context.Context, urls []string, destPath string) (string, error) {
+	if len(urls) == 0 {
+		return "", fmt.Errorf("no download URLs provided")
+	}
+
+	tmpPath := destPath + ".tmp"
+	var lastErr error
+	for _, url := range urls {
+		checksum, err := downloadWithResume(ctx, url, tmpPath)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", url, err)
+			fmt.Printf("  mirror failed (%v), trying next\n", err)
+			continue
+		}
+
+		if err := os.Rename(tmpPath, destPath); err != nil {
+			return "", fmt.Errorf("rename: %w", err)
+		}
+		return checksum, nil
+	}
+
+	return "", fmt.Errorf("all download URLs failed, last error: %w", lastErr)
+}
+
+// downloadWithResume downloads url to tmpPath, resuming from tmpPath's
+// existing size (if any) via a Range request, and returns the SHA-256 of
+// the completed file. The resumed prefix is streamed through the hasher
+// before the new bytes are appended, so the checksum doesn't require a
+// second pass over the completed file.
+func downloadWithResume(ctx context.Context, url, tmpPath string) (string, error) {
+	resumeFrom := int64(0)
+	if info, err := os.Stat(tmpPath); err == nil {
+		resumeFrom = info.Size()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+	if resumeFrom > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", resumeFrom))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("http get: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error closing response body: %v\n", err)
+		}
+	}()
+
+	hasher := sha256.New()
+
+	var out *os.File
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		if err := validateContentRange(resp.Header.Get("Content-Range"), resumeFrom); err != nil {
+			if rmErr := os.Remove(tmpPath); rmErr != nil && !os.IsNotExist(rmErr) {
+				return "", fmt.Errorf("remove stale partial file: %w", rmErr)
+			}
+			return "", fmt.Errorf("resume validation failed, discarded partial download: %w", err)
+		}
+		if err := hashExistingPrefix(tmpPath, hasher); err != nil {
+			return "", fmt.Errorf("hash existing partial download: %w", err)
+		}
+		out, err = os.OpenFile(tmpPath, os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", fmt.Errorf("open for resume: %w", err)
+		}
+	case http.StatusOK:
+		// Either we didn't ask to resume, or the server doesn't support
+		// Range requests and sent the whole file - start fresh either way.
+		out, err = os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", fmt.Errorf("create file: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("http status: %s", resp.Status)
+	}
+	closeOnReturn := true
+	defer func() {
+		if closeOnReturn {
+			if err := out.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error closing file: %v\n", err)
+			}
+		}
+	}()
+
+	if err := copyWithProgress(resp.Body, io.MultiWriter(out, hasher), resp.ContentLength); err != nil {
+		return "", err
+	}
+
+	closeOnReturn = false
+	if err := out.Close(); err != nil {
+		return "", fmt.Errorf("close: %w", err)
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// validateContentRange confirms a 206 response's Content-Range header (e.g.
+// "bytes 1048576-10485759/10485760") starts at resumeFrom - a mismatch
+// means the server isn't resuming from where we asked, so the partial file
+// on disk can't be trusted.
+func validateContentRange(contentRange string, resumeFrom int64) error {
+	if contentRange == "" {
+		return fmt.Errorf("server returned 206 with no Content-Range header")
+	}
+	rest, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return fmt.Errorf("unparseable Content-Range %q", contentRange)
+	}
+	startStr, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return fmt.Errorf("unparseable Content-Range %q", contentRange)
+	}
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil {
+		return fmt.Errorf("unparseable Content-Range %q: %w", contentRange, err)
+	}
+	if start != resumeFrom {
+		return fmt.Errorf("Content-Range starts at %d, expected %d", start, resumeFrom)
+	}
+	return nil
+}
+
+// hashExistingPrefix streams tmpPath's current contents through hasher, so
+// a resumed download's final checksum covers the bytes from the earlier
+// attempt too.
+func hashExistingPrefix(tmpPath string, hasher io.Writer) error {
+	f, err := os.Open(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = io.Copy(hasher, bufio.NewReader(f))
+	return err
+}
+
+func copyWithProgress(src io.Reader, dst io.Writer, size int64) error {
+	written := int64(0)
+	lastPct := -10 // Start at -10 so we print 0%
+	buf := make([]byte, 32*1024)
+
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if _, writeErr := dst.Write(buf[:n]); writeErr != nil {
+				return fmt.Errorf("write: %w", writeErr)
+			}
+			written += int64(n)
+			if size > 0 {
+				pct := int(float64(written) / float64(size) * 100)
+				if pct >= lastPct+10 {
+					fmt.Printf("  %d%% (%d / %d MB)\n", pct, written/(1024*1024), size/(1024*1024))
+					lastPct = pct
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+	}
+	return nil
+}
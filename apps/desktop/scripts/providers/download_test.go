@@ -0,0 +1,156 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestDownloadFile_FreshDownload(t *testing.T) {
+	const content = "the quick brown fox jumps over the lazy dog"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	checksum, err := downloadFile(context.Background(), []string{srv.URL}, dest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checksum != sha256Hex(content) {
+		t.Errorf("checksum mismatch: got %s", checksum)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != content {
+		t.Errorf("unexpected contents: %q", got)
+	}
+}
+
+// rangeServer serves content and supports Range requests, used to exercise
+// the resume path.
+func rangeServer(content string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rng := r.Header.Get("Range")
+		if rng == "" {
+			_, _ = w.Write([]byte(content))
+			return
+		}
+		var start int
+		if _, err := fmt.Sscanf(rng, "bytes=%d-", &start); err != nil || start > len(content) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte(content[start:]))
+	}))
+}
+
+func TestDownloadFile_ResumesFromPartialTmpFile(t *testing.T) {
+	const content = "the quick brown fox jumps over the lazy dog"
+	srv := rangeServer(content)
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	if err := os.WriteFile(dest+".tmp", []byte(content[:10]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	checksum, err := downloadFile(context.Background(), []string{srv.URL}, dest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checksum != sha256Hex(content) {
+		t.Errorf("checksum mismatch: got %s", checksum)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != content {
+		t.Errorf("unexpected contents after resume: %q", got)
+	}
+}
+
+func TestDownloadFile_DiscardsPartialFileOnContentRangeMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Range", "bytes 999-1099/1100")
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte("mismatched content"))
+	}))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	if err := os.WriteFile(dest+".tmp", []byte("stale partial bytes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := downloadFile(context.Background(), []string{srv.URL}, dest); err == nil {
+		t.Fatal("expected an error for a Content-Range mismatch")
+	}
+	if _, err := os.Stat(dest + ".tmp"); !os.IsNotExist(err) {
+		t.Error("expected the stale partial file to be removed")
+	}
+}
+
+func TestDownloadFile_FallsBackToMirrorOnFailure(t *testing.T) {
+	badSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer badSrv.Close()
+
+	const content = "mirrored content"
+	goodSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(content))
+	}))
+	defer goodSrv.Close()
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	checksum, err := downloadFile(context.Background(), []string{badSrv.URL, goodSrv.URL}, dest)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checksum != sha256Hex(content) {
+		t.Errorf("checksum mismatch: got %s", checksum)
+	}
+}
+
+func TestDownloadFile_AllMirrorsFailingReturnsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	dest := filepath.Join(t.TempDir(), "out.bin")
+	_, err := downloadFile(context.Background(), []string{srv.URL}, dest)
+	if err == nil {
+		t.Fatal("expected an error when every mirror fails")
+	}
+	if !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected error to mention the status, got: %v", err)
+	}
+}
+
+func TestDownloadFile_NoURLsReturnsError(t *testing.T) {
+	if _, err := downloadFile(context.Background(), nil, filepath.Join(t.TempDir(), "out.bin")); err == nil {
+		t.Fatal("expected an error for an empty URL list")
+	}
+}
@@ -0,0 +1,91 @@
+package providers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// extractBinary pulls memberPath out of the tar.gz or zip archive at
+// archivePath and writes it to destPath with executable permissions.
+func extractBinary(archivePath, layout, memberPath, destPath string) error {
+	switch layout {
+	case "tar.gz":
+		return extractFromTarGz(archivePath, memberPath, destPath)
+	case "zip":
+		return extractFromZip(archivePath, memberPath, destPath)
+	default:
+		return fmt.Errorf("unknown archive layout %q (want tar.gz or zip)", layout)
+	}
+}
+
+func extractFromTarGz(archivePath, memberPath, destPath string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("gzip: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return fmt.Errorf("member %s not found in %s", memberPath, archivePath)
+		}
+		if err != nil {
+			return fmt.Errorf("tar: %w", err)
+		}
+		if path.Clean(hdr.Name) != path.Clean(memberPath) {
+			continue
+		}
+		return writeExecutable(destPath, tr)
+	}
+}
+
+func extractFromZip(archivePath, memberPath, destPath string) error {
+	r, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("zip: %w", err)
+	}
+	defer func() { _ = r.Close() }()
+
+	for _, zf := range r.File {
+		if path.Clean(zf.Name) != path.Clean(memberPath) {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return fmt.Errorf("open %s: %w", zf.Name, err)
+		}
+		defer func() { _ = rc.Close() }()
+		return writeExecutable(destPath, rc)
+	}
+	return fmt.Errorf("member %s not found in %s", memberPath, archivePath)
+}
+
+func writeExecutable(destPath string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	out, err := os.OpenFile(destPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", destPath, err)
+	}
+	defer func() { _ = out.Close() }()
+
+	if _, err := io.Copy(out, r); err != nil {
+		return fmt.Errorf("write %s: %w", destPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package providers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTarGz(t *testing.T, path, memberName, contents string) {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: memberName, Mode: 0o755, Size: int64(len(contents))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(contents)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeZip(t *testing.T, path, memberName, contents string) {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(memberName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(contents)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractBinary_TarGz(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "a.tar.gz")
+	writeTarGz(t, archivePath, "build/bin/llama-server", "fake binary")
+
+	dest := filepath.Join(dir, "llama-server")
+	if err := extractBinary(archivePath, "tar.gz", "build/bin/llama-server", dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "fake binary" {
+		t.Errorf("unexpected contents: %q", got)
+	}
+}
+
+func TestExtractBinary_Zip(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "a.zip")
+	writeZip(t, archivePath, "llama-server.exe", "fake exe")
+
+	dest := filepath.Join(dir, "llama-server.exe")
+	if err := extractBinary(archivePath, "zip", "llama-server.exe", dest); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "fake exe" {
+		t.Errorf("unexpected contents: %q", got)
+	}
+}
+
+func TestExtractBinary_MissingMemberReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "a.tar.gz")
+	writeTarGz(t, archivePath, "other-file", "contents")
+
+	if err := extractBinary(archivePath, "tar.gz", "build/bin/llama-server", filepath.Join(dir, "out")); err == nil {
+		t.Fatal("expected an error for a missing member")
+	}
+}
+
+func TestExtractBinary_UnknownLayoutReturnsError(t *testing.T) {
+	if err := extractBinary("whatever", "rar", "member", "dest"); err == nil {
+		t.Fatal("expected an error for an unknown archive layout")
+	}
+}
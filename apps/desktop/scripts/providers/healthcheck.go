@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// checkEndpointReachable issues a GET to url and treats any non-2xx status
+// (or a transport-level failure) as unhealthy. It's shared by the remote
+// providers, which differ only in which endpoint and headers they probe.
+func checkEndpointReachable(ctx context.Context, client *http.Client, url string, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// binaryDir is where the extracted llama-server binary is placed, relative
+// to the working directory (apps/desktop).
+const binaryDir = "src-tauri/resources"
+
+// LocalProvider downloads, checksum-verifies, and extracts the llama-server
+// binary for the current platform, picked from a manifest mapping
+// {os, arch} to a download (see manifest.go).
+type LocalProvider struct {
+	cfg LocalConfig
+}
+
+// NewLocalProvider returns a LocalProvider, falling back to
+// ManifestFileName if cfg.ManifestPath is empty.
+func NewLocalProvider(cfg LocalConfig) *LocalProvider {
+	if cfg.ManifestPath == "" {
+		cfg.ManifestPath = ManifestFileName
+	}
+	return &LocalProvider{cfg: cfg}
+}
+
+func (p *LocalProvider) Name() string { return "local" }
+
+// binaryPath returns the destination path of the extracted llama-server
+// binary for the current platform.
+func binaryPath() string {
+	name := "llama-server"
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(binaryDir, name)
+}
+
+// Prepare downloads, verifies, and extracts the llama-server binary for
+// runtime.GOOS/runtime.GOARCH, per the manifest entry for this platform. If
+// the manifest has no entry for this platform, it falls back to an empty
+// placeholder, since Tauri requires the resource to exist even when there's
+// nothing to run on this platform yet.
+func (p *LocalProvider) Prepare(ctx context.Context) error {
+	manifest, err := LoadManifest(p.cfg.ManifestPath)
+	if err != nil {
+		return fmt.Errorf("load manifest: %w", err)
+	}
+
+	entry, err := manifest.Entry(runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return createPlaceholder(binaryPath())
+	}
+
+	dest := binaryPath()
+	if fileExistsWithChecksum(archivePath(dest), entry.SHA256) {
+		fmt.Printf("llama-server archive for %s/%s already verified, extracting\n", runtime.GOOS, runtime.GOARCH)
+		return extractBinary(archivePath(dest), entry.ArchiveLayout, entry.BinaryPath, dest)
+	}
+
+	if err := os.MkdirAll(binaryDir, 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	urls := append([]string{entry.URL}, entry.Mirrors...)
+	fmt.Printf("Downloading llama-server %s for %s/%s...\n", manifest.Version, runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("URL: %s\n", entry.URL)
+
+	actualChecksum, err := downloadFile(ctx, urls, archivePath(dest))
+	if err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+	if actualChecksum != entry.SHA256 {
+		if err := os.Remove(archivePath(dest)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error removing corrupted file: %v\n", err)
+		}
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", entry.SHA256, actualChecksum)
+	}
+
+	fmt.Println("Download complete and verified, extracting...")
+	return extractBinary(archivePath(dest), entry.ArchiveLayout, entry.BinaryPath, dest)
+}
+
+// archivePath is where the downloaded archive for binPath's platform is
+// kept, alongside the binary it's extracted from.
+func archivePath(binPath string) string {
+	return binPath + ".download"
+}
+
+// Endpoint is empty for LocalProvider - the desktop app launches
+// llama-server itself and talks to the port it's told to listen on, rather
+// than discovering it through this package.
+func (p *LocalProvider) Endpoint() string { return "" }
+
+// HealthCheck confirms the extracted binary exists.
+func (p *LocalProvider) HealthCheck(ctx context.Context) error {
+	if _, err := os.Stat(binaryPath()); err != nil {
+		return fmt.Errorf("%s missing: %w", binaryPath(), err)
+	}
+	return nil
+}
+
+func createPlaceholder(destPath string) error {
+	if _, err := os.Stat(destPath); err == nil {
+		fmt.Printf("Placeholder %s already exists, skipping\n", destPath)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	f, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("create file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close file: %w", err)
+	}
+
+	fmt.Printf("Created placeholder %s (no manifest entry for this platform)\n", destPath)
+	return nil
+}
+
+func fileExistsWithChecksum(path, expectedChecksum string) bool {
+	checksum, err := computeSHA256(path)
+	if err != nil {
+		return false
+	}
+	return checksum == expectedChecksum
+}
+
+func computeSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,98 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestLocalProvider_PrepareDownloadsVerifiesAndExtracts(t *testing.T) {
+	dir := t.TempDir()
+	archiveDir := t.TempDir()
+	archivePath := filepath.Join(archiveDir, "a.tar.gz")
+	writeTarGz(t, archivePath, "build/bin/llama-server", "fake binary")
+	checksum, err := computeSHA256(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.FileServer(http.Dir(archiveDir)))
+	defer srv.Close()
+
+	manifestPath := filepath.Join(dir, "llama-manifest.json")
+	m := &Manifest{
+		Version: "test",
+		Platforms: map[string]ManifestEntry{
+			platformKeyForTest(): {
+				URL:           srv.URL + "/a.tar.gz",
+				SHA256:        checksum,
+				ArchiveLayout: "tar.gz",
+				BinaryPath:    "build/bin/llama-server",
+			},
+		},
+	}
+	if err := m.Save(manifestPath); err != nil {
+		t.Fatal(err)
+	}
+
+	withWorkingDir(t, dir, func() {
+		p := NewLocalProvider(LocalConfig{ManifestPath: manifestPath})
+		if err := p.Prepare(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		got, err := os.ReadFile(binaryPath())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != "fake binary" {
+			t.Errorf("unexpected extracted contents: %q", got)
+		}
+
+		if err := p.HealthCheck(context.Background()); err != nil {
+			t.Errorf("unexpected health check error: %v", err)
+		}
+	})
+}
+
+func TestLocalProvider_PrepareFallsBackToPlaceholderForUnlistedPlatform(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "llama-manifest.json")
+	m := &Manifest{Version: "test", Platforms: map[string]ManifestEntry{}}
+	if err := m.Save(manifestPath); err != nil {
+		t.Fatal(err)
+	}
+
+	withWorkingDir(t, dir, func() {
+		p := NewLocalProvider(LocalConfig{ManifestPath: manifestPath})
+		if err := p.Prepare(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := os.Stat(binaryPath()); err != nil {
+			t.Errorf("expected a placeholder file: %v", err)
+		}
+	})
+}
+
+// platformKeyForTest returns the platformKey for runtime.GOOS/GOARCH, so
+// the test's manifest entry matches whatever platform the test runs on.
+func platformKeyForTest() string {
+	return platformKey(runtime.GOOS, runtime.GOARCH)
+}
+
+func withWorkingDir(t *testing.T, dir string, fn func()) {
+	t.Helper()
+	prev, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(prev) }()
+	fn()
+}
@@ -0,0 +1,88 @@
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFileName is the default llama-manifest.json path, relative to
+// apps/desktop.
+const ManifestFileName = "scripts/llama-manifest.json"
+
+// pendingChecksumPrefix marks a ManifestEntry.SHA256 that hasn't been
+// computed yet, e.g. a platform added to llama-manifest.json by hand before
+// running `go run scripts/download-llama-server.go -update`. Entry treats
+// such an entry the same as a platform with no entry at all, so Prepare
+// falls back to its placeholder binary instead of downloading an archive
+// whose checksum can never match.
+const pendingChecksumPrefix = "pending-update:"
+
+// ManifestEntry is one platform's download in llama-manifest.json.
+type ManifestEntry struct {
+	URL           string `json:"url"`
+	SHA256        string `json:"sha256"`
+	ArchiveLayout string `json:"archive_layout"` // "tar.gz" or "zip"
+	BinaryPath    string `json:"binary_path"`    // path to llama-server within the archive
+
+	// Mirrors are fallback URLs tried in order after URL, e.g. a
+	// self-hosted mirror for air-gapped environments. All entries are
+	// expected to serve byte-identical content, so a resumed download can
+	// fall over to the next mirror mid-transfer.
+	Mirrors []string `json:"mirrors,omitempty"`
+}
+
+// Manifest maps "{os}-{arch}" (e.g. "darwin-arm64") to its download entry,
+// so the local provider can pick the right one for runtime.GOOS/GOARCH.
+type Manifest struct {
+	Version   string                   `json:"version"`
+	Platforms map[string]ManifestEntry `json:"platforms"`
+}
+
+// platformKey is the Manifest.Platforms key for goos/goarch.
+func platformKey(goos, goarch string) string {
+	return fmt.Sprintf("%s-%s", goos, goarch)
+}
+
+// LoadManifest reads and parses a llama-manifest.json file.
+func LoadManifest(path string) (*Manifest, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// Entry returns the manifest entry for goos/goarch. An entry whose SHA256
+// still carries pendingChecksumPrefix is reported as not found, the same as
+// an absent platform, since there's no real checksum yet to verify a
+// download against.
+func (m *Manifest) Entry(goos, goarch string) (ManifestEntry, error) {
+	entry, ok := m.Platforms[platformKey(goos, goarch)]
+	if !ok || strings.HasPrefix(entry.SHA256, pendingChecksumPrefix) {
+		return ManifestEntry{}, fmt.Errorf("no llama-server download for %s/%s in %s", goos, goarch, ManifestFileName)
+	}
+	return entry, nil
+}
+
+// Save writes m to path as indented JSON, so -update can rewrite the
+// manifest after refreshing its download URLs and checksums.
+func (m *Manifest) Save(path string) error {
+	raw, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(raw, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
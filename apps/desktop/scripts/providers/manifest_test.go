@@ -0,0 +1,96 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeManifest(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadManifest_ParsesPlatforms(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "llama-manifest.json")
+	writeManifest(t, path, `{
+		"version": "b7815",
+		"platforms": {
+			"darwin-arm64": {
+				"url": "https://example.com/a.tar.gz",
+				"sha256": "abc123",
+				"archive_layout": "tar.gz",
+				"binary_path": "build/bin/llama-server"
+			}
+		}
+	}`)
+
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Version != "b7815" {
+		t.Errorf("unexpected version: %q", m.Version)
+	}
+
+	entry, err := m.Entry("darwin", "arm64")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.URL != "https://example.com/a.tar.gz" || entry.SHA256 != "abc123" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestManifest_EntryMissingPlatformReturnsError(t *testing.T) {
+	m := &Manifest{Platforms: map[string]ManifestEntry{}}
+	if _, err := m.Entry("plan9", "mips"); err == nil {
+		t.Fatal("expected an error for an unlisted platform")
+	}
+}
+
+func TestManifest_EntryPendingChecksumReturnsError(t *testing.T) {
+	m := &Manifest{
+		Platforms: map[string]ManifestEntry{
+			"linux-amd64": {
+				URL:    "https://example.com/b.tar.gz",
+				SHA256: "pending-update: run `go run scripts/download-llama-server.go -update` to compute",
+			},
+		},
+	}
+	if _, err := m.Entry("linux", "amd64"); err == nil {
+		t.Fatal("expected an error for an entry with a pending checksum")
+	}
+}
+
+func TestManifest_SaveRoundTrips(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sub", "llama-manifest.json")
+
+	m := &Manifest{
+		Version: "b7815",
+		Platforms: map[string]ManifestEntry{
+			"linux-amd64": {URL: "https://example.com/b.tar.gz", SHA256: "def456", ArchiveLayout: "tar.gz", BinaryPath: "build/bin/llama-server"},
+		},
+	}
+	if err := m.Save(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entry, err := loaded.Entry("linux", "amd64")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.URL != "https://example.com/b.tar.gz" {
+		t.Errorf("unexpected round-tripped entry: %+v", entry)
+	}
+}
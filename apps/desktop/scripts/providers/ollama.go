@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultOllamaBaseURL = "http://localhost:11434"
+
+// healthCheckTimeout bounds how long a remote provider's HealthCheck waits
+// for a response, so a hung endpoint doesn't block the desktop app forever.
+const healthCheckTimeout = 5 * time.Second
+
+// OllamaProvider talks to a local or remote Ollama install.
+type OllamaProvider struct {
+	cfg    OllamaConfig
+	client *http.Client
+}
+
+// NewOllamaProvider returns an OllamaProvider, falling back to
+// defaultOllamaBaseURL if cfg.BaseURL is empty.
+func NewOllamaProvider(cfg OllamaConfig) *OllamaProvider {
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultOllamaBaseURL
+	}
+	return &OllamaProvider{cfg: cfg, client: &http.Client{Timeout: healthCheckTimeout}}
+}
+
+func (p *OllamaProvider) Name() string { return "ollama" }
+
+// Prepare validates that a model name is configured; Ollama itself handles
+// pulling/serving the model, so there's nothing to download here.
+func (p *OllamaProvider) Prepare(ctx context.Context) error {
+	if p.cfg.Model == "" {
+		return fmt.Errorf("ollama provider requires Model to be set in %s", ConfigFileName)
+	}
+	return nil
+}
+
+func (p *OllamaProvider) Endpoint() string { return p.cfg.BaseURL }
+
+// HealthCheck confirms Ollama's API is reachable at BaseURL.
+func (p *OllamaProvider) HealthCheck(ctx context.Context) error {
+	return checkEndpointReachable(ctx, p.client, p.cfg.BaseURL+"/api/tags", nil)
+}
@@ -0,0 +1,42 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// OpenAIProvider talks to any OpenAI-compatible HTTP endpoint (OpenAI
+// itself, or a self-hosted compatible server).
+type OpenAIProvider struct {
+	cfg    OpenAIConfig
+	client *http.Client
+}
+
+// NewOpenAIProvider returns an OpenAIProvider for the given config.
+func NewOpenAIProvider(cfg OpenAIConfig) *OpenAIProvider {
+	return &OpenAIProvider{cfg: cfg, client: &http.Client{Timeout: healthCheckTimeout}}
+}
+
+func (p *OpenAIProvider) Name() string { return "openai" }
+
+// Prepare validates that a base URL and API key are configured; there's
+// nothing to download for a remote endpoint.
+func (p *OpenAIProvider) Prepare(ctx context.Context) error {
+	if p.cfg.BaseURL == "" {
+		return fmt.Errorf("openai provider requires BaseURL to be set in %s", ConfigFileName)
+	}
+	if p.cfg.APIKey == "" {
+		return fmt.Errorf("openai provider requires APIKey to be set in %s", ConfigFileName)
+	}
+	return nil
+}
+
+func (p *OpenAIProvider) Endpoint() string { return p.cfg.BaseURL }
+
+// HealthCheck confirms the endpoint is reachable and the API key is
+// accepted by listing models.
+func (p *OpenAIProvider) HealthCheck(ctx context.Context) error {
+	headers := map[string]string{"Authorization": "Bearer " + p.cfg.APIKey}
+	return checkEndpointReachable(ctx, p.client, p.cfg.BaseURL+"/v1/models", headers)
+}
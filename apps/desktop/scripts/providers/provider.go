@@ -0,0 +1,33 @@
+// Package providers abstracts over where the desktop app's local AI
+// features get their model server from: a locally downloaded llama-server
+// binary, a local Ollama install, or any OpenAI-compatible HTTP endpoint.
+// Which one is active is controlled by model-provider.toml (see Load) so
+// Linux/Windows users - who can't use the macOS-only llama-server binary -
+// can point at a remote endpoint instead of getting a placeholder file.
+package providers
+
+import "context"
+
+// ModelProvider is one way of serving the model the desktop app's AI
+// features talk to. Prepare does whatever one-time setup the provider
+// needs (downloading a binary, or just validating that a remote endpoint
+// is configured) before Endpoint is used.
+type ModelProvider interface {
+	// Name identifies the provider, e.g. for log output and the "provider"
+	// field in model-provider.toml.
+	Name() string
+
+	// Prepare makes the provider ready to serve requests. For the local
+	// provider this downloads and checksum-verifies the llama-server
+	// binary; for a remote provider it validates that the configured
+	// URL/credentials are present.
+	Prepare(ctx context.Context) error
+
+	// Endpoint returns the base URL the desktop app should talk to once
+	// Prepare has succeeded.
+	Endpoint() string
+
+	// HealthCheck reports whether the provider's endpoint is currently
+	// reachable and responding.
+	HealthCheck(ctx context.Context) error
+}
@@ -0,0 +1,17 @@
+package providers
+
+import "fmt"
+
+// New builds the ModelProvider selected by cfg.Provider.
+func New(cfg *Config) (ModelProvider, error) {
+	switch cfg.Provider {
+	case "", "local":
+		return NewLocalProvider(cfg.Local), nil
+	case "ollama":
+		return NewOllamaProvider(cfg.Ollama), nil
+	case "openai":
+		return NewOpenAIProvider(cfg.OpenAI), nil
+	default:
+		return nil, fmt.Errorf("unknown provider %q in %s (want local, ollama, or openai)", cfg.Provider, ConfigFileName)
+	}
+}
@@ -0,0 +1,42 @@
+package providers
+
+import "testing"
+
+func TestNew_DefaultsToLocalProvider(t *testing.T) {
+	p, err := New(&Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "local" {
+		t.Errorf("expected local provider, got %q", p.Name())
+	}
+}
+
+func TestNew_SelectsOllamaProvider(t *testing.T) {
+	p, err := New(&Config{Provider: "ollama", Ollama: OllamaConfig{BaseURL: "http://x", Model: "llama3"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "ollama" {
+		t.Errorf("expected ollama provider, got %q", p.Name())
+	}
+	if p.Endpoint() != "http://x" {
+		t.Errorf("unexpected endpoint: %q", p.Endpoint())
+	}
+}
+
+func TestNew_SelectsOpenAIProvider(t *testing.T) {
+	p, err := New(&Config{Provider: "openai", OpenAI: OpenAIConfig{BaseURL: "http://x", APIKey: "sk"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "openai" {
+		t.Errorf("expected openai provider, got %q", p.Name())
+	}
+}
+
+func TestNew_UnknownProviderReturnsError(t *testing.T) {
+	if _, err := New(&Config{Provider: "carrier-pigeon"}); err == nil {
+		t.Fatal("expected an error for an unknown provider")
+	}
+}
@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOllamaProvider_PrepareRequiresModel(t *testing.T) {
+	p := NewOllamaProvider(OllamaConfig{BaseURL: "http://x"})
+	if err := p.Prepare(context.Background()); err == nil {
+		t.Fatal("expected an error when Model is unset")
+	}
+}
+
+func TestOllamaProvider_HealthCheckReportsUnreachableEndpoint(t *testing.T) {
+	p := NewOllamaProvider(OllamaConfig{BaseURL: "http://127.0.0.1:1", Model: "llama3"})
+	if err := p.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected an error for an unreachable endpoint")
+	}
+}
+
+func TestOllamaProvider_HealthCheckSucceedsAgainstFakeServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := NewOllamaProvider(OllamaConfig{BaseURL: srv.URL, Model: "llama3"})
+	if err := p.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOpenAIProvider_PrepareRequiresAPIKey(t *testing.T) {
+	p := NewOpenAIProvider(OpenAIConfig{BaseURL: "http://x"})
+	if err := p.Prepare(context.Background()); err == nil {
+		t.Fatal("expected an error when APIKey is unset")
+	}
+}
+
+func TestOpenAIProvider_HealthCheckSendsBearerToken(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	p := NewOpenAIProvider(OpenAIConfig{BaseURL: srv.URL, APIKey: "sk-test"})
+	if err := p.HealthCheck(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer sk-test" {
+		t.Errorf("expected Authorization header, got %q", gotAuth)
+	}
+}
+
+func TestOpenAIProvider_HealthCheckReportsNonOKStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	p := NewOpenAIProvider(OpenAIConfig{BaseURL: srv.URL, APIKey: "sk-bad"})
+	if err := p.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected an error for a non-2xx status")
+	}
+}
@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// latestReleaseURL is the GitHub API endpoint for llama.cpp's latest release.
+const latestReleaseURL = "https://api.github.com/repos/ggml-org/llama.cpp/releases/latest"
+
+// assetSuffixes maps a platformKey (see manifest.go) to the suffix of its
+// release asset name, e.g. "llama-b7815-bin-macos-arm64.tar.gz" ends in
+// "bin-macos-arm64.tar.gz" for "darwin-arm64".
+var assetSuffixes = map[string]string{
+	"darwin-arm64":  "bin-macos-arm64.tar.gz",
+	"darwin-amd64":  "bin-macos-x64.tar.gz",
+	"linux-amd64":   "bin-ubuntu-x64.tar.gz",
+	"linux-arm64":   "bin-ubuntu-arm64.tar.gz",
+	"windows-amd64": "bin-win-x64.zip",
+}
+
+type ghRelease struct {
+	TagName string    `json:"tag_name"`
+	Assets  []ghAsset `json:"assets"`
+}
+
+type ghAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// UpdateManifest fetches the latest llama.cpp release from GitHub, downloads
+// each platform's asset to compute its checksum, and returns a Manifest
+// ready to be Saved. This is the -update subcommand's implementation.
+func UpdateManifest(ctx context.Context) (*Manifest, error) {
+	release, err := fetchLatestRelease(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{Version: release.TagName, Platforms: map[string]ManifestEntry{}}
+	for plat, suffix := range assetSuffixes {
+		asset := findAsset(release.Assets, suffix)
+		if asset == nil {
+			fmt.Printf("no %s asset found in release %s, skipping %s\n", suffix, release.TagName, plat)
+			continue
+		}
+
+		fmt.Printf("Computing checksum for %s (%s)...\n", plat, asset.Name)
+		checksum, err := checksumRemoteFile(ctx, asset.BrowserDownloadURL)
+		if err != nil {
+			return nil, fmt.Errorf("checksum %s: %w", asset.Name, err)
+		}
+
+		manifest.Platforms[plat] = ManifestEntry{
+			URL:           asset.BrowserDownloadURL,
+			SHA256:        checksum,
+			ArchiveLayout: archiveLayoutFor(suffix),
+			BinaryPath:    binaryPathFor(plat),
+		}
+	}
+	return manifest, nil
+}
+
+func archiveLayoutFor(assetSuffix string) string {
+	if strings.HasSuffix(assetSuffix, ".zip") {
+		return "zip"
+	}
+	return "tar.gz"
+}
+
+func binaryPathFor(plat string) string {
+	if strings.HasPrefix(plat, "windows-") {
+		return "llama-server.exe"
+	}
+	return "build/bin/llama-server"
+}
+
+func findAsset(assets []ghAsset, suffix string) *ghAsset {
+	for i := range assets {
+		if strings.HasSuffix(assets[i].Name, suffix) {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+func fetchLatestRelease(ctx context.Context) (*ghRelease, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch latest release: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch latest release: http status %s", resp.Status)
+	}
+
+	var release ghRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("decode release: %w", err)
+	}
+	return &release, nil
+}
+
+func checksumRemoteFile(ctx context.Context, url string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("get %s: http status %s", url, resp.Status)
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, resp.Body); err != nil {
+		return "", fmt.Errorf("read %s: %w", url, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -1,22 +1,46 @@
 // Preview server for Listmonk email templates.
 // Renders the actual Go templates with sample data so you can iterate
-// without deploying.
+// without deploying. Every preview is declared by a fixtures/<name>.json
+// file (see fixture) pointing at the template and sample data to render it
+// with — register a new preview by dropping a template plus its matching
+// fixtures/<name>.json, no Go code required.
 //
 // Usage: `cd infra/listmonk/preview && go run .`
 // Then open http://localhost:9900
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
 )
 
 const port = "9900"
 
+// templatesDir is where parseSystemTemplates looks for the shared base
+// layout plus every individual transactional email — see the "system"
+// fixture kind.
+const templatesDir = "../email-templates"
+
+// fixturesDir holds one JSON file per preview (see fixture), each naming
+// the .html template it renders and the sample data to render it with.
+const fixturesDir = "fixtures"
+
+// reloadDebounce coalesces the burst of fsnotify events a single save
+// generates (write + chmod on most editors) into one reload push, matching
+// scripts/check/serve.go's watchDebounce.
+const reloadDebounce = 150 * time.Millisecond
+
 // ---------------------------------------------------------------------------
 // Localizer mock — same interface as listmonk's L template object
 // ---------------------------------------------------------------------------
@@ -30,7 +54,19 @@ func (l *localizer) T(key string) string {
 	return "[" + key + "]"
 }
 
-func (l *localizer) Ts(key string) string { return l.T(key) }
+// Ts is T plus Listmonk's Ts convention of sprintf-style positional args
+// (real Listmonk uses it for strings like "email.optin.confirmSubInfo" that
+// need a list name or count interpolated in). A key with no matching
+// fixture's %-verbs and a nonzero arg count just prints fmt.Sprintf's own
+// %!(EXTRA ...) marker, which is a more useful failure mode for a preview
+// tool than silently dropping the args.
+func (l *localizer) Ts(key string, args ...any) string {
+	v := l.T(key)
+	if len(args) == 0 {
+		return v
+	}
+	return fmt.Sprintf(v, args...)
+}
 
 var loc = &localizer{t: map[string]string{
 	"email.optin.confirmSubTitle":   "Confirm subscription",
@@ -47,11 +83,14 @@ var loc = &localizer{t: map[string]string{
 // Main
 // ---------------------------------------------------------------------------
 
+var reload = newReloadHub()
+
 func main() {
+	go watchTemplates()
+
 	mux := http.NewServeMux()
-	mux.HandleFunc("/", handleIndex)
-	mux.HandleFunc("/optin", handleOptin)
-	mux.HandleFunc("/campaign", handleCampaign)
+	mux.HandleFunc("/", handlePreview)
+	mux.HandleFunc("/__events", reload.serveHTTP)
 
 	fmt.Printf("Email template preview → http://localhost:%s\n", port)
 	if err := http.ListenAndServe(":"+port, mux); err != nil {
@@ -60,17 +99,207 @@ func main() {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// Live reload
+// ---------------------------------------------------------------------------
+
+// reloadHub is a minimal version of cmdr serve's serveState subscriber set
+// (see scripts/check/serve.go's serveEvents): no results to track here, just
+// a fan-out "reload" push to every connected /__events client.
+type reloadHub struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]bool
+}
+
+func newReloadHub() *reloadHub {
+	return &reloadHub{subs: map[chan struct{}]bool{}}
+}
+
+func (h *reloadHub) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	h.mu.Lock()
+	h.subs[ch] = true
+	h.mu.Unlock()
+	return ch
+}
+
+func (h *reloadHub) unsubscribe(ch chan struct{}) {
+	h.mu.Lock()
+	delete(h.subs, ch)
+	h.mu.Unlock()
+}
+
+func (h *reloadHub) broadcast() {
+	h.mu.Lock()
+	subs := make([]chan struct{}, 0, len(h.subs))
+	for ch := range h.subs {
+		subs = append(subs, ch)
+	}
+	h.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- struct{}{}:
+		default: // a client that's behind just misses this tick; the next push still reloads it
+		}
+	}
+}
+
+// serveHTTP is the SSE endpoint liveReloadScript's EventSource subscribes
+// to: one "reload" event per watched-file change, telling the page to
+// location.reload() rather than trying to patch the DOM in place.
+func (h *reloadHub) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := h.subscribe()
+	defer h.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			fmt.Fprint(w, "event: reload\ndata: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// liveReloadScript is injected into every rendered page. If fsnotify isn't
+// available (watchTemplates logs and returns without ever calling
+// reload.broadcast), the connection just sits open and the page falls back
+// to the existing re-parse-on-every-request behavior: edits still show up,
+// just on a manual refresh.
+const liveReloadScript = `
+<script>
+new EventSource("/__events").addEventListener("reload", () => location.reload());
+</script>
+`
+
+// injectLiveReloadScript inserts liveReloadScript right before </body>, or
+// appends it if the page has no </body> (e.g. a render-error page).
+func injectLiveReloadScript(html string) string {
+	if i := strings.LastIndex(html, "</body>"); i != -1 {
+		return html[:i] + liveReloadScript + html[i:]
+	}
+	return html + liveReloadScript
+}
+
+// watchTemplates fsnotify-watches templatesDir, fixturesDir, and the
+// directory of every standalone-kind fixture's template (the only places a
+// render reads from — see fixture.render), and broadcasts a reload once per
+// reloadDebounce window. A missing fsnotify (some container filesystems
+// don't support inotify) just disables live reload — the handlers'
+// re-parse-on-every-request behavior still serves current content on a
+// manual refresh.
+func watchTemplates() {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "live reload disabled, fsnotify unavailable: %v\n", err)
+		return
+	}
+	defer watcher.Close()
+
+	for dir := range watchDirs() {
+		if err := watcher.Add(dir); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "live reload disabled, failed to watch %s: %v\n", dir, err)
+			return
+		}
+	}
+
+	var timer *time.Timer
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(reloadDebounce, reload.broadcast)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "watch error: %v\n", err)
+		}
+	}
+}
+
+// watchDirs returns the set of directories watchTemplates needs watched:
+// templatesDir, fixturesDir itself (so adding/editing a fixture's JSON also
+// reloads the page), and every standalone fixture's template directory. A
+// fixture load error just means watchDirs falls back to the two static
+// directories — watchTemplates logs its own Add failures already, this
+// isn't worth a second error path.
+func watchDirs() map[string]bool {
+	dirs := map[string]bool{templatesDir: true, fixturesDir: true}
+	fixtures, err := loadFixtures(fixturesDir)
+	if err != nil {
+		return dirs
+	}
+	for _, fx := range fixtures {
+		if fx.Kind == fixtureKindStandalone {
+			dirs[filepath.Dir(fx.Template)] = true
+		}
+	}
+	return dirs
+}
+
 // ---------------------------------------------------------------------------
 // Handlers
 // ---------------------------------------------------------------------------
 
-func handleIndex(w http.ResponseWriter, r *http.Request) {
-	if r.URL.Path != "/" {
-		http.NotFound(w, r)
+// handlePreview serves the index at "/" and, for every other path, looks up
+// the fixture whose Name matches and renders it — replacing the old
+// hardcoded handleIndex/handleOptin/handleCampaign trio now that both the
+// index and the set of previews come from fixturesDir.
+func handlePreview(w http.ResponseWriter, r *http.Request) {
+	fixtures, err := loadFixtures(fixturesDir)
+	if err != nil {
+		http.Error(w, "Fixture error:\n"+err.Error(), 500)
 		return
 	}
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	_, err := fmt.Fprint(w, `<!doctype html>
+
+	if r.URL.Path == "/" {
+		writeHTML(w, renderIndex(fixtures))
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	for _, fx := range fixtures {
+		if fx.Name != name {
+			continue
+		}
+		html, err := fx.render()
+		if err != nil {
+			http.Error(w, "Render error:\n"+err.Error(), 500)
+			return
+		}
+		writeHTML(w, html)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// renderIndex lists every fixture as a link, so adding a fixtures/*.json
+// file is enough to get it listed here — no index edit required.
+func renderIndex(fixtures []fixture) string {
+	var items strings.Builder
+	for _, fx := range fixtures {
+		fmt.Fprintf(&items, "<li><a href=\"/%s\">%s</a> — <code>fixtures/%s.json</code></li>\n", fx.Name, fx.Title, fx.Name)
+	}
+
+	return fmt.Sprintf(`<!doctype html>
 <html><head><title>Email template preview</title>
 <style>
 body { font-family: system-ui, sans-serif; max-width: 480px; margin: 60px auto; color: #333; }
@@ -82,73 +311,164 @@ code { background: #f0f0f0; padding: 2px 6px; border-radius: 3px; font-size: 13p
 </style></head><body>
 <h1>Email template preview</h1>
 <ul>
-<li><a href="/optin">Opt-in confirmation</a> — <code>email-templates/subscriber-optin.html</code></li>
-<li><a href="/campaign">Campaign newsletter</a> — <code>campaign-template.html</code></li>
-</ul>
-<p class="hint">Edit the template files, then refresh the browser to see changes.</p>
-</body></html>`)
-	if err != nil {
+%s</ul>
+<p class="hint">Edit a template or its fixtures/*.json, then save — the page reloads itself.
+To add a new preview, drop a &lt;name&gt;.html template and a matching fixtures/&lt;name&gt;.json.</p>
+</body></html>`, items.String())
+}
+
+// writeHTML writes html to w with the live-reload script injected, the one
+// path every handler's successful render goes through.
+func writeHTML(w http.ResponseWriter, html string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := fmt.Fprint(w, injectLiveReloadScript(html)); err != nil {
 		_, _ = fmt.Fprintf(os.Stderr, "error: %v\n", err)
 	}
 }
 
-func handleOptin(w http.ResponseWriter, _ *http.Request) {
-	tmpl, err := parseSystemTemplates("../email-templates")
+// ---------------------------------------------------------------------------
+// Fixtures
+// ---------------------------------------------------------------------------
+
+// fixtureKind selects how fixture.render parses and executes Template.
+type fixtureKind string
+
+const (
+	// fixtureKindSystem executes TemplateName within the whole templatesDir
+	// set (see parseSystemTemplates) — for a transactional email that shares
+	// templatesDir's base layout with the others.
+	fixtureKindSystem fixtureKind = "system"
+	// fixtureKindStandalone parses Template on its own (see
+	// parseStandaloneTemplate) — for a self-contained template like
+	// campaign-template.html that isn't part of templatesDir's set.
+	fixtureKindStandalone fixtureKind = "standalone"
+)
+
+// fixture is the decoded shape of one fixtures/<name>.json file. Name is
+// the file's base name (without .json), not a JSON field, so a fixture
+// can't claim a Name that disagrees with where it lives on disk.
+type fixture struct {
+	Name  string      `json:"-"`
+	Title string      `json:"title"`
+	Kind  fixtureKind `json:"kind"`
+
+	// Template is required for fixtureKindStandalone (a path relative to
+	// this package's directory, e.g. "../campaign-template.html") and
+	// ignored for fixtureKindSystem, which always reads templatesDir.
+	Template string `json:"template,omitempty"`
+	// TemplateName is required for fixtureKindSystem: the named template
+	// within templatesDir's set to execute (e.g. "subscriber-optin").
+	TemplateName string `json:"template_name,omitempty"`
+	// Content, if set, is parsed as the standalone template's "content"
+	// block (see parseStandaloneTemplate) — the campaign layout's
+	// {{ template "content" . }} hole. Ignored for fixtureKindSystem.
+	Content string `json:"content,omitempty"`
+
+	// Data is merged onto {"L": loc} as the template's root data, so a
+	// fixture never has to know loc exists.
+	Data map[string]any `json:"data"`
+}
+
+// loadFixtures decodes every fixturesDir/*.json file, sorted by name so
+// renderIndex lists them in a stable order.
+func loadFixtures(dir string) ([]fixture, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
 	if err != nil {
-		http.Error(w, "Template parse error:\n"+err.Error(), 500)
-		return
+		return nil, err
 	}
+	sort.Strings(files)
 
-	data := map[string]any{
-		"L": loc,
-		"Subscriber": map[string]string{
-			"FirstName": "Alex",
-			"Email":     "alex@example.com",
-			"Name":      "Alex Johnson",
-		},
-		"Lists": []map[string]string{
-			{"Name": "Cmdr newsletter", "Type": "public"},
-			{"Name": "Beta testers", "Type": "private"},
-		},
-		"OptinURL": "#confirm",
-		"UnsubURL": "#unsubscribe",
+	fixtures := make([]fixture, 0, len(files))
+	for _, f := range files {
+		raw, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var fx fixture
+		if err := json.Unmarshal(raw, &fx); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+		fx.Name = strings.TrimSuffix(filepath.Base(f), ".json")
+		if fx.Kind != fixtureKindSystem && fx.Kind != fixtureKindStandalone {
+			return nil, fmt.Errorf("%s: unknown kind %q (want %q or %q)", filepath.Base(f), fx.Kind, fixtureKindSystem, fixtureKindStandalone)
+		}
+		fixtures = append(fixtures, fx)
 	}
+	return fixtures, nil
+}
 
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := tmpl.ExecuteTemplate(w, "subscriber-optin", data); err != nil {
-		http.Error(w, "Render error:\n"+err.Error(), 500)
+// render parses and executes fx's template, re-parsing from disk every
+// call (same re-parse-on-every-request tradeoff parseSystemTemplates always
+// made, now shared by both fixture kinds) so edits show up without a
+// restart.
+func (fx fixture) render() (string, error) {
+	data := map[string]any{"L": loc}
+	for k, v := range fx.Data {
+		data[k] = v
 	}
-}
 
-func handleCampaign(w http.ResponseWriter, _ *http.Request) {
-	tmpl, err := parseCampaignTemplate("../campaign-template.html")
+	var tmpl *template.Template
+	var err error
+	var executeName string
+	switch fx.Kind {
+	case fixtureKindSystem:
+		tmpl, err = parseSystemTemplates(templatesDir)
+		executeName = fx.TemplateName
+	case fixtureKindStandalone:
+		tmpl, err = parseStandaloneTemplate(fx.Template, fx.Content)
+		executeName = ""
+	}
 	if err != nil {
-		http.Error(w, "Template parse error:\n"+err.Error(), 500)
-		return
+		return "", err
 	}
 
-	data := map[string]any{
-		"L": loc,
-		"Campaign": map[string]any{
-			"Subject": "What's new in Cmdr — February 2026",
-			"Attribs": map[string]string{
-				"preheader": "Fresh features, bug fixes, and what's coming next.",
-			},
-		},
+	var buf bytes.Buffer
+	if executeName != "" {
+		err = tmpl.ExecuteTemplate(&buf, executeName, data)
+	} else {
+		err = tmpl.Execute(&buf, data)
 	}
-
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	if err := tmpl.Execute(w, data); err != nil {
-		http.Error(w, "Render error:\n"+err.Error(), 500)
+	if err != nil {
+		return "", err
 	}
+	return buf.String(), nil
 }
 
 // ---------------------------------------------------------------------------
 // Template loading
 // ---------------------------------------------------------------------------
 
+// sharedFuncMap is the set of helpers real Listmonk campaign and
+// transactional templates call beyond the L localizer — a minimal preview
+// stand-in for each, not a faithful reimplementation of Listmonk's actual
+// runtime behavior:
+//   - Date formats the current time with a Go reference-time layout, for
+//     templates that stamp e.g. a copyright year or send date.
+//   - Safe is a no-op: text/template (unlike html/template) never
+//     auto-escapes, so there's nothing to mark safe, but real templates
+//     still call it and it must exist to parse.
+//   - RootURL/MediaURL stand in for Listmonk's site root and media-upload
+//     base URL.
+//   - Add/Sub cover the small amount of int arithmetic templates do inline
+//     (e.g. numbering list items). Attribs lookups (.Subscriber.Attribs.x,
+//     .Campaign.Attribs.x) need no helper: they're plain map access, already
+//     handled by the `index` builtin or dot notation on the fixture's Data.
+func sharedFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"UnsubscribeURL": func() string { return "#unsubscribe" },
+		"MessageURL":     func() string { return "#message" },
+		"TrackView":      func() string { return "" },
+		"Date":           func(layout string) string { return time.Now().Format(layout) },
+		"Safe":           func(s string) string { return s },
+		"RootURL":        func() string { return "https://example.com" },
+		"MediaURL":       func(path string) string { return "https://example.com/uploads/" + strings.TrimPrefix(path, "/") },
+		"Add":            func(a, b int) int { return a + b },
+		"Sub":            func(a, b int) int { return a - b },
+	}
+}
+
 // parseSystemTemplates loads email-templates/*.html (base + individual emails).
-// Templates are re-parsed on every request so edits show up on browser refresh.
+// Templates are re-parsed on every request so edits show up immediately.
 func parseSystemTemplates(dir string) (*template.Template, error) {
 	files, err := filepath.Glob(filepath.Join(dir, "*.html"))
 	if err != nil {
@@ -158,7 +478,7 @@ func parseSystemTemplates(dir string) (*template.Template, error) {
 		return nil, fmt.Errorf("no .html files in %s", dir)
 	}
 
-	tmpl := template.New("root")
+	tmpl := template.New("root").Funcs(sharedFuncMap())
 	for _, f := range files {
 		raw, err := os.ReadFile(f)
 		if err != nil {
@@ -172,27 +492,24 @@ func parseSystemTemplates(dir string) (*template.Template, error) {
 	return tmpl, nil
 }
 
-// parseCampaignTemplate loads campaign-template.html and injects a sample
-// "content" block so the template renders end-to-end.
-func parseCampaignTemplate(file string) (*template.Template, error) {
+// parseStandaloneTemplate loads a single .html file not part of
+// templatesDir's set (e.g. campaign-template.html) and, if contentHTML is
+// set, injects it as the template's "content" block.
+func parseStandaloneTemplate(file, contentHTML string) (*template.Template, error) {
 	raw, err := os.ReadFile(file)
 	if err != nil {
 		return nil, err
 	}
 
 	src := rewriteLocalizerCalls(string(raw))
-	funcMap := template.FuncMap{
-		"UnsubscribeURL": func() string { return "#unsubscribe" },
-		"MessageURL":     func() string { return "#message" },
-		"TrackView":      func() string { return "" },
-	}
-
-	tmpl, err := template.New("campaign").Funcs(funcMap).Parse(src)
+	tmpl, err := template.New(filepath.Base(file)).Funcs(sharedFuncMap()).Parse(src)
 	if err != nil {
 		return nil, err
 	}
-	if _, err := tmpl.New("content").Parse(sampleCampaignContent); err != nil {
-		return nil, err
+	if contentHTML != "" {
+		if _, err := tmpl.New("content").Parse(contentHTML); err != nil {
+			return nil, err
+		}
 	}
 	return tmpl, nil
 }
@@ -203,45 +520,3 @@ func parseCampaignTemplate(file string) (*template.Template, error) {
 func rewriteLocalizerCalls(src string) string {
 	return strings.ReplaceAll(src, "{{ L.", "{{ $.L.")
 }
-
-// ---------------------------------------------------------------------------
-// Sample content — exercises most CSS styles in the campaign template
-// ---------------------------------------------------------------------------
-
-const sampleCampaignContent = `
-<h1>What's new in Cmdr</h1>
-<p>Hey Alex,</p>
-<p>Here's what we've been up to this month. Cmdr keeps getting faster, smarter,
-and more keyboard-friendly.</p>
-
-<h2>Highlights</h2>
-<ul>
-<li><strong>Batch rename</strong> — rename dozens of files with a single pattern.
-<a href="https://getcmdr.com">Learn more</a></li>
-<li><strong>Quick preview</strong> — press Space to preview any file without
-leaving the file list</li>
-<li><strong>Faster SMB</strong> — network folder loading is now 3x faster on
-large shares</li>
-</ul>
-
-<blockquote>
-"I switched from Forklift and haven't looked back. The speed difference is
-insane."<br>— A happy Cmdr user
-</blockquote>
-
-<h2>Try it out</h2>
-<p>Update to the latest version to get all these improvements:</p>
-<p><a href="https://getcmdr.com" class="button">Download Cmdr</a></p>
-
-<hr>
-
-<h3>A bit of code</h3>
-<pre>cmdr --version
-Cmdr 2.4.0 (build 1337)</pre>
-
-<h3>Coming next</h3>
-<p>We're working on <strong>tabs</strong>, <strong>bookmarks</strong>, and a
-built-in <code>terminal</code> panel. Stay tuned!</p>
-
-<p>Happy file managing,<br>The Cmdr team</p>
-`
@@ -0,0 +1,87 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"vmail/scripts/check/checks/config"
+)
+
+// baselineFileName is the file RunFileLength ratchets long-file warnings against.
+const baselineFileName = ".cmdr-baseline.json"
+
+// BaselineEntry records a single grandfathered file's line count at the time
+// the baseline was written.
+type BaselineEntry struct {
+	Lines int `json:"lines"`
+}
+
+// Baseline is the on-disk shape of .cmdr-baseline.json.
+type Baseline struct {
+	Files map[string]BaselineEntry `json:"files"`
+}
+
+func emptyBaseline() *Baseline {
+	return &Baseline{Files: map[string]BaselineEntry{}}
+}
+
+// LoadBaseline reads .cmdr-baseline.json from rootDir. A missing file is not
+// an error — it's treated as an empty baseline, which is what a fresh repo
+// (or one that's never run `cmdr baseline update`) looks like.
+func LoadBaseline(rootDir string) (*Baseline, error) {
+	data, err := os.ReadFile(filepath.Join(rootDir, baselineFileName))
+	if os.IsNotExist(err) {
+		return emptyBaseline(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", baselineFileName, err)
+	}
+	if b.Files == nil {
+		b.Files = map[string]BaselineEntry{}
+	}
+	return &b, nil
+}
+
+// SaveBaseline writes b to .cmdr-baseline.json in rootDir, pretty-printed so
+// diffs in code review stay readable.
+func SaveBaseline(rootDir string, b *Baseline) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	return os.WriteFile(filepath.Join(rootDir, baselineFileName), data, 0644)
+}
+
+// WriteFileLengthBaseline scans rootDir for every file currently over the
+// file-length warn threshold and records it (and its current line count) in
+// .cmdr-baseline.json, overwriting whatever was there before. This is what
+// `cmdr baseline update` runs.
+func WriteFileLengthBaseline(rootDir string) (int, error) {
+	cfg, err := config.Load(rootDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	longFiles, err := scanLongFiles(NewOSFS(rootDir), cfg, true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan files: %w", err)
+	}
+
+	b := emptyBaseline()
+	for _, f := range longFiles {
+		b.Files[f.relPath] = BaselineEntry{Lines: f.lines}
+	}
+
+	if err := SaveBaseline(rootDir, b); err != nil {
+		return 0, fmt.Errorf("failed to write %s: %w", baselineFileName, err)
+	}
+	return len(b.Files), nil
+}
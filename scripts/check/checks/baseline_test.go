@@ -0,0 +1,138 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadBaseline_MissingFileReturnsEmpty(t *testing.T) {
+	tmp := t.TempDir()
+
+	b, err := LoadBaseline(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(b.Files) != 0 {
+		t.Errorf("expected empty baseline, got %v", b.Files)
+	}
+}
+
+func TestSaveAndLoadBaseline_RoundTrips(t *testing.T) {
+	tmp := t.TempDir()
+
+	b := emptyBaseline()
+	b.Files["scripts/big.go"] = BaselineEntry{Lines: 950}
+
+	if err := SaveBaseline(tmp, b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := LoadBaseline(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Files["scripts/big.go"].Lines != 950 {
+		t.Errorf("expected 950 lines, got %v", loaded.Files)
+	}
+}
+
+func TestRunFileLength_GrandfathersBaselinedFile(t *testing.T) {
+	tmp := t.TempDir()
+
+	path := filepath.Join(tmp, "long.go")
+	if err := os.WriteFile(path, []byte(strings.Repeat("line\n", 900)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := emptyBaseline()
+	b.Files["long.go"] = BaselineEntry{Lines: 900}
+	if err := SaveBaseline(tmp, b); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: tmp}
+	result, err := RunFileLength(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result.Message, "grandfathered") {
+		t.Errorf("expected grandfathered section, got: %s", result.Message)
+	}
+	if strings.Contains(result.Message, "1 file over 800 lines") {
+		t.Errorf("grandfathered file should not count as fresh, got: %s", result.Message)
+	}
+}
+
+func TestRunFileLength_WarnsWhenGrownPastBaseline(t *testing.T) {
+	tmp := t.TempDir()
+
+	path := filepath.Join(tmp, "long.go")
+	if err := os.WriteFile(path, []byte(strings.Repeat("line\n", 950)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := emptyBaseline()
+	b.Files["long.go"] = BaselineEntry{Lines: 900} // grown since baseline was recorded
+	if err := SaveBaseline(tmp, b); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: tmp}
+	result, err := RunFileLength(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result.Message, "1 file over 800 lines") {
+		t.Errorf("expected file to be reported as fresh since it grew, got: %s", result.Message)
+	}
+}
+
+func TestRunFileLength_NoBaselineFlagIgnoresFile(t *testing.T) {
+	tmp := t.TempDir()
+
+	path := filepath.Join(tmp, "long.go")
+	if err := os.WriteFile(path, []byte(strings.Repeat("line\n", 900)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := emptyBaseline()
+	b.Files["long.go"] = BaselineEntry{Lines: 900}
+	if err := SaveBaseline(tmp, b); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: tmp, NoBaseline: true}
+	result, err := RunFileLength(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(result.Message, "1 file over 800 lines") {
+		t.Errorf("expected --no-baseline to ignore the baseline, got: %s", result.Message)
+	}
+}
+
+func TestWriteFileLengthBaseline_RecordsOverThresholdFiles(t *testing.T) {
+	tmp := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(tmp, "long.go"), []byte(strings.Repeat("line\n", 900)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := WriteFileLengthBaseline(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 baselined file, got %d", count)
+	}
+
+	loaded, err := LoadBaseline(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Files["long.go"].Lines != 900 {
+		t.Errorf("expected 900 lines recorded, got %v", loaded.Files)
+	}
+}
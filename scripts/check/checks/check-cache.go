@@ -0,0 +1,333 @@
+package checks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// checkCacheVersion is bumped whenever checkCacheEntry's shape or
+// fingerprintInputs' inputs change, so every entry an older version wrote is
+// treated as a miss instead of being read back wrong.
+const checkCacheVersion = 1
+
+// goSourceGlobs and rustSourceGlobs are shared between a check's
+// CheckDefinition.Inputs entry in registry.go and its CachedCheck call, so
+// `cmdr watch`'s affected-check computation and CachedCheck's fingerprint
+// can't silently drift apart into disagreeing about what a check depends on.
+// (GetCheckByID can't serve this instead: AllChecks' initializer takes the
+// address of these Run functions, so a Run function calling back into
+// GetCheckByID/AllChecks at runtime is a compile-time initialization cycle,
+// even though the call itself never happens during init.)
+var (
+	goSourceGlobs            = []string{"**/*.go"}
+	rustSourceGlobs          = []string{"apps/desktop/src-tauri/**/*.rs"}
+	desktopSvelteSourceGlobs = []string{"apps/desktop/src/**/*.ts", "apps/desktop/src/**/*.svelte", "apps/desktop/src/**/*.js"}
+
+	// goTestInputGlobs extends goSourceGlobs with go.mod/go.sum: a dependency
+	// bump can change test behavior (or vendor a fix) without touching any
+	// .go file, and RunGoTests' cache entry must miss in that case too.
+	goTestInputGlobs = []string{"**/*.go", "**/go.mod", "**/go.sum"}
+)
+
+// hasherPool reuses sha256 hashers across CachedCheck calls instead of
+// allocating one per invocation; a run with dozens of Go modules (see
+// FindAllGoModules) fingerprints inputs for several checks back to back.
+var hasherPool = sync.Pool{
+	New: func() any { return sha256.New() },
+}
+
+// CachedCheck wraps fn so that re-running it with the same input file
+// contents, toolVersion, and ctx.CI is a cache hit instead of a
+// re-execution. It fingerprints the sorted contents of every file under
+// ctx.RootDir matching one of inputs (the same glob syntax as
+// CheckDefinition.Inputs, see MatchInputGlob) plus toolVersion and ctx.CI —
+// folded in because it's the flag that switches several Run* functions
+// between fixing and check-only mode — and looks the fingerprint up under
+// ~/.cache/cmdr/<name>/<hash>.json. A hit returns
+// Success("cached: "+prevMessage) with the stored Diagnostics, without
+// calling fn; a miss calls fn and, if it succeeds, stores the result for next
+// time.
+//
+// Only successful results are cached — a failing check should always be
+// re-run rather than have its failure silently suppressed until some input
+// changes. Checks that mutate files by default (rustfmt, eslint --fix,
+// stylelint --fix) should only call CachedCheck from their non-mutating (CI)
+// branch, since caching a run that wrote to disk would skip the write next
+// time the inputs are unchanged.
+//
+// toolVersion is a thunk rather than a plain string so that --no-cache skips
+// the subprocess (`go version`, `cargo fmt --version`, ...) most toolVersion
+// implementations shell out to, not just the cache lookup.
+//
+// cacheVersion is folded into the fingerprint alongside the inputs and
+// toolVersion, so bumping it busts every entry a check previously wrote —
+// for when the check's own parsing/flags changed in a way that would make
+// an old cached CheckResult wrong even though none of its input files or
+// tool version did. Most checks pass 0 and never need to bump it.
+func CachedCheck(ctx *CheckContext, name string, inputs []string, toolVersion func() string, cacheVersion int, fn func() (CheckResult, error)) (CheckResult, error) {
+	if ctx.NoCache {
+		return fn()
+	}
+
+	cache, err := openCheckCache(name)
+	if err != nil {
+		return fn()
+	}
+
+	files, err := expandInputFiles(ctx.RootDir, inputs)
+	if err != nil {
+		return fn()
+	}
+
+	fingerprint, err := fingerprintInputs(ctx.RootDir, files, toolVersion(), cacheVersion, ctx.CI)
+	if err != nil {
+		return fn()
+	}
+
+	if entry, ok := cache.get(fingerprint); ok {
+		return CheckResult{Code: ResultSuccess, Message: "cached: " + entry.Message, Diagnostics: entry.Diagnostics}, nil
+	}
+
+	result, err := fn()
+	if err != nil {
+		return result, err
+	}
+
+	_ = cache.put(fingerprint, checkCacheEntry{Message: result.Message, Diagnostics: result.Diagnostics})
+	return result, nil
+}
+
+// expandInputFiles walks rootDir and returns the root-relative,
+// slash-separated paths of every regular file matching at least one of
+// patterns, sorted so two runs over an unchanged tree fingerprint identically
+// regardless of directory-walk order. Skips dot-directories and
+// fileLengthSkipDirs (node_modules, target, build, dist) the same way
+// scanLongFiles does, so fingerprinting a check's inputs doesn't also walk
+// every build artifact and vendored dependency in the repo.
+func expandInputFiles(rootDir string, patterns []string) ([]string, error) {
+	var matches []string
+	err := filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if name != "." && (strings.HasPrefix(name, ".") || fileLengthSkipDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		for _, pattern := range patterns {
+			if MatchInputGlob(pattern, rel) {
+				matches = append(matches, rel)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// fingerprintInputs hashes each file's path and content (files must already
+// be sorted, as expandInputFiles returns them), then toolVersion,
+// cacheVersion, ci, and runningBuildID(), into a single hex-encoded sha256
+// digest.
+func fingerprintInputs(rootDir string, files []string, toolVersion string, cacheVersion int, ci bool) (string, error) {
+	hasher := hasherPool.Get().(hash.Hash)
+	hasher.Reset()
+	defer hasherPool.Put(hasher)
+
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(rootDir, rel))
+		if err != nil {
+			return "", err
+		}
+		hasher.Write([]byte(rel))
+		hasher.Write(data)
+	}
+	hasher.Write([]byte(toolVersion))
+	hasher.Write([]byte(runningBuildID()))
+	fmt.Fprintf(hasher, "cacheVersion=%d,ci=%v", cacheVersion, ci)
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// buildIDOnce memoizes runningBuildID's result: os.Executable plus a full
+// file hash is too slow to redo for every check CachedCheck wraps in a
+// single invocation (AllChecks runs dozens of them).
+var buildIDOnce struct {
+	sync.Once
+	id string
+}
+
+// runningBuildID fingerprints the currently-running cmdr binary itself, so
+// that every CachedCheck entry misses the moment cmdr is rebuilt — a stale
+// cache surviving a cmdr update could serve a result from before a check's
+// own parsing or flags changed, same risk CachedCheck's cacheVersion param
+// guards against for a single check, but for the binary as a whole. Hashing
+// the executable's bytes (rather than, say, a version string or VCS
+// revision from debug.ReadBuildInfo) catches `go build`-from-source
+// invocations too, which carry no embedded version info. A lookup failure
+// (os.Executable erroring, the binary having been removed since exec) falls
+// back to the empty string rather than disabling caching altogether.
+func runningBuildID() string {
+	buildIDOnce.Do(func() {
+		path, err := os.Executable()
+		if err != nil {
+			return
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return
+		}
+		sum := sha256.Sum256(data)
+		buildIDOnce.id = hex.EncodeToString(sum[:])
+	})
+	return buildIDOnce.id
+}
+
+// checkCacheEntry is the on-disk JSON shape of one CachedCheck cache file.
+type checkCacheEntry struct {
+	Version     int          `json:"version"`
+	Message     string       `json:"message"`
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// checkCache is the content-addressed on-disk cache CachedCheck reads and
+// writes through: one JSON file per (check name, input fingerprint), under
+// ~/.cache/cmdr/<name>/<fingerprint>.json.
+type checkCache struct {
+	dir string
+}
+
+// openCheckCache resolves the cache directory for name (see cmdrCacheDir) and
+// ensures it exists.
+func openCheckCache(name string) (*checkCache, error) {
+	dir, err := cmdrCacheDir(name)
+	if err != nil {
+		return nil, err
+	}
+	return &checkCache{dir: dir}, nil
+}
+
+// get looks up the cached entry for fingerprint, returning ok=false on a
+// miss: no entry, unreadable, corrupt, or written by a different
+// checkCacheVersion. A hit bumps the entry's mtime so CleanCache's
+// --older-than treats it as recently used rather than stale.
+func (c *checkCache) get(fingerprint string) (entry checkCacheEntry, ok bool) {
+	path := c.entryPath(fingerprint)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return checkCacheEntry{}, false
+	}
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Version != checkCacheVersion {
+		return checkCacheEntry{}, false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return entry, true
+}
+
+// put writes entry to the cache under fingerprint. c.dir is already created
+// by openCheckCache, so this only needs to write the file.
+func (c *checkCache) put(fingerprint string, entry checkCacheEntry) error {
+	entry.Version = checkCacheVersion
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.entryPath(fingerprint), raw, 0644)
+}
+
+func (c *checkCache) entryPath(fingerprint string) string {
+	return filepath.Join(c.dir, fingerprint+".json")
+}
+
+// cmdrCacheDir resolves a subdirectory of cmdr's on-disk cache root —
+// $XDG_CACHE_HOME/cmdr/<sub> if XDG_CACHE_HOME is set, otherwise
+// os.UserCacheDir()/cmdr/<sub> — and ensures it exists. Shared by
+// openCheckCache and openAnalysisCache so both caches live under the same
+// ~/.cache/cmdr root.
+func cmdrCacheDir(sub string) (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		base = userCacheDir
+	}
+
+	dir := filepath.Join(base, "cmdr", sub)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// CacheDir exposes cmdrCacheDir to callers outside this package — `cmdr
+// history` uses it to find the run records RunRecord writes under the
+// "runs" subdirectory.
+func CacheDir(sub string) (string, error) {
+	return cmdrCacheDir(sub)
+}
+
+// ClearCache removes every cached entry for one check, for `cmdr cache clear
+// <check>` — unlike CleanCache's age-based sweep, this drops everything
+// regardless of mtime, e.g. after a known-bad result got cached before a fix
+// landed and waiting out --older-than isn't good enough. name is the same
+// check name CachedCheck was called with (a CheckDefinition's ID, by
+// convention). Removing a cache directory that was never created (a check
+// that's never been cached, or a typo'd name) is not an error.
+func ClearCache(name string) error {
+	dir, err := cmdrCacheDir(name)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// CleanCache removes every file under ~/.cache/cmdr (both CachedCheck's
+// entries and RunCfgGate's analysis cache) whose modification time is older
+// than olderThan, for the `cmdr cache clean` subcommand. Returns the number
+// of files removed.
+func CleanCache(olderThan time.Duration) (int, error) {
+	root, err := cmdrCacheDir(".")
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	removed := 0
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		if info.ModTime().Before(cutoff) {
+			if rmErr := os.Remove(path); rmErr == nil {
+				removed++
+			}
+		}
+		return nil
+	})
+	return removed, err
+}
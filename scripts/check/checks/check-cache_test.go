@@ -0,0 +1,188 @@
+package checks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// --- CachedCheck ---
+
+func testToolVersion() string { return "v1" }
+
+func TestCachedCheck_MissCallsFnAndCachesResult(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	rootDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(rootDir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := &CheckContext{RootDir: rootDir}
+
+	calls := 0
+	fn := func() (CheckResult, error) {
+		calls++
+		return Success("did the work"), nil
+	}
+
+	result, err := CachedCheck(ctx, "mycheck", []string{"**/*.go"}, testToolVersion, 0, fn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected fn to run once on a miss, ran %d times", calls)
+	}
+	if result.Message != "did the work" {
+		t.Errorf("expected the uncached message on a miss, got %q", result.Message)
+	}
+
+	result, err = CachedCheck(ctx, "mycheck", []string{"**/*.go"}, testToolVersion, 0, fn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected fn to stay uncalled on a hit, ran %d times total", calls)
+	}
+	if result.Message != "cached: did the work" {
+		t.Errorf("expected a cached message on a hit, got %q", result.Message)
+	}
+}
+
+func TestCachedCheck_ChangedInputInvalidatesCache(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	rootDir := t.TempDir()
+	path := filepath.Join(rootDir, "main.go")
+	if err := os.WriteFile(path, []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := &CheckContext{RootDir: rootDir}
+
+	calls := 0
+	fn := func() (CheckResult, error) {
+		calls++
+		return Success("did the work"), nil
+	}
+
+	if _, err := CachedCheck(ctx, "mycheck", []string{"**/*.go"}, testToolVersion, 0, fn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := CachedCheck(ctx, "mycheck", []string{"**/*.go"}, testToolVersion, 0, fn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected fn to re-run after an input file changed, ran %d times", calls)
+	}
+}
+
+func TestCachedCheck_FailureIsNeverCached(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	rootDir := t.TempDir()
+	ctx := &CheckContext{RootDir: rootDir}
+
+	calls := 0
+	fn := func() (CheckResult, error) {
+		calls++
+		return CheckResult{}, errors.New("boom")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := CachedCheck(ctx, "mycheck", nil, testToolVersion, 0, fn); err == nil {
+			t.Fatal("expected the error to propagate")
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected a failing result to never be cached, fn ran %d times", calls)
+	}
+}
+
+func TestCachedCheck_NoCacheAlwaysCallsFn(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	rootDir := t.TempDir()
+	ctx := &CheckContext{RootDir: rootDir, NoCache: true}
+
+	calls := 0
+	fn := func() (CheckResult, error) {
+		calls++
+		return Success("did the work"), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := CachedCheck(ctx, "mycheck", nil, testToolVersion, 0, fn); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected --no-cache to always call fn, ran %d times", calls)
+	}
+}
+
+// --- ClearCache ---
+
+func TestClearCache_RemovesCheckDirectory(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	rootDir := t.TempDir()
+	ctx := &CheckContext{RootDir: rootDir}
+
+	fn := func() (CheckResult, error) { return Success("did the work"), nil }
+	if _, err := CachedCheck(ctx, "mycheck", nil, testToolVersion, 0, fn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir, err := CacheDir("mycheck")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Fatalf("expected %s to exist before ClearCache: %v", dir, err)
+	}
+
+	if err := ClearCache("mycheck"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, got err %v", dir, err)
+	}
+}
+
+func TestClearCache_MissingDirectoryIsNotAnError(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	if err := ClearCache("never-cached"); err != nil {
+		t.Fatalf("expected no error clearing an unused check, got %v", err)
+	}
+}
+
+// --- expandInputFiles ---
+
+func TestExpandInputFiles_MatchesDoubleStarAndSortsResults(t *testing.T) {
+	rootDir := t.TempDir()
+	for _, rel := range []string{"b.go", "a.go", "sub/c.go", "README.md"} {
+		full := filepath.Join(rootDir, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := os.WriteFile(full, []byte("x"), 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	files, err := expandInputFiles(rootDir, []string{"**/*.go"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"a.go", "b.go", "sub/c.go"}
+	if len(files) != len(want) {
+		t.Fatalf("expected %v, got %v", want, files)
+	}
+	for i, rel := range want {
+		if files[i] != rel {
+			t.Errorf("expected %v, got %v", want, files)
+			break
+		}
+	}
+}
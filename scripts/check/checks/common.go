@@ -2,11 +2,20 @@ package checks
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+
+	"vmail/scripts/check/checks/config"
 )
 
 // App represents the application a check belongs to.
@@ -50,6 +59,54 @@ type CheckResult struct {
 	Code        ResultCode
 	Message     string
 	MadeChanges bool // true if the check modified files (for example, formatted code)
+
+	// Diagnostics optionally breaks Message down into one machine-readable
+	// entry per finding, for consumers (editors, CI) that want structured
+	// output instead of scraping Message. Most checks leave this nil and rely
+	// on Message alone; RunCfgGate is the first to populate it (see
+	// desktop-rust-cfg-gate-diagnostics.go). A check may still return
+	// Diagnostics alongside a non-nil error, since a failing CheckResult isn't
+	// always the zero value.
+	Diagnostics []Diagnostic
+
+	// Output optionally carries the underlying tool's raw, unformatted output
+	// (e.g. a linter's full stdout+stderr), for consumers like --format=ndjson
+	// that want it alongside the short, human-oriented Message instead of
+	// re-deriving it by scraping Message's colored text. Most checks leave
+	// this empty; ndjson_output.go falls back to Message when it's unset.
+	Output string
+}
+
+// Diagnostic is one machine-readable finding from a check: a location, which
+// rule flagged it, a human-readable message, and (for checks whose rule is
+// driven by a parsed expression rather than a plain string match) the
+// expression responsible, so tooling can filter on its structure instead of
+// re-parsing Message. SuggestedFix is omitted when a check has no automatic
+// fix to offer. Severity and Tool are omitted by checks (like RunCfgGate)
+// that only ever report one kind of finding from cmdr's own analysis rather
+// than relaying another tool's output.
+type Diagnostic struct {
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+	Col          int    `json:"col"`
+	EndLine      int    `json:"endLine"`
+	EndCol       int    `json:"endCol"`
+	Rule         string `json:"rule"`
+	Message      string `json:"message"`
+	Severity     string `json:"severity,omitempty"` // e.g. "error", "warning"; as reported by Tool
+	Tool         string `json:"tool,omitempty"`     // the underlying linter/analyzer this was parsed from, e.g. "clippy"
+	GatedBy      any    `json:"gatedBy,omitempty"`
+	SuggestedFix string `json:"suggestedFix,omitempty"`
+
+	// HelpURI is a link a consumer (GitHub Code Scanning, VS Code's Problems
+	// panel) can offer the user to read more about Rule, populated only when
+	// one genuinely exists. For a relayed linter (clippy, staticcheck,
+	// govulncheck) that's the linter's own public docs for that lint/check/
+	// advisory ID — see e.g. clippyHelpURI, staticcheckHelpURI,
+	// govulncheckHelpURI. cmdr has no hosted docs site of its own yet, so
+	// checks whose Rule is cmdr's own analysis (RunCfgGate's "cfg-gate")
+	// leave this empty rather than link to a page that doesn't exist.
+	HelpURI string `json:"helpUri,omitempty"`
 }
 
 // Success creates a success result with the given message (no changes made).
@@ -69,9 +126,140 @@ func Skipped(reason string) CheckResult {
 
 // CheckContext holds the context for running checks.
 type CheckContext struct {
-	CI      bool
-	Verbose bool
-	RootDir string
+	CI         bool
+	Verbose    bool
+	RootDir    string
+	NoBaseline bool           // when true, RunFileLength ignores .cmdr-baseline.json
+	Config     *config.Config // thresholds/skip-dirs/disabled checks from cmdr.toml; nil means config.Default()
+	FS         SourceFS       // filesystem checks scan for source files; nil means NewOSFS(RootDir)
+	Exec       Exec           // runs external commands; nil means RealExec{} (see effectiveExec)
+	FastTokens bool           // when true, RunFileLength estimates tokens as sizeBytes/4 instead of running the BPE tokenizer
+
+	// TargetSpecs is the set of platforms RunCfgGate validates cfg-gating
+	// against; nil means []TargetSpec{DefaultMacOSTargetSpec}, preserving the
+	// check's original macOS-only behavior.
+	TargetSpecs []TargetSpec
+
+	// CfgGateAllowlist is the set of Rust module names (same shape as
+	// extractPlatformCrateModules's result) RunCfgGate never flags as
+	// ungated, for a crate that's intentionally declared platform-only for
+	// more than one of TargetSpecs at once (e.g. a windowing dep that's both
+	// macOS- and iOS-only) and used identically on each without a cfg gate
+	// distinguishing them. nil means no exceptions.
+	CfgGateAllowlist map[string]bool
+
+	// Fix, when true, makes RunCfgGate insert the missing cfg gate attribute
+	// above each ungated use it finds instead of just reporting it (surfaced
+	// as --fix). Unlike the gofmt/rustfmt checks, which auto-fix by default
+	// and only skip fixing in ctx.CI, this opts in explicitly: inserting a
+	// cfg gate changes what the affected code compiles to, so it shouldn't
+	// happen on every local run the way whitespace formatting does.
+	Fix bool
+
+	// DryRun, when true alongside Fix, builds the same unified-diff-style
+	// summary RunCfgGate would otherwise write to disk, without touching any
+	// files (surfaced as --dry-run). Has no effect when Fix is false.
+	DryRun bool
+
+	// Cache, when true, makes RunCfgGate read and write its per-file analysis
+	// through the on-disk cache in desktop-rust-analysis-cache.go (surfaced as
+	// --cache), keyed by each .rs file's content hash so re-scanning unchanged
+	// files on a later run is a cache hit instead of a re-parse. Defaults to
+	// false so tests building a bare &CheckContext{RootDir: root} never touch
+	// the host's real cache directory.
+	Cache bool
+
+	// NoCache, when true, makes CachedCheck always call through to the
+	// wrapped check instead of consulting its on-disk fingerprint cache
+	// (surfaced as --no-cache). Caching is opt-out rather than opt-in here,
+	// unlike Cache above, since CachedCheck's cache only ever stores a
+	// check's last successful result keyed by its own declared inputs —
+	// there's no equivalent risk of reading stale analysis for code ctx.Cache
+	// guards against.
+	NoCache bool
+
+	// ChangedOnly, when true, makes RunSvelteTests fail only on coverage
+	// regressions in lines added or modified since BaseRef, instead of every
+	// file below CoverageThreshold (surfaced as --changed-only, and implied
+	// by CI so PRs are judged against the diff they actually introduce).
+	ChangedOnly bool
+
+	// BaseRef is the git ref RunSvelteTests diffs HEAD against when
+	// ChangedOnly is set (surfaced as --base-ref). Empty means "origin/main".
+	BaseRef string
+
+	// Offline, when true, makes checks that would otherwise install a missing
+	// toolchain (RunGovulncheck's govulncheck, RunCargoUdeps's cargo-udeps and
+	// nightly toolchain, RunRustTests's cargo-nextest) return Skipped instead
+	// of attempting the install, and RunWebsiteTypecheck return Skipped
+	// instead of failing when pnpm itself isn't on PATH (surfaced as
+	// --offline, for contributors without every toolchain or without network
+	// access to fetch one).
+	Offline bool
+
+	// Update, when true, makes the runner call a check's Update hook (if it
+	// has one) instead of Run, to regenerate a golden file like
+	// loc-report.json from the current working tree (surfaced as --update).
+	// Checks without an Update hook ignore this and always run normally.
+	Update bool
+
+	// CheckID is set by the runner to the running CheckDefinition.ID before
+	// Run/Update is called, so WorkDir can name the directory after it.
+	CheckID string
+
+	// workDirMu guards workDir, memoizing the per-check temp directory
+	// WorkDir lazily creates on first use.
+	workDirMu sync.Mutex
+	workDir   string
+
+	// platformCrateCacheMu guards platformCrateCache, memoizing
+	// resolvePlatformCratesFor results (keyed by rootDir+spec.Name) so a run
+	// checking several targets doesn't shell out to cargo metadata twice for
+	// the same one.
+	platformCrateCacheMu sync.Mutex
+	platformCrateCache   map[string]map[string]bool
+
+	// Cancel, when non-nil, is cancelled by the runner once a check's
+	// per-check timeout elapses. Checks that shell out to slow subprocesses
+	// (Hugo, lychee, npm, ...) should use RunCommandWithContext with this
+	// context so the subprocess is actually killed instead of left running
+	// in the background after the runner gives up on it.
+	Cancel context.Context
+}
+
+// CloneForCheck returns a copy of ctx for a single check's run, named after
+// id. It copies every field except workDirMu/workDir and
+// platformCrateCacheMu/platformCrateCache, which are left at their zero
+// value: each check gets its own freshly memoized work directory and cargo
+// metadata cache, never one shared with ctx or another check's copy, so a
+// bare struct copy (ctx := *r.ctx) would have the same effect — except that
+// copies the two sync.Mutex fields too, which go vet flags as copying a lock
+// value. Listing fields explicitly, rather than fixing that up after the
+// fact, also means a future stateful field on CheckContext has to be added
+// here deliberately instead of silently riding along.
+func (ctx *CheckContext) CloneForCheck() *CheckContext {
+	return &CheckContext{
+		CI:               ctx.CI,
+		Verbose:          ctx.Verbose,
+		RootDir:          ctx.RootDir,
+		NoBaseline:       ctx.NoBaseline,
+		Config:           ctx.Config,
+		FS:               ctx.FS,
+		Exec:             ctx.Exec,
+		FastTokens:       ctx.FastTokens,
+		TargetSpecs:      ctx.TargetSpecs,
+		CfgGateAllowlist: ctx.CfgGateAllowlist,
+		Fix:              ctx.Fix,
+		DryRun:           ctx.DryRun,
+		Cache:            ctx.Cache,
+		NoCache:          ctx.NoCache,
+		ChangedOnly:      ctx.ChangedOnly,
+		BaseRef:          ctx.BaseRef,
+		Offline:          ctx.Offline,
+		Update:           ctx.Update,
+		CheckID:          ctx.CheckID,
+		Cancel:           ctx.Cancel,
+	}
 }
 
 // CheckFunc is the function signature for check implementations.
@@ -86,9 +274,51 @@ type CheckDefinition struct {
 	Tech        string
 	IsSlow      bool
 	DependsOn   []string
-	Run         CheckFunc
+
+	// Resources names the shared state this check's Run touches that isn't
+	// safe for two checks to touch at once — e.g. two cargo invocations
+	// racing on the same target/src-tauri directory's build lock and
+	// Cargo.lock. Unlike DependsOn (which only orders a check after another
+	// has finished, and fails it if that dependency failed), Resources just
+	// serializes: the runner won't start two checks that share a resource
+	// name concurrently, but neither's outcome affects whether the other
+	// runs. Most checks need none.
+	Resources []string
+
+	// Inputs is the set of root-relative glob patterns (matched with
+	// MatchInputGlob, which understands "**" as "zero or more path
+	// segments") whose contents this check's Run depends on — used by
+	// `cmdr watch` to rerun only the checks affected by a file change
+	// instead of the whole suite. A check that declares no Inputs is
+	// treated as always-affected, since an empty Inputs means "unknown",
+	// not "depends on nothing".
+	Inputs []string
+
+	Run CheckFunc
+
+	// Update optionally regenerates this check's golden file (e.g.
+	// loc-report.json) from the current working tree instead of comparing
+	// against it, borrowing the `-update_errors` idea from Go's own
+	// test/run.go. The runner calls Update instead of Run when
+	// CheckContext.Update is set and this is non-nil; a check with no golden
+	// file to regenerate leaves this nil and always runs Run, --update or
+	// not.
+	Update CheckFunc
+
+	// Disabled is set by a "disabled" entry in an external registry file
+	// (see LoadRegistry), the same opt-out cmdr.toml's [checks] disabled
+	// list gives by ID. Kept as a flag rather than dropped from
+	// ActiveChecks entirely so ValidateCheckDependencies still sees the
+	// check as a valid DependsOn target; filterDisabledChecks is what
+	// actually excludes it from a run.
+	Disabled bool
 }
 
+// ResourceDesktopRustTarget is the shared Cargo.lock/target directory under
+// apps/desktop/src-tauri — checks that shell out to cargo against it list
+// this in Resources so the runner doesn't run two of them at once.
+const ResourceDesktopRustTarget = "desktop-rust-target"
+
 // RunCommand executes a command and captures its output.
 func RunCommand(cmd *exec.Cmd, captureOutput bool) (string, error) {
 	var stdout, stderr bytes.Buffer
@@ -108,6 +338,129 @@ func RunCommand(cmd *exec.Cmd, captureOutput bool) (string, error) {
 	return output, err
 }
 
+// RunCommandWithContext behaves like RunCommand, but kills the process if ctx
+// is cancelled (or times out) before the command exits, returning ctx.Err()
+// alongside whatever output was captured up to that point. Pass nil to behave
+// exactly like RunCommand.
+func RunCommandWithContext(ctx context.Context, cmd *exec.Cmd, captureOutput bool) (string, error) {
+	if ctx == nil {
+		return RunCommand(cmd, captureOutput)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if captureOutput {
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+	} else {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var runErr error
+	select {
+	case runErr = <-done:
+	case <-ctx.Done():
+		if cmd.Process != nil {
+			_ = cmd.Process.Kill()
+		}
+		<-done // reap the process to avoid a zombie/leaked goroutine
+		runErr = ctx.Err()
+	}
+
+	output := stdout.String()
+	if stderr.Len() > 0 {
+		output += stderr.String()
+	}
+	return output, runErr
+}
+
+// WorkDir lazily creates and returns this check's isolated temp directory,
+// os.TempDir()/cmdr-check-<id>-<timestamp>/, following the `-k` ("keep
+// temporary directory") pattern in Go's own test/run.go: RunCommandLogged
+// tees each command's stdout/stderr here and writes the exact command line
+// to cmd.txt, so a flaky cargo-nextest/cargo-udeps failure with pages of
+// output is diagnosable from the retained directory instead of a blind
+// re-run. Safe to call more than once for the same check — every call
+// returns the same directory.
+func (ctx *CheckContext) WorkDir() (string, error) {
+	ctx.workDirMu.Lock()
+	defer ctx.workDirMu.Unlock()
+	if ctx.workDir != "" {
+		return ctx.workDir, nil
+	}
+
+	id := ctx.CheckID
+	if id == "" {
+		id = "unknown"
+	}
+	dir := filepath.Join(os.TempDir(), fmt.Sprintf("cmdr-check-%s-%d", id, time.Now().UnixNano()))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating work dir: %w", err)
+	}
+	ctx.workDir = dir
+	return dir, nil
+}
+
+// CreatedWorkDir returns the directory WorkDir created for this check, or ""
+// if WorkDir was never called. The runner uses this after a check finishes
+// to decide whether to delete or retain it (see Runner.Keep).
+func (ctx *CheckContext) CreatedWorkDir() string {
+	return ctx.workDir
+}
+
+// RunCommandLogged behaves like RunCommand, but additionally tees the
+// command's stdout/stderr into ctx.WorkDir()'s stdout.log/stderr.log and
+// records the exact command line in cmd.txt, so the full output survives
+// past Message's (often truncated) summary. Falls back to plain RunCommand
+// if the work directory can't be created.
+func RunCommandLogged(ctx *CheckContext, cmd *exec.Cmd, captureOutput bool) (string, error) {
+	dir, err := ctx.WorkDir()
+	if err != nil {
+		return RunCommand(cmd, captureOutput)
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, "cmd.txt"), []byte(strings.Join(cmd.Args, " ")+"\n"), 0644)
+
+	stdoutFile, outErr := os.Create(filepath.Join(dir, "stdout.log"))
+	if outErr == nil {
+		defer stdoutFile.Close()
+	}
+	stderrFile, errErr := os.Create(filepath.Join(dir, "stderr.log"))
+	if errErr == nil {
+		defer stderrFile.Close()
+	}
+
+	var stdout, stderr bytes.Buffer
+	stdoutWriters := []io.Writer{&stdout}
+	stderrWriters := []io.Writer{&stderr}
+	if !captureOutput {
+		stdoutWriters = append(stdoutWriters, os.Stdout)
+		stderrWriters = append(stderrWriters, os.Stderr)
+	}
+	if outErr == nil {
+		stdoutWriters = append(stdoutWriters, stdoutFile)
+	}
+	if errErr == nil {
+		stderrWriters = append(stderrWriters, stderrFile)
+	}
+	cmd.Stdout = io.MultiWriter(stdoutWriters...)
+	cmd.Stderr = io.MultiWriter(stderrWriters...)
+
+	runErr := cmd.Run()
+	output := stdout.String()
+	if stderr.Len() > 0 {
+		output += stderr.String()
+	}
+	return output, runErr
+}
+
 // CommandExists checks if a command exists in PATH.
 func CommandExists(name string) bool {
 	_, err := exec.LookPath(name)
@@ -117,6 +470,11 @@ func CommandExists(name string) bool {
 // EnsureGoTool ensures a Go tool is installed and returns the path to the binary.
 // If the tool is already in PATH, returns just the name. Otherwise installs it
 // and returns the full path to the installed binary.
+//
+// Only nilaway and deadcode still use this: both are pinned to the Go
+// toolchain version rather than an independent release (see
+// goToolchainVersion), so a tools.lock entry would have nothing meaningful
+// to pin. Every other Go tool is pinned via checks/toolchain instead.
 func EnsureGoTool(name, installPath string) (string, error) {
 	if CommandExists(name) {
 		return name, nil
@@ -138,6 +496,32 @@ func EnsureGoTool(name, installPath string) (string, error) {
 	return filepath.Join(goBin, name), nil
 }
 
+// goToolchainVersion returns `go version`'s output trimmed, or "" if it
+// can't be determined. Used as the toolVersion fingerprint component for
+// checks built on top of the Go toolchain (deadcode, nilaway) rather than a
+// separately versioned binary.
+func goToolchainVersion() string {
+	cmd := exec.Command("go", "version")
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// pnpmVersion returns `pnpm --version`'s output trimmed, or "" if it can't
+// be determined. Used as the toolVersion fingerprint component for checks
+// that shell out to `pnpm lint`/`pnpm lint:fix` rather than a directly
+// invokable linter binary, e.g. RunDesktopESLint.
+func pnpmVersion() string {
+	cmd := exec.Command("pnpm", "--version")
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
 // getGoBinDir returns the directory where go install puts binaries.
 func getGoBinDir() string {
 	// First check GOBIN
@@ -188,51 +572,85 @@ func Pluralize(count int, singular, plural string) string {
 	return plural
 }
 
-// GetGoDirectories returns all directories in the repo that contain Go code.
-// Each returned path is relative to rootDir.
-func GetGoDirectories() []string {
-	return []string{
-		"scripts",
-		"apps/desktop/scripts",
-	}
+// ModuleInfo identifies one Go module FindAllGoModules discovered.
+type ModuleInfo struct {
+	// Dir is the module's directory relative to rootDir, always
+	// slash-separated (even on Windows) so it doubles as a display label
+	// as well as a path to filepath.Join back onto rootDir. "." if rootDir
+	// itself is a module.
+	Dir string
 }
 
-// FindGoModules finds all go.mod files in the given directory and returns
-// the directories containing them.
-func FindGoModules(rootDir string) ([]string, error) {
-	findCmd := exec.Command("find", ".", "-name", "go.mod", "-type", "f")
-	findCmd.Dir = rootDir
-	output, err := RunCommand(findCmd, true)
-	if err != nil {
-		return nil, err
-	}
+// DiscoveryOptions controls FindAllGoModules' walk.
+type DiscoveryOptions struct {
+	// Exclude lists directories (relative to rootDir, slash-separated) to
+	// skip entirely, on top of the always-skipped node_modules, .git, and
+	// vendor — e.g. a vendored copy of another project's Go code that
+	// happens to carry its own go.mod but isn't one of ours to check.
+	Exclude []string
+}
 
-	var modules []string
-	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
-		if line != "" {
-			// Get directory containing go.mod
-			dir := strings.TrimSuffix(line, "/go.mod")
-			dir = strings.TrimPrefix(dir, "./")
-			if dir == "go.mod" {
-				dir = "."
+// FindAllGoModules discovers every Go module (a directory containing a
+// go.mod) under rootDir within fsys, walking the filesystem directly rather
+// than shelling out to the Unix-only `find`, so it works unmodified on
+// Windows. It auto-discovers modules anywhere under rootDir instead of only
+// looking inside a hardcoded list of Go directories, so a newly added Go
+// sub-project is picked up without touching this package; pass
+// opts.Exclude to opt a directory back out. fsys lets a caller pass a
+// MemFS of synthetic go.mod files in tests instead of walking real disk;
+// production callers pass ctx.effectiveFS().
+func FindAllGoModules(fsys SourceFS, rootDir string, opts DiscoveryOptions) ([]ModuleInfo, error) {
+	var modules []ModuleInfo
+	err := fs.WalkDir(fsys, rootDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if d.Name() == "node_modules" || d.Name() == ".git" || d.Name() == "vendor" {
+			return fs.SkipDir
+		}
+		rel, err := filepath.Rel(rootDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		for _, excl := range opts.Exclude {
+			if rel == excl || strings.HasPrefix(rel, excl+"/") {
+				return fs.SkipDir
 			}
-			modules = append(modules, dir)
 		}
+		if _, err := fs.Stat(fsys, path.Join(p, "go.mod")); err == nil {
+			modules = append(modules, ModuleInfo{Dir: rel})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Dir < modules[j].Dir })
 	return modules, nil
 }
 
-// FindAllGoModules finds Go modules across all Go directories in the repo.
-// Returns a map of base directory to list of module subdirectories.
-func FindAllGoModules(rootDir string) (map[string][]string, error) {
-	result := make(map[string][]string)
-	for _, goDir := range GetGoDirectories() {
-		fullPath := filepath.Join(rootDir, goDir)
-		modules, err := FindGoModules(fullPath)
+// countGoFiles counts *.go files under dir, walking the filesystem directly
+// rather than shelling out to `find` (see FindAllGoModules).
+func countGoFiles(dir string) (int, error) {
+	count := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
-			return nil, fmt.Errorf("failed to find modules in %s: %w", goDir, err)
+			return err
 		}
-		result[goDir] = modules
-	}
-	return result, nil
+		if d.IsDir() {
+			if d.Name() == "node_modules" || d.Name() == ".git" {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".go") {
+			count++
+		}
+		return nil
+	})
+	return count, err
 }
@@ -0,0 +1,45 @@
+package checks
+
+import "testing"
+
+func TestFindAllGoModules_WorksAgainstMemFS(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("go.mod", "module root\n")
+	fsys.AddFile("scripts/check/go.mod", "module check\n")
+	fsys.AddFile("scripts/check/main.go", "package main\n")
+	fsys.AddFile("node_modules/some-dep/go.mod", "module vendored\n")
+
+	modules, err := FindAllGoModules(fsys, ".", DiscoveryOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dirs []string
+	for _, mod := range modules {
+		dirs = append(dirs, mod.Dir)
+	}
+	want := []string{".", "scripts/check"}
+	if len(dirs) != len(want) {
+		t.Fatalf("expected %v, got %v", want, dirs)
+	}
+	for i, d := range want {
+		if dirs[i] != d {
+			t.Errorf("expected %v, got %v", want, dirs)
+			break
+		}
+	}
+}
+
+func TestFindAllGoModules_RespectsExclude(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("scripts/check/go.mod", "module check\n")
+	fsys.AddFile("scripts/legacy/go.mod", "module legacy\n")
+
+	modules, err := FindAllGoModules(fsys, "scripts", DiscoveryOptions{Exclude: []string{"legacy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(modules) != 1 || modules[0].Dir != "check" {
+		t.Errorf("expected only scripts/check, got %v", modules)
+	}
+}
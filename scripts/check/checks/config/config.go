@@ -0,0 +1,204 @@
+// Package config loads cmdr.toml, the repo-level override file for check
+// behavior: file-length thresholds, which source extensions/directories
+// file-length looks at, per-language coverage thresholds, and which checks
+// are disabled outright.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Threshold is a warn/critical line-count pair, either the global default or
+// a per-extension/per-path override.
+type Threshold struct {
+	Warn     int
+	Critical int
+}
+
+// PathOverride raises (or lowers) the threshold for paths matching Glob, e.g.
+// a generated file that's expected to be huge.
+type PathOverride struct {
+	Glob      string
+	Threshold Threshold
+}
+
+// FileLengthConfig controls the file-length check.
+type FileLengthConfig struct {
+	Default          Threshold
+	PerExtension     map[string]Threshold // keyed by extension without the dot, e.g. "go"
+	PathOverrides    []PathOverride
+	ExtraExtensions  []string // added to the default source-extension set
+	RemoveExtensions []string // removed from the default source-extension set
+	ExtraSkipDirs    []string // added to the default skip-dir set
+}
+
+// CoverageConfig holds the minimum acceptable line-coverage percentage per
+// language for the coverage check. A zero value means no threshold is
+// enforced for that language, since a repo with no cmdr.toml (or no
+// [coverage] section) shouldn't suddenly start failing on coverage.
+type CoverageConfig struct {
+	Rust int
+	TS   int
+	Go   int
+}
+
+// Config is the parsed, defaults-merged form of cmdr.toml.
+type Config struct {
+	FileLength     FileLengthConfig
+	Coverage       CoverageConfig
+	DisabledChecks map[string]bool
+}
+
+// Default returns the configuration cmdr has always used, for repos with no
+// cmdr.toml.
+func Default() *Config {
+	return &Config{
+		FileLength: FileLengthConfig{
+			Default:      Threshold{Warn: 800, Critical: 1200},
+			PerExtension: map[string]Threshold{},
+		},
+		DisabledChecks: map[string]bool{},
+	}
+}
+
+// Load reads cmdr.toml from rootDir and merges it over Default(). A missing
+// file is not an error.
+func Load(rootDir string) (*Config, error) {
+	cfg := Default()
+
+	path := filepath.Join(rootDir, "cmdr.toml")
+	raw, err := decodeFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	mergeFileLength(cfg, raw)
+	mergeCoverage(cfg, raw)
+	mergeDisabledChecks(cfg, raw)
+	return cfg, nil
+}
+
+// decodeFile parses cmdr.toml into a generic tree, the same approach
+// extractMacOSCrateModules uses for Cargo.toml: navigate the untyped map
+// rather than declaring a struct shape that can't represent arbitrary
+// per-extension or per-path tables.
+func decodeFile(path string) (map[string]any, error) {
+	var raw map[string]any
+	if _, err := toml.DecodeFile(path, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func mergeFileLength(cfg *Config, raw map[string]any) {
+	section, ok := asTable(raw["filelength"])
+	if !ok {
+		return
+	}
+
+	if v, ok := asInt(section["warn"]); ok {
+		cfg.FileLength.Default.Warn = v
+	}
+	if v, ok := asInt(section["critical"]); ok {
+		cfg.FileLength.Default.Critical = v
+	}
+	cfg.FileLength.ExtraExtensions = asStringSlice(section["extra_extensions"])
+	cfg.FileLength.RemoveExtensions = asStringSlice(section["remove_extensions"])
+	cfg.FileLength.ExtraSkipDirs = asStringSlice(section["extra_skip_dirs"])
+
+	if overrides, ok := asTable(section["overrides"]); ok {
+		for glob, v := range overrides {
+			entry, ok := asTable(v)
+			if !ok {
+				continue
+			}
+			th := cfg.FileLength.Default
+			if w, ok := asInt(entry["warn"]); ok {
+				th.Warn = w
+			}
+			if c, ok := asInt(entry["critical"]); ok {
+				th.Critical = c
+			}
+			cfg.FileLength.PathOverrides = append(cfg.FileLength.PathOverrides, PathOverride{Glob: glob, Threshold: th})
+		}
+	}
+
+	// Remaining sub-tables of [filelength] (other than the reserved keys
+	// above) are per-extension overrides, e.g. [filelength.go].
+	reserved := map[string]bool{"overrides": true}
+	for key, v := range section {
+		if reserved[key] {
+			continue
+		}
+		sub, ok := asTable(v)
+		if !ok {
+			continue
+		}
+		th := cfg.FileLength.Default
+		if w, ok := asInt(sub["warn"]); ok {
+			th.Warn = w
+		}
+		if c, ok := asInt(sub["critical"]); ok {
+			th.Critical = c
+		}
+		cfg.FileLength.PerExtension[key] = th
+	}
+}
+
+func mergeCoverage(cfg *Config, raw map[string]any) {
+	section, ok := asTable(raw["coverage"])
+	if !ok {
+		return
+	}
+
+	if v, ok := asInt(section["rust"]); ok {
+		cfg.Coverage.Rust = v
+	}
+	if v, ok := asInt(section["ts"]); ok {
+		cfg.Coverage.TS = v
+	}
+	if v, ok := asInt(section["go"]); ok {
+		cfg.Coverage.Go = v
+	}
+}
+
+func mergeDisabledChecks(cfg *Config, raw map[string]any) {
+	section, ok := asTable(raw["checks"])
+	if !ok {
+		return
+	}
+	for _, id := range asStringSlice(section["disabled"]) {
+		cfg.DisabledChecks[id] = true
+	}
+}
+
+func asTable(v any) (map[string]any, bool) {
+	m, ok := v.(map[string]any)
+	return m, ok
+}
+
+func asInt(v any) (int, bool) {
+	i, ok := v.(int64)
+	return int(i), ok
+}
+
+func asStringSlice(v any) []string {
+	raw, ok := v.([]any)
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(raw))
+	for _, item := range raw {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
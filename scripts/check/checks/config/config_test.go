@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, dir, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "cmdr.toml"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_MissingFileReturnsDefaults(t *testing.T) {
+	tmp := t.TempDir()
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.FileLength.Default.Warn != 800 || cfg.FileLength.Default.Critical != 1200 {
+		t.Errorf("expected default thresholds, got %+v", cfg.FileLength.Default)
+	}
+}
+
+func TestLoad_OverridesDefaultThresholds(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+[filelength]
+warn = 500
+critical = 900
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.FileLength.Default.Warn != 500 || cfg.FileLength.Default.Critical != 900 {
+		t.Errorf("expected overridden thresholds, got %+v", cfg.FileLength.Default)
+	}
+}
+
+func TestLoad_PerExtensionThreshold(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+[filelength.go]
+warn = 500
+critical = 900
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	th, ok := cfg.FileLength.PerExtension["go"]
+	if !ok {
+		t.Fatal("expected a per-extension threshold for go")
+	}
+	if th.Warn != 500 || th.Critical != 900 {
+		t.Errorf("expected 500/900, got %+v", th)
+	}
+}
+
+func TestLoad_PathOverrides(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+[filelength.overrides]
+"generated/*.ts" = { warn = 5000, critical = 8000 }
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.FileLength.PathOverrides) != 1 {
+		t.Fatalf("expected 1 path override, got %d", len(cfg.FileLength.PathOverrides))
+	}
+	override := cfg.FileLength.PathOverrides[0]
+	if override.Glob != "generated/*.ts" || override.Threshold.Warn != 5000 || override.Threshold.Critical != 8000 {
+		t.Errorf("unexpected override: %+v", override)
+	}
+}
+
+func TestLoad_ExtraAndRemoveExtensions(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+[filelength]
+extra_extensions = ["mjs"]
+remove_extensions = ["sh"]
+extra_skip_dirs = ["vendor"]
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.FileLength.ExtraExtensions) != 1 || cfg.FileLength.ExtraExtensions[0] != "mjs" {
+		t.Errorf("expected extra_extensions [mjs], got %v", cfg.FileLength.ExtraExtensions)
+	}
+	if len(cfg.FileLength.RemoveExtensions) != 1 || cfg.FileLength.RemoveExtensions[0] != "sh" {
+		t.Errorf("expected remove_extensions [sh], got %v", cfg.FileLength.RemoveExtensions)
+	}
+	if len(cfg.FileLength.ExtraSkipDirs) != 1 || cfg.FileLength.ExtraSkipDirs[0] != "vendor" {
+		t.Errorf("expected extra_skip_dirs [vendor], got %v", cfg.FileLength.ExtraSkipDirs)
+	}
+}
+
+func TestLoad_CoverageThresholds(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+[coverage]
+rust = 80
+ts = 75
+go = 70
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Coverage.Rust != 80 || cfg.Coverage.TS != 75 || cfg.Coverage.Go != 70 {
+		t.Errorf("expected rust=80 ts=75 go=70, got %+v", cfg.Coverage)
+	}
+}
+
+func TestLoad_CoverageThresholdsDefaultToZero(t *testing.T) {
+	tmp := t.TempDir()
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Coverage != (CoverageConfig{}) {
+		t.Errorf("expected no thresholds enforced by default, got %+v", cfg.Coverage)
+	}
+}
+
+func TestLoad_DisabledChecks(t *testing.T) {
+	tmp := t.TempDir()
+	writeConfig(t, tmp, `
+[checks]
+disabled = ["scripts-go-deadcode", "desktop-rust-clippy"]
+`)
+
+	cfg, err := Load(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.DisabledChecks["scripts-go-deadcode"] || !cfg.DisabledChecks["desktop-rust-clippy"] {
+		t.Errorf("expected both checks to be disabled, got %v", cfg.DisabledChecks)
+	}
+	if cfg.DisabledChecks["desktop-svelte-tests"] {
+		t.Errorf("expected unrelated check to remain enabled")
+	}
+}
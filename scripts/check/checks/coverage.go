@@ -0,0 +1,368 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"vmail/scripts/check/checks/config"
+)
+
+// coverageTotals accumulates LCOV DA (line-hit) records across every file
+// parsed for one language leg (Rust, TS, or Go), or across all of them for
+// the combined total.
+type coverageTotals struct {
+	LinesHit   int
+	LinesFound int
+}
+
+// Percent returns t's line-coverage percentage. A leg with no coverable
+// lines at all is reported as fully covered rather than 0%, since there's
+// nothing uncovered to flag.
+func (t coverageTotals) Percent() float64 {
+	if t.LinesFound == 0 {
+		return 100
+	}
+	return 100 * float64(t.LinesHit) / float64(t.LinesFound)
+}
+
+// RunCoverage produces a unified coverage report across the workspace: Rust
+// (cargo-llvm-cov against apps/desktop/src-tauri), TS (desktop-svelte-tests'
+// own coverage-summary.json, see collectTSCoverage), and Go (go test plus
+// gocov2lcov for every module FindAllGoModules finds). It fails if any
+// language's line coverage drops below its [coverage] threshold in
+// cmdr.toml. A language whose tooling or prior coverage run isn't available
+// (no cargo-llvm-cov installed, desktop-svelte-tests hasn't run, ...) is
+// skipped rather than failed, since not every checkout has every toolchain
+// available.
+func RunCoverage(ctx *CheckContext) (CheckResult, error) {
+	cfg := ctx.Config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	totals := map[string]coverageTotals{}
+
+	goTotals, goRan, err := collectGoCoverage(ctx)
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("go coverage: %w", err)
+	}
+	if goRan {
+		totals["go"] = goTotals
+	}
+
+	rustTotals, rustRan, err := collectRustCoverage(ctx)
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("rust coverage: %w", err)
+	}
+	if rustRan {
+		totals["rust"] = rustTotals
+	}
+
+	tsTotals, tsRan, err := collectTSCoverage(ctx)
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("ts coverage: %w", err)
+	}
+	if tsRan {
+		totals["ts"] = tsTotals
+	}
+
+	if len(totals) == 0 {
+		return Skipped("no coverage tooling found (cargo-llvm-cov, apps/desktop package.json, or Go modules)"), nil
+	}
+
+	var legs []string
+	for leg := range totals {
+		legs = append(legs, leg)
+	}
+	sort.Strings(legs)
+
+	var lines []string
+	var failures []string
+	overall := coverageTotals{}
+	for _, leg := range legs {
+		t := totals[leg]
+		overall.LinesHit += t.LinesHit
+		overall.LinesFound += t.LinesFound
+		pct := t.Percent()
+		lines = append(lines, fmt.Sprintf("%s: %.1f%% (%d/%d lines)", leg, pct, t.LinesHit, t.LinesFound))
+		if threshold := coverageThreshold(cfg, leg); threshold > 0 && pct < float64(threshold) {
+			failures = append(failures, fmt.Sprintf("%s coverage %.1f%% is below the %d%% threshold", leg, pct, threshold))
+		}
+	}
+	lines = append(lines, fmt.Sprintf("total: %.1f%% (%d/%d lines)", overall.Percent(), overall.LinesHit, overall.LinesFound))
+	message := strings.Join(lines, "\n")
+
+	if len(failures) > 0 {
+		return CheckResult{}, fmt.Errorf("%s\n%s", strings.Join(failures, "\n"), indentOutput(message))
+	}
+	return Success(message), nil
+}
+
+// coverageThreshold returns the configured minimum percentage for leg
+// ("go", "rust", or "ts"), or 0 if none is configured.
+func coverageThreshold(cfg *config.Config, leg string) int {
+	switch leg {
+	case "go":
+		return cfg.Coverage.Go
+	case "rust":
+		return cfg.Coverage.Rust
+	case "ts":
+		return cfg.Coverage.TS
+	default:
+		return 0
+	}
+}
+
+// collectGoCoverage runs `go test -coverprofile` for every module
+// FindAllGoModules discovers, converts each profile to LCOV via gocov2lcov,
+// and sums the totals. ran is false if there were no Go modules with a
+// coverage profile to collect (no tests, or no statements to cover).
+func collectGoCoverage(ctx *CheckContext) (coverageTotals, bool, error) {
+	modules, err := FindAllGoModules(ctx.effectiveFS(), ".", DiscoveryOptions{})
+	if err != nil {
+		return coverageTotals{}, false, fmt.Errorf("failed to find Go modules: %w", err)
+	}
+
+	var totals coverageTotals
+	ran := false
+
+	for _, mod := range modules {
+		modulePath := filepath.Join(ctx.RootDir, mod.Dir)
+
+		profilePath := filepath.Join(modulePath, ".cmdr-coverage.out")
+		cmd := exec.Command("go", "test", "-coverprofile="+profilePath, "./...")
+		cmd.Dir = modulePath
+		output, err := RunCommand(cmd, true)
+		if err != nil {
+			return coverageTotals{}, false, fmt.Errorf("go test failed in %s: %w\n%s", mod.Dir, err, output)
+		}
+
+		profile, readErr := os.ReadFile(profilePath)
+		os.Remove(profilePath)
+		if readErr != nil {
+			// A module with no statements to cover doesn't write a
+			// profile at all; that's not a coverage failure.
+			continue
+		}
+
+		lcov, err := gocov2lcov(string(profile))
+		if err != nil {
+			return coverageTotals{}, false, fmt.Errorf("failed to convert %s's coverage profile: %w", mod.Dir, err)
+		}
+		t := lcovTotals(lcov)
+		totals.LinesHit += t.LinesHit
+		totals.LinesFound += t.LinesFound
+		ran = true
+	}
+	return totals, ran, nil
+}
+
+// collectRustCoverage shells out to cargo-llvm-cov for
+// apps/desktop/src-tauri and parses its LCOV output. ran is false if
+// cargo-llvm-cov isn't installed, so RunCoverage can skip this leg on a
+// machine that hasn't added the subcommand rather than failing outright.
+func collectRustCoverage(ctx *CheckContext) (coverageTotals, bool, error) {
+	if !CommandExists("cargo") {
+		return coverageTotals{}, false, nil
+	}
+	if _, err := RunCommand(exec.Command("cargo", "llvm-cov", "--version"), true); err != nil {
+		return coverageTotals{}, false, nil
+	}
+
+	rustDir := filepath.Join(ctx.RootDir, "apps", "desktop", "src-tauri")
+	outputPath := filepath.Join(rustDir, "target", "cov", "rust.lcov")
+
+	cmd := exec.Command("cargo", "llvm-cov", "--lcov", "--output-path", outputPath)
+	cmd.Dir = rustDir
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return coverageTotals{}, false, fmt.Errorf("cargo llvm-cov failed\n%s", indentOutput(output))
+	}
+
+	lcov, err := os.ReadFile(outputPath)
+	if err != nil {
+		return coverageTotals{}, false, fmt.Errorf("failed to read %s: %w", outputPath, err)
+	}
+	return lcovTotals(string(lcov)), true, nil
+}
+
+// collectTSCoverage reads the coverage-summary.json that desktop-svelte-tests
+// (see RunSvelteTests) already produces via `pnpm test:coverage`, rather than
+// re-running vitest with a second, differently-configured reporter: the
+// registry's DependsOn on desktop-svelte-tests guarantees it has already run
+// by the time this executes, and the two checks sharing one test run avoids
+// two divergent coverage thresholds for the same TS sources. ran is false if
+// the summary file isn't there, e.g. desktop-svelte-tests didn't run or the
+// app has no test:coverage script set up.
+func collectTSCoverage(ctx *CheckContext) (coverageTotals, bool, error) {
+	summaryPath := filepath.Join(ctx.RootDir, "apps", "desktop", "coverage", "coverage-summary.json")
+	data, err := os.ReadFile(summaryPath)
+	if err != nil {
+		return coverageTotals{}, false, nil
+	}
+
+	var coverage map[string]FileCoverage
+	if err := json.Unmarshal(data, &coverage); err != nil {
+		return coverageTotals{}, false, fmt.Errorf("failed to parse %s: %w", summaryPath, err)
+	}
+
+	total, ok := coverage["total"]
+	if !ok {
+		return coverageTotals{}, false, nil
+	}
+	return coverageTotals{LinesHit: total.Lines.Covered, LinesFound: total.Lines.Total}, true, nil
+}
+
+// lcovTotals sums line-coverage counts across every DA record in lcov text
+// (cargo-llvm-cov and gocov2lcov's output both use this format), regardless
+// of which SF block they fall under.
+func lcovTotals(lcov string) coverageTotals {
+	var totals coverageTotals
+	for _, line := range strings.Split(lcov, "\n") {
+		rest, ok := strings.CutPrefix(line, "DA:")
+		if !ok {
+			continue
+		}
+		fields := strings.SplitN(rest, ",", 2)
+		if len(fields) != 2 {
+			continue
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		totals.LinesFound++
+		if count > 0 {
+			totals.LinesHit++
+		}
+	}
+	return totals
+}
+
+// goCoverageBlock is one line of a `go test -coverprofile` profile: a
+// half-open range of lines a single block of statements spans, and how many
+// times the test run executed it.
+type goCoverageBlock struct {
+	File      string
+	StartLine int
+	EndLine   int
+	Count     int
+}
+
+// gocov2lcov converts a `go test -coverprofile` profile into LCOV text
+// (SF/DA/end_of_record per file) so RunCoverage can merge Go coverage with
+// Rust and TS through the same lcovTotals parser. Go's profile format
+// reports hit counts per statement block (a line range), not per line, so a
+// line covered by more than one block takes the highest count seen for it.
+func gocov2lcov(profile string) (string, error) {
+	blocks, err := parseGoCoverageProfile(profile)
+	if err != nil {
+		return "", err
+	}
+
+	lineHitsByFile := map[string]map[int]int{}
+	var files []string
+	for _, b := range blocks {
+		lineHits, ok := lineHitsByFile[b.File]
+		if !ok {
+			lineHits = map[int]int{}
+			lineHitsByFile[b.File] = lineHits
+			files = append(files, b.File)
+		}
+		for line := b.StartLine; line <= b.EndLine; line++ {
+			if existing, seen := lineHits[line]; !seen || b.Count > existing {
+				lineHits[line] = b.Count
+			}
+		}
+	}
+	sort.Strings(files)
+
+	var out strings.Builder
+	for _, file := range files {
+		lineHits := lineHitsByFile[file]
+		var lineNums []int
+		for line := range lineHits {
+			lineNums = append(lineNums, line)
+		}
+		sort.Ints(lineNums)
+
+		fmt.Fprintf(&out, "SF:%s\n", file)
+		for _, line := range lineNums {
+			fmt.Fprintf(&out, "DA:%d,%d\n", line, lineHits[line])
+		}
+		out.WriteString("end_of_record\n")
+	}
+	return out.String(), nil
+}
+
+// parseGoCoverageProfile parses every line of a coverage profile after its
+// leading "mode: ..." header.
+func parseGoCoverageProfile(profile string) ([]goCoverageBlock, error) {
+	lines := strings.Split(profile, "\n")
+	var blocks []goCoverageBlock
+	for i, line := range lines {
+		if i == 0 {
+			continue // "mode: ..." header
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		block, err := parseGoCoverageLine(line)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// parseGoCoverageLine parses one profile line, shaped like:
+// file.go:12.34,16.2 3 1
+// (file:startLine.startCol,endLine.endCol numStmt count)
+func parseGoCoverageLine(line string) (goCoverageBlock, error) {
+	file, rest, ok := strings.Cut(line, ":")
+	if !ok {
+		return goCoverageBlock{}, fmt.Errorf("malformed coverage line: %q", line)
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) != 3 {
+		return goCoverageBlock{}, fmt.Errorf("malformed coverage line: %q", line)
+	}
+
+	startPos, endPos, ok := strings.Cut(fields[0], ",")
+	if !ok {
+		return goCoverageBlock{}, fmt.Errorf("malformed coverage line: %q", line)
+	}
+	startLine, err := lineFromPos(startPos)
+	if err != nil {
+		return goCoverageBlock{}, fmt.Errorf("malformed coverage line: %q", line)
+	}
+	endLine, err := lineFromPos(endPos)
+	if err != nil {
+		return goCoverageBlock{}, fmt.Errorf("malformed coverage line: %q", line)
+	}
+
+	count, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return goCoverageBlock{}, fmt.Errorf("malformed coverage line: %q", line)
+	}
+
+	return goCoverageBlock{File: file, StartLine: startLine, EndLine: endLine, Count: count}, nil
+}
+
+// lineFromPos parses the line number out of a "line.col" position.
+func lineFromPos(pos string) (int, error) {
+	line, _, ok := strings.Cut(pos, ".")
+	if !ok {
+		return 0, fmt.Errorf("malformed position %q", pos)
+	}
+	return strconv.Atoi(line)
+}
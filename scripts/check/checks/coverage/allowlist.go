@@ -0,0 +1,118 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Allowlist is the coverage-allowlist.json schema shared by every per-file
+// coverage check: a file in Files is exempt from the threshold entirely
+// (with a recorded reason), while Thresholds overrides the default for
+// every path matching its glob key, e.g. `"internal/legacy/**": {"lines": 40}`.
+type Allowlist struct {
+	Comment    string                    `json:"$comment"`
+	Files      map[string]AllowlistEntry `json:"files"`
+	Thresholds map[string]ThresholdEntry `json:"thresholds"`
+}
+
+// AllowlistEntry is a single allowlisted file's recorded reason.
+type AllowlistEntry struct {
+	Reason string `json:"reason"`
+}
+
+// ThresholdEntry overrides the default threshold for every file whose path
+// matches its glob key (see Allowlist.ThresholdFor).
+type ThresholdEntry struct {
+	Lines float64 `json:"lines"`
+}
+
+// Load reads a coverage-allowlist.json at path, treating a missing file as
+// an empty allowlist rather than an error, since most modules don't need one.
+func Load(path string) (Allowlist, error) {
+	allowlist := Allowlist{Files: make(map[string]AllowlistEntry)}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return allowlist, nil
+	}
+	if err := json.Unmarshal(data, &allowlist); err != nil {
+		return Allowlist{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return allowlist, nil
+}
+
+// ThresholdFor returns the minimum line coverage percentage relPath must
+// meet: the most specific (longest pattern) entry in a.Thresholds that
+// matches, or defaultThreshold if none do.
+func (a Allowlist) ThresholdFor(relPath string, defaultThreshold float64) float64 {
+	threshold := defaultThreshold
+	bestLen := -1
+	for pattern, entry := range a.Thresholds {
+		if len(pattern) <= bestLen || !matchGlob(pattern, relPath) {
+			continue
+		}
+		bestLen = len(pattern)
+		threshold = entry.Lines
+	}
+	return threshold
+}
+
+// LowCoverageFiles returns a formatted "  path: X.X% (threshold: Y%)" line
+// for every file in report whose line coverage is below the threshold
+// ThresholdFor resolves for it, skipping files listed in allowlist.Files
+// entirely. Results are sorted for stable output.
+func LowCoverageFiles(report Report, allowlist Allowlist, defaultThreshold float64) []string {
+	var lowCoverageFiles []string
+	for relPath, fileCov := range report.Files() {
+		if _, ok := allowlist.Files[relPath]; ok {
+			continue
+		}
+		threshold := allowlist.ThresholdFor(relPath, defaultThreshold)
+		if fileCov.Percent() < threshold {
+			lowCoverageFiles = append(lowCoverageFiles,
+				fmt.Sprintf("  %s: %.1f%% (threshold: %.0f%%)", relPath, fileCov.Percent(), threshold))
+		}
+	}
+	sort.Strings(lowCoverageFiles)
+	return lowCoverageFiles
+}
+
+// matchGlob reports whether pattern (using "*" as a single path-segment
+// wildcard and "**" as zero-or-more segments) matches name. Mirrors
+// checks.MatchInputGlob's syntax so coverage-allowlist.json's [thresholds]
+// keys behave the same whether they're read by this package or that one.
+func matchGlob(pattern, name string) bool {
+	patternParts := strings.Split(pattern, "/")
+	nameParts := strings.Split(name, "/")
+	return matchGlobParts(patternParts, nameParts)
+}
+
+func matchGlobParts(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		if matchGlobParts(pattern[1:], name) {
+			return true
+		}
+		if len(name) == 0 {
+			return false
+		}
+		return matchGlobParts(pattern, name[1:])
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if !matchSegment(pattern[0], name[0]) {
+		return false
+	}
+	return matchGlobParts(pattern[1:], name[1:])
+}
+
+func matchSegment(pattern, segment string) bool {
+	matched, err := filepath.Match(pattern, segment)
+	return err == nil && matched
+}
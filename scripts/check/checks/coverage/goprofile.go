@@ -0,0 +1,44 @@
+package coverage
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/cover"
+)
+
+// GoReport adapts a `go test -coverprofile` profile into Report, keyed by
+// the file's import path as recorded in the profile (e.g.
+// "vmail/scripts/check/checks/coverage.go").
+type GoReport struct {
+	files map[string]FileCoverage
+}
+
+// LoadGoProfile parses the coverage profile go test writes to
+// -coverprofile=path, aggregating each file's statement blocks into line
+// counts: a block counts as LinesTotal/LinesCovered once per source line it
+// spans, mirroring how `go tool cover` reports whole-line coverage.
+func LoadGoProfile(path string) (GoReport, error) {
+	profiles, err := cover.ParseProfiles(path)
+	if err != nil {
+		return GoReport{}, fmt.Errorf("failed to parse coverage profile %s: %w", path, err)
+	}
+
+	files := make(map[string]FileCoverage, len(profiles))
+	for _, profile := range profiles {
+		var fileCov FileCoverage
+		for _, block := range profile.Blocks {
+			lines := block.EndLine - block.StartLine + 1
+			fileCov.LinesTotal += lines
+			if block.Count > 0 {
+				fileCov.LinesCovered += lines
+			}
+		}
+		files[profile.FileName] = fileCov
+	}
+	return GoReport{files: files}, nil
+}
+
+// Files implements Report.
+func (r GoReport) Files() map[string]FileCoverage {
+	return r.files
+}
@@ -0,0 +1,31 @@
+// Package coverage provides a language-agnostic per-file coverage report
+// abstraction, plus an allowlist mechanism that tolerates a file falling
+// below the configured threshold when there's a recorded reason. Vitest's
+// coverage-summary.json (see VitestReport) and Go's -coverprofile output
+// (see GoReport) both adapt into the same Report interface, so the
+// allowlist/threshold logic in LowCoverageFiles is written once rather than
+// once per language.
+package coverage
+
+// FileCoverage is one file's line coverage, in whatever units the
+// underlying tool reports (Vitest and go test both report lines).
+type FileCoverage struct {
+	LinesTotal   int
+	LinesCovered int
+}
+
+// Percent returns f's line-coverage percentage. A file with no coverable
+// lines is reported as fully covered rather than 0%, since there's nothing
+// uncovered to flag.
+func (f FileCoverage) Percent() float64 {
+	if f.LinesTotal == 0 {
+		return 100
+	}
+	return 100 * float64(f.LinesCovered) / float64(f.LinesTotal)
+}
+
+// Report is a per-file coverage result for one test run, keyed by a path
+// relative to the module or package root it was collected for.
+type Report interface {
+	Files() map[string]FileCoverage
+}
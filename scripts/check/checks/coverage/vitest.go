@@ -0,0 +1,60 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// vitestMetric is one coverage dimension (lines, statements, ...) in
+// Vitest's coverage-summary.json, as written by its built-in "json-summary"
+// reporter.
+type vitestMetric struct {
+	Total   int `json:"total"`
+	Covered int `json:"covered"`
+}
+
+// vitestFileEntry is one file's entry in coverage-summary.json. Only line
+// coverage is needed here; the other metrics (statements, functions,
+// branches) are left for callers that want Vitest's own shape.
+type vitestFileEntry struct {
+	Lines vitestMetric `json:"lines"`
+}
+
+// VitestReport adapts Vitest's coverage-summary.json into Report, keyed by
+// whatever path the summary itself uses (callers typically strip a source
+// directory prefix before comparing against an Allowlist).
+type VitestReport struct {
+	files map[string]FileCoverage
+}
+
+// LoadVitestReport reads and parses a coverage-summary.json at path,
+// skipping its "total" entry since that's an aggregate, not a file.
+func LoadVitestReport(path string) (VitestReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return VitestReport{}, fmt.Errorf("failed to read coverage summary: %w", err)
+	}
+
+	var summary map[string]vitestFileEntry
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return VitestReport{}, fmt.Errorf("failed to parse coverage summary: %w", err)
+	}
+
+	files := make(map[string]FileCoverage, len(summary))
+	for filePath, entry := range summary {
+		if filePath == "total" {
+			continue
+		}
+		files[filePath] = FileCoverage{
+			LinesTotal:   entry.Lines.Total,
+			LinesCovered: entry.Lines.Covered,
+		}
+	}
+	return VitestReport{files: files}, nil
+}
+
+// Files implements Report.
+func (r VitestReport) Files() map[string]FileCoverage {
+	return r.files
+}
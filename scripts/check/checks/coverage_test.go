@@ -0,0 +1,140 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"vmail/scripts/check/checks/config"
+)
+
+func TestGocov2lcov_ConvertsProfileToLCOV(t *testing.T) {
+	profile := strings.Join([]string{
+		"mode: set",
+		"example.com/m/foo.go:3.14,5.2 2 1",
+		"example.com/m/foo.go:7.2,9.3 1 0",
+		"example.com/m/bar.go:1.1,1.10 1 1",
+	}, "\n")
+
+	lcov, err := gocov2lcov(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"SF:example.com/m/bar.go",
+		"DA:1,1",
+		"end_of_record",
+		"SF:example.com/m/foo.go",
+		"DA:3,1",
+		"DA:4,1",
+		"DA:5,1",
+		"DA:7,0",
+		"DA:8,0",
+		"DA:9,0",
+		"end_of_record",
+		"",
+	}, "\n")
+	if lcov != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, lcov)
+	}
+}
+
+func TestGocov2lcov_OverlappingBlocksTakeTheHighestCount(t *testing.T) {
+	profile := strings.Join([]string{
+		"mode: set",
+		"foo.go:1.1,3.2 2 0",
+		"foo.go:2.1,2.5 1 1",
+	}, "\n")
+
+	lcov, err := gocov2lcov(profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	totals := lcovTotals(lcov)
+	if totals.LinesFound != 3 || totals.LinesHit != 1 {
+		t.Errorf("expected 1/3 lines hit (line 2 covered by the second block), got %d/%d", totals.LinesHit, totals.LinesFound)
+	}
+}
+
+func TestGocov2lcov_RejectsMalformedLine(t *testing.T) {
+	if _, err := gocov2lcov("mode: set\nnot a coverage line\n"); err == nil {
+		t.Fatal("expected an error for a malformed coverage line")
+	}
+}
+
+func TestLcovTotals_SumsDARecordsAcrossFiles(t *testing.T) {
+	lcov := strings.Join([]string{
+		"SF:a.go",
+		"DA:1,1",
+		"DA:2,0",
+		"end_of_record",
+		"SF:b.go",
+		"DA:1,5",
+		"end_of_record",
+	}, "\n")
+
+	totals := lcovTotals(lcov)
+	if totals.LinesFound != 3 || totals.LinesHit != 2 {
+		t.Errorf("expected 2/3 lines hit, got %d/%d", totals.LinesHit, totals.LinesFound)
+	}
+}
+
+func TestCoverageTotals_PercentWithNoLinesIsFullyCovered(t *testing.T) {
+	var totals coverageTotals
+	if pct := totals.Percent(); pct != 100 {
+		t.Errorf("expected 100%% for a leg with no coverable lines, got %v", pct)
+	}
+}
+
+func TestCollectTSCoverage_ReadsDesktopSvelteTestsSummary(t *testing.T) {
+	rootDir := t.TempDir()
+	coverageDir := filepath.Join(rootDir, "apps", "desktop", "coverage")
+	if err := os.MkdirAll(coverageDir, 0755); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	summary := `{
+		"total": {"lines": {"total": 100, "covered": 80, "skipped": 0, "pct": 80}},
+		"src/lib/foo.ts": {"lines": {"total": 10, "covered": 8, "skipped": 0, "pct": 80}}
+	}`
+	if err := os.WriteFile(filepath.Join(coverageDir, "coverage-summary.json"), []byte(summary), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	totals, ran, err := collectTSCoverage(&CheckContext{RootDir: rootDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ran {
+		t.Fatal("expected ran to be true when a coverage summary exists")
+	}
+	if totals.LinesHit != 80 || totals.LinesFound != 100 {
+		t.Errorf("expected 80/100, got %d/%d", totals.LinesHit, totals.LinesFound)
+	}
+}
+
+func TestCollectTSCoverage_SkipsWhenNoSummaryExists(t *testing.T) {
+	rootDir := t.TempDir()
+
+	_, ran, err := collectTSCoverage(&CheckContext{RootDir: rootDir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ran {
+		t.Fatal("expected ran to be false when desktop-svelte-tests hasn't produced a summary")
+	}
+}
+
+func TestCoverageThreshold_ZeroMeansUnconfigured(t *testing.T) {
+	cfg := config.Default()
+	cfg.Coverage.Go = 70
+
+	if got := coverageThreshold(cfg, "go"); got != 70 {
+		t.Errorf("expected 70, got %d", got)
+	}
+	if got := coverageThreshold(cfg, "rust"); got != 0 {
+		t.Errorf("expected 0 (unconfigured) for rust, got %d", got)
+	}
+}
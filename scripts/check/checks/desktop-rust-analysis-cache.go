@@ -0,0 +1,117 @@
+package checks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// analysisCacheVersion is bumped whenever cachedFileUse's shape or
+// scanFileLinesForUses' behavior changes, so every entry an older version
+// wrote is treated as a miss instead of being read back wrong.
+const analysisCacheVersion = 2
+
+// maxAnalysisCacheEntries caps how many per-file entries the cache directory
+// holds before evictLRU starts removing the least-recently-read ones.
+const maxAnalysisCacheEntries = 5000
+
+// analysisCache is the content-addressed on-disk cache scanForUngatedUsesFor
+// reads and writes through when ctx.Cache is set: one JSON file per (file
+// content hash, target spec), so re-scanning an unchanged .rs file on a
+// later run skips re-parsing it and re-walking its attributes entirely.
+// Keyed by content hash rather than path+mtime so a file that's touched but
+// not modified, or copied to a new path, still hits.
+type analysisCache struct {
+	dir string
+}
+
+// openAnalysisCache resolves the cache directory (see cmdrCacheDir) and
+// ensures it exists.
+func openAnalysisCache() (*analysisCache, error) {
+	dir, err := cmdrCacheDir("cfg-gate-analysis")
+	if err != nil {
+		return nil, err
+	}
+	return &analysisCache{dir: dir}, nil
+}
+
+// analysisCacheEntry is the on-disk JSON shape of one cache file.
+type analysisCacheEntry struct {
+	Version int             `json:"version"`
+	Uses    []cachedFileUse `json:"uses"`
+}
+
+// get looks up the cached use list for a file's raw content under specName,
+// returning ok=false on a miss: no entry, unreadable, corrupt, or written by
+// a different analysisCacheVersion. A hit bumps the entry's mtime so
+// evictLRU treats it as recently used.
+func (c *analysisCache) get(data []byte, specName string) (uses []cachedFileUse, ok bool) {
+	path := c.entryPath(data, specName)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry analysisCacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil || entry.Version != analysisCacheVersion {
+		return nil, false
+	}
+
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	return entry.Uses, true
+}
+
+// put writes uses to the cache under data+specName, then evicts
+// least-recently-read entries if the cache has grown past
+// maxAnalysisCacheEntries.
+func (c *analysisCache) put(data []byte, specName string, uses []cachedFileUse) error {
+	raw, err := json.Marshal(analysisCacheEntry{Version: analysisCacheVersion, Uses: uses})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.entryPath(data, specName), raw, 0644); err != nil {
+		return err
+	}
+	return c.evictLRU()
+}
+
+func (c *analysisCache) entryPath(data []byte, specName string) string {
+	sum := sha256.Sum256(data)
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+"-"+specName+".json")
+}
+
+// evictLRU removes the oldest-by-mtime entries once the cache directory
+// holds more than maxAnalysisCacheEntries files.
+func (c *analysisCache) evictLRU() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) <= maxAnalysisCacheEntries {
+		return nil
+	}
+
+	type cacheFile struct {
+		path    string
+		modTime time.Time
+	}
+	var files []cacheFile
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, cacheFile{path: filepath.Join(c.dir, e.Name()), modTime: info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+
+	for _, f := range files[:len(files)-maxAnalysisCacheEntries] {
+		_ = os.Remove(f.path)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// --- analysisCache ---
+
+func TestAnalysisCache_PutThenGetHits(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache, err := openAnalysisCache()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := []byte("use core_foundation::base::TCFType;\n")
+	uses := []cachedFileUse{{Line: 1, CrateName: "core_foundation", Gated: false}}
+	if err := cache.put(data, "macOS", uses); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, ok := cache.get(data, "macOS")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if len(got) != 1 || got[0] != uses[0] {
+		t.Errorf("expected %v, got %v", uses, got)
+	}
+}
+
+func TestAnalysisCache_GetMissesOnDifferentContent(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache, err := openAnalysisCache()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := cache.put([]byte("use foo::bar;\n"), "macOS", []cachedFileUse{{Line: 1, CrateName: "foo"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := cache.get([]byte("use baz::qux;\n"), "macOS"); ok {
+		t.Error("expected cache miss for different file content")
+	}
+}
+
+func TestAnalysisCache_GetMissesOnDifferentSpec(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache, err := openAnalysisCache()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := []byte("use foo::bar;\n")
+	if err := cache.put(data, "macOS", []cachedFileUse{{Line: 1, CrateName: "foo"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := cache.get(data, "Windows"); ok {
+		t.Error("expected cache miss for a different spec name")
+	}
+}
+
+func TestAnalysisCache_GetMissesOnVersionMismatch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache, err := openAnalysisCache()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data := []byte("use foo::bar;\n")
+	raw, err := json.Marshal(analysisCacheEntry{Version: analysisCacheVersion + 1, Uses: []cachedFileUse{{Line: 1, CrateName: "foo"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(cache.entryPath(data, "macOS"), raw, 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := cache.get(data, "macOS"); ok {
+		t.Error("expected cache miss for an entry written by a newer analysisCacheVersion")
+	}
+}
+
+func TestAnalysisCache_EvictLRURemovesOldestEntriesFirst(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	cache, err := openAnalysisCache()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Seed entries directly (bypassing put, which would run an eviction sweep
+	// after every single insert) so the test only has to drive evictLRU once,
+	// and stamp each file's mtime explicitly so eviction order is
+	// deterministic regardless of filesystem mtime granularity.
+	const total = maxAnalysisCacheEntries + 5
+	raw, err := json.Marshal(analysisCacheEntry{Version: analysisCacheVersion, Uses: []cachedFileUse{{Line: 1, CrateName: "foo"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	base := time.Now().Add(-time.Hour)
+	for i := 0; i < total; i++ {
+		path := filepath.Join(cache.dir, fmt.Sprintf("entry-%04d.json", i))
+		if err := os.WriteFile(path, raw, 0644); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		mtime := base.Add(time.Duration(i) * time.Second)
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := cache.evictLRU(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := os.ReadDir(cache.dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != maxAnalysisCacheEntries {
+		t.Errorf("expected %d entries after eviction, got %d", maxAnalysisCacheEntries, len(entries))
+	}
+
+	if _, err := os.Stat(filepath.Join(cache.dir, "entry-0000.json")); !os.IsNotExist(err) {
+		t.Error("expected the oldest entry to have been evicted")
+	}
+	last := total - 1
+	if _, err := os.Stat(filepath.Join(cache.dir, fmt.Sprintf("entry-%04d.json", last))); err != nil {
+		t.Error("expected the most recently written entry to survive eviction")
+	}
+}
@@ -0,0 +1,170 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// linuxTriple is the baseline triple resolvePlatformCrates diffs against for
+// every spec except LinuxTargetSpec itself.
+const linuxTriple = "x86_64-unknown-linux-gnu"
+
+// baselineTriple picks a triple spec's cfg facts should not match, so the
+// set-difference in resolvePlatformCrates isolates crates that are
+// genuinely only pulled in for spec's target.
+func baselineTriple(spec TargetSpec) string {
+	if spec.Triple == linuxTriple {
+		return DefaultMacOSTargetSpec.Triple
+	}
+	return linuxTriple
+}
+
+// resolvePlatformCrates shells out to `cargo metadata --filter-platform
+// <triple>` once for spec's triple and once for a non-matching baseline
+// triple, and returns the set-difference of resolved lib target names. Unlike
+// extractPlatformCrateModules (which only sees crates named directly in
+// Cargo.toml's [target.'cfg(...)'.dependencies] section), this also catches
+// transitive crates a direct macOS-only dependency pulls in — e.g.
+// objc2-app-kit bringing in objc2-foundation and block2, both `use`able from
+// code without a cfg gate of their own.
+func resolvePlatformCrates(rootDir string, spec TargetSpec) (map[string]bool, error) {
+	if spec.Triple == "" {
+		return nil, fmt.Errorf("target spec %s has no cargo triple", spec.Name)
+	}
+	cargoDir := filepath.Join(rootDir, "apps", "desktop", "src-tauri")
+
+	targetCrates, err := cargoMetadataLibNames(cargoDir, spec.Triple)
+	if err != nil {
+		return nil, err
+	}
+	baselineCrates, err := cargoMetadataLibNames(cargoDir, baselineTriple(spec))
+	if err != nil {
+		return nil, err
+	}
+
+	onlyForTarget := make(map[string]bool)
+	for name := range targetCrates {
+		if !baselineCrates[name] {
+			onlyForTarget[name] = true
+		}
+	}
+	return onlyForTarget, nil
+}
+
+// cargoMetadataOutput is the subset of `cargo metadata --format-version 1`'s
+// JSON this check needs: enough of each package's id/name/targets to map a
+// resolved dependency-graph node back to the module name code would `use`.
+type cargoMetadataOutput struct {
+	Packages []cargoMetadataPackage `json:"packages"`
+	Resolve  cargoMetadataResolve   `json:"resolve"`
+}
+
+type cargoMetadataPackage struct {
+	ID      string                `json:"id"`
+	Name    string                `json:"name"`
+	Targets []cargoMetadataTarget `json:"targets"`
+}
+
+type cargoMetadataTarget struct {
+	Kind []string `json:"kind"`
+	Name string   `json:"name"`
+}
+
+type cargoMetadataResolve struct {
+	Nodes []cargoMetadataNode `json:"nodes"`
+}
+
+type cargoMetadataNode struct {
+	ID string `json:"id"`
+}
+
+// cargoMetadataLibNames runs `cargo metadata --filter-platform triple` in
+// cargoDir and returns the lib target name of every package in the
+// platform-filtered resolved dependency graph.
+func cargoMetadataLibNames(cargoDir, triple string) (map[string]bool, error) {
+	cmd := exec.Command("cargo", "metadata", "--format-version", "1", "--filter-platform", triple)
+	cmd.Dir = cargoDir
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return nil, fmt.Errorf("cargo metadata --filter-platform %s: %w", triple, err)
+	}
+
+	var meta cargoMetadataOutput
+	if err := json.Unmarshal([]byte(output), &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse cargo metadata output: %w", err)
+	}
+
+	libNameByID := make(map[string]string, len(meta.Packages))
+	for _, pkg := range meta.Packages {
+		libNameByID[pkg.ID] = cargoLibTargetName(pkg)
+	}
+
+	names := make(map[string]bool, len(meta.Resolve.Nodes))
+	for _, node := range meta.Resolve.Nodes {
+		if name, ok := libNameByID[node.ID]; ok {
+			names[name] = true
+		}
+	}
+	return names, nil
+}
+
+// cargoLibTargetName returns the module identifier code would `use` for
+// pkg: its [lib] (or proc-macro) target's name if it declares one — which
+// may differ from the package name — falling back to the package name with
+// hyphens converted to underscores the way Cargo does by default.
+func cargoLibTargetName(pkg cargoMetadataPackage) string {
+	for _, target := range pkg.Targets {
+		for _, kind := range target.Kind {
+			if kind == "lib" || kind == "proc-macro" {
+				return strings.ReplaceAll(target.Name, "-", "_")
+			}
+		}
+	}
+	return strings.ReplaceAll(pkg.Name, "-", "_")
+}
+
+// resolvePlatformCratesFor returns spec's platform-only crate modules,
+// preferring resolvePlatformCrates (which also catches transitive crates)
+// and falling back to the direct Cargo.toml scan when cargo isn't on PATH,
+// there's no Cargo.lock yet (so metadata would need to hit the network to
+// resolve versions), or metadata fails for any other reason. Results are
+// memoized on ctx per (cargoPath, spec) so checking several targets — or
+// several crates in a workspace — in one run doesn't shell out to cargo
+// metadata twice for the same one.
+func (ctx *CheckContext) resolvePlatformCratesFor(cargoPath string, spec TargetSpec) (map[string]bool, error) {
+	cacheKey := cargoPath + "|" + spec.Name
+
+	ctx.platformCrateCacheMu.Lock()
+	if cached, ok := ctx.platformCrateCache[cacheKey]; ok {
+		ctx.platformCrateCacheMu.Unlock()
+		return cached, nil
+	}
+	ctx.platformCrateCacheMu.Unlock()
+
+	modules, err := ctx.resolvePlatformCratesOrFallback(cargoPath, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.platformCrateCacheMu.Lock()
+	if ctx.platformCrateCache == nil {
+		ctx.platformCrateCache = make(map[string]map[string]bool)
+	}
+	ctx.platformCrateCache[cacheKey] = modules
+	ctx.platformCrateCacheMu.Unlock()
+
+	return modules, nil
+}
+
+func (ctx *CheckContext) resolvePlatformCratesOrFallback(cargoPath string, spec TargetSpec) (map[string]bool, error) {
+	cargoDir := filepath.Join(ctx.RootDir, "apps", "desktop", "src-tauri")
+	if CommandExists("cargo") && fileExists(filepath.Join(cargoDir, "Cargo.lock")) {
+		if modules, err := resolvePlatformCrates(ctx.RootDir, spec); err == nil {
+			return modules, nil
+		}
+	}
+	return extractPlatformCrateModules(cargoPath, spec)
+}
@@ -21,6 +21,9 @@ func RunCargoUdeps(ctx *CheckContext) (CheckResult, error) {
 
 	// Check if cargo-udeps is installed
 	if !CommandExists("cargo-udeps") {
+		if ctx.Offline {
+			return Skipped("cargo-udeps not installed and --offline set"), nil
+		}
 		installCmd := exec.Command("cargo", "install", "cargo-udeps", "--locked")
 		if _, err := RunCommand(installCmd, true); err != nil {
 			return CheckResult{}, fmt.Errorf("failed to install cargo-udeps: %w", err)
@@ -30,10 +33,13 @@ func RunCargoUdeps(ctx *CheckContext) (CheckResult, error) {
 	// cargo-udeps requires nightly
 	cmd := exec.Command("cargo", "+nightly", "udeps", "--all-targets")
 	cmd.Dir = rustDir
-	output, err := RunCommand(cmd, true)
+	output, err := RunCommandLogged(ctx, cmd, true)
 	if err != nil {
 		// Check if nightly is not installed
 		if strings.Contains(output, "toolchain 'nightly'") {
+			if ctx.Offline {
+				return Skipped("nightly toolchain unavailable in offline mode"), nil
+			}
 			installCmd := exec.Command("rustup", "toolchain", "install", "nightly")
 			if _, err := RunCommand(installCmd, true); err != nil {
 				return CheckResult{}, fmt.Errorf("failed to install nightly")
@@ -41,7 +47,7 @@ func RunCargoUdeps(ctx *CheckContext) (CheckResult, error) {
 			// Retry
 			cmd = exec.Command("cargo", "+nightly", "udeps", "--all-targets")
 			cmd.Dir = rustDir
-			output, err = RunCommand(cmd, true)
+			output, err = RunCommandLogged(ctx, cmd, true)
 		}
 		if err != nil {
 			return CheckResult{}, fmt.Errorf("unused dependencies found\n%s", indentOutput(output))
@@ -0,0 +1,370 @@
+package checks
+
+import "fmt"
+
+// This file implements a small parser and three-valued evaluator for Rust's
+// cfg-expression language (the predicate grammar inside #[cfg(...)]),
+// modeled after Cargo's own platform-cfg handling. isMacOSGateAttribute used
+// to answer "does this attribute mention target_os = \"macos\" unnegated?"
+// with string scanning, which gets nested all()/any()/not() combinations
+// wrong (see desktop-rust-cfg-gate_test.go for the cases this was fixing).
+
+// cfgTokenKind identifies a token in a cfg-expression body.
+type cfgTokenKind int
+
+const (
+	cfgTokIdent cfgTokenKind = iota
+	cfgTokString
+	cfgTokLParen
+	cfgTokRParen
+	cfgTokComma
+	cfgTokEq
+)
+
+type cfgToken struct {
+	kind cfgTokenKind
+	text string
+}
+
+// tokenizeCfgExpr splits a cfg-expression body (the text between cfg(...)'s
+// parens) into identifiers, string literals, parens, commas and '='.
+// Whitespace is skipped; anything else unrecognized is skipped too, since a
+// malformed attribute should fail to parse rather than panic here.
+func tokenizeCfgExpr(s string) []cfgToken {
+	var tokens []cfgToken
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, cfgToken{cfgTokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, cfgToken{cfgTokRParen, ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, cfgToken{cfgTokComma, ","})
+			i++
+		case c == '=':
+			tokens = append(tokens, cfgToken{cfgTokEq, "="})
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				j++
+			}
+			tokens = append(tokens, cfgToken{cfgTokString, s[i+1 : j]})
+			i = j + 1
+		case isCfgIdentByte(c):
+			j := i
+			for j < len(s) && isCfgIdentByte(s[j]) {
+				j++
+			}
+			tokens = append(tokens, cfgToken{cfgTokIdent, s[i:j]})
+			i = j
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+func isCfgIdentByte(b byte) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// cfgExpr is the AST for a cfg-expression: cfgAll/cfgAny/cfgNot combinators
+// over cfgPred leaves.
+type cfgExpr interface {
+	isCfgExpr()
+}
+
+type cfgAll struct{ Exprs []cfgExpr }
+type cfgAny struct{ Exprs []cfgExpr }
+type cfgNot struct{ Expr cfgExpr }
+
+// cfgPred is a leaf predicate: key = "value" (e.g. target_os = "macos"), or
+// a bare atom with no value (e.g. cfg(unix), cfg(test), cfg(feature_flag)).
+type cfgPred struct {
+	Key   string
+	Value string // "" for a bare atom
+}
+
+func (cfgAll) isCfgExpr()  {}
+func (cfgAny) isCfgExpr()  {}
+func (cfgNot) isCfgExpr()  {}
+func (cfgPred) isCfgExpr() {}
+
+// cfgParser is a recursive-descent parser over a token stream for the
+// grammar: Expr := Ident '(' Expr (',' Expr)* ')' | Ident '=' String | Ident.
+type cfgParser struct {
+	tokens []cfgToken
+	pos    int
+}
+
+func (p *cfgParser) peek() (cfgToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return cfgToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+func (p *cfgParser) next() (cfgToken, bool) {
+	tok, ok := p.peek()
+	if ok {
+		p.pos++
+	}
+	return tok, ok
+}
+
+// parseCfgExprTokens parses a full cfg-expression from tokens, erroring on
+// anything that isn't well-formed (unknown combinator, missing string after
+// '=', mismatched parens, trailing tokens).
+func parseCfgExprTokens(tokens []cfgToken) (cfgExpr, error) {
+	p := &cfgParser{tokens: tokens}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := p.peek(); ok {
+		return nil, fmt.Errorf("unexpected trailing tokens in cfg expression")
+	}
+	return expr, nil
+}
+
+func (p *cfgParser) parseExpr() (cfgExpr, error) {
+	tok, ok := p.next()
+	if !ok || tok.kind != cfgTokIdent {
+		return nil, fmt.Errorf("expected identifier in cfg expression")
+	}
+	name := tok.text
+
+	if next, ok := p.peek(); ok && next.kind == cfgTokLParen {
+		p.pos++
+		children, err := p.parseExprList()
+		if err != nil {
+			return nil, err
+		}
+		switch name {
+		case "all":
+			return cfgAll{Exprs: children}, nil
+		case "any":
+			return cfgAny{Exprs: children}, nil
+		case "not":
+			if len(children) != 1 {
+				return nil, fmt.Errorf("not() expects exactly one argument, got %d", len(children))
+			}
+			return cfgNot{Expr: children[0]}, nil
+		default:
+			return nil, fmt.Errorf("unknown cfg combinator %q", name)
+		}
+	}
+
+	if next, ok := p.peek(); ok && next.kind == cfgTokEq {
+		p.pos++
+		valTok, ok := p.next()
+		if !ok || valTok.kind != cfgTokString {
+			return nil, fmt.Errorf("expected string literal after %s =", name)
+		}
+		return cfgPred{Key: name, Value: valTok.text}, nil
+	}
+
+	return cfgPred{Key: name}, nil
+}
+
+func (p *cfgParser) parseExprList() ([]cfgExpr, error) {
+	var children []cfgExpr
+	for {
+		if tok, ok := p.peek(); ok && tok.kind == cfgTokRParen {
+			p.pos++
+			return children, nil
+		}
+		child, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+
+		tok, ok := p.next()
+		if !ok {
+			return nil, fmt.Errorf("unclosed cfg expression")
+		}
+		switch tok.kind {
+		case cfgTokRParen:
+			return children, nil
+		case cfgTokComma:
+			continue
+		default:
+			return nil, fmt.Errorf("expected ',' or ')' in cfg expression")
+		}
+	}
+}
+
+// triState is three-valued logic over whether a cfg-expression holds for a
+// given target: definitely true, definitely false, or unknown (a feature
+// flag or other predicate we don't have a fixed answer for).
+type triState int
+
+const (
+	triFalse triState = iota
+	triMaybe
+	triTrue
+)
+
+// platformKeys are the cfg keys this evaluator has fixed facts for. A
+// key=value predicate using any other key (feature, debug_assertions, ...)
+// evaluates as triMaybe, since its truth depends on things outside the
+// fixed target predicate set.
+var platformKeys = map[string]bool{
+	"target_os":     true,
+	"target_family": true,
+	"target_vendor": true,
+	"target_env":    true,
+	"target_arch":   true,
+}
+
+// platformAtoms are the bare (no '=') cfg atoms this evaluator knows how to
+// resolve against the fixed target predicate set, e.g. cfg(unix).
+var platformAtoms = map[string]bool{
+	"unix":    true,
+	"windows": true,
+}
+
+// containsPlatformPredicate reports whether expr mentions at least one
+// predicate this evaluator has a fixed answer for. An expression built
+// entirely from feature flags or other unknown predicates can't be
+// classified as any particular platform's gate — see isMacOSGateAttribute.
+func containsPlatformPredicate(expr cfgExpr) bool {
+	switch e := expr.(type) {
+	case cfgAll:
+		for _, c := range e.Exprs {
+			if containsPlatformPredicate(c) {
+				return true
+			}
+		}
+	case cfgAny:
+		for _, c := range e.Exprs {
+			if containsPlatformPredicate(c) {
+				return true
+			}
+		}
+	case cfgNot:
+		return containsPlatformPredicate(e.Expr)
+	case cfgPred:
+		if e.Value != "" {
+			return platformKeys[e.Key]
+		}
+		return platformAtoms[e.Key]
+	}
+	return false
+}
+
+// containsPositiveMatchFor reports whether expr, accounting for how many
+// cfgNot layers wrap it so far (negated), directly asserts one of facts'
+// key=value pairs true — e.g. target_os = "macos" or all(unix, target_os =
+// "macos") count, but not(target_os = "windows") doesn't, even though it
+// also evaluates true whenever facts' target_os isn't windows. Used by
+// TargetSpec.RejectNegationOnlyGate to require an explicit positive gate per
+// platform instead of accepting one that's only incidentally true because it
+// excludes some other, unrelated platform.
+func containsPositiveMatchFor(expr cfgExpr, facts map[string]string, negated bool) bool {
+	switch e := expr.(type) {
+	case cfgAll:
+		for _, c := range e.Exprs {
+			if containsPositiveMatchFor(c, facts, negated) {
+				return true
+			}
+		}
+	case cfgAny:
+		for _, c := range e.Exprs {
+			if containsPositiveMatchFor(c, facts, negated) {
+				return true
+			}
+		}
+	case cfgNot:
+		return containsPositiveMatchFor(e.Expr, facts, !negated)
+	case cfgPred:
+		if negated || e.Value == "" {
+			return false
+		}
+		return facts[e.Key] == e.Value
+	}
+	return false
+}
+
+// evalCfg evaluates expr against facts using Kleene three-valued logic:
+// all()/any() short-circuit on a definite false/true the way && and ||
+// would, but otherwise propagate triMaybe rather than guessing.
+func evalCfg(expr cfgExpr, facts map[string]string) triState {
+	switch e := expr.(type) {
+	case cfgAll:
+		result := triTrue
+		for _, c := range e.Exprs {
+			switch evalCfg(c, facts) {
+			case triFalse:
+				return triFalse
+			case triMaybe:
+				result = triMaybe
+			}
+		}
+		return result
+	case cfgAny:
+		result := triFalse
+		for _, c := range e.Exprs {
+			switch evalCfg(c, facts) {
+			case triTrue:
+				return triTrue
+			case triMaybe:
+				result = triMaybe
+			}
+		}
+		return result
+	case cfgNot:
+		switch evalCfg(e.Expr, facts) {
+		case triTrue:
+			return triFalse
+		case triFalse:
+			return triTrue
+		default:
+			return triMaybe
+		}
+	case cfgPred:
+		return evalCfgPred(e, facts)
+	}
+	return triMaybe
+}
+
+func evalCfgPred(pred cfgPred, facts map[string]string) triState {
+	if pred.Value == "" {
+		switch pred.Key {
+		case "unix":
+			return evalCfgKnownFact(facts, "target_family", "unix")
+		case "windows":
+			return evalCfgKnownFact(facts, "target_os", "windows")
+		default:
+			return triMaybe // feature flags, test, debug_assertions, etc.
+		}
+	}
+
+	fact, known := facts[pred.Key]
+	if !known {
+		return triMaybe
+	}
+	if fact == pred.Value {
+		return triTrue
+	}
+	return triFalse
+}
+
+func evalCfgKnownFact(facts map[string]string, key, value string) triState {
+	fact, known := facts[key]
+	if !known {
+		return triMaybe
+	}
+	if fact == value {
+		return triTrue
+	}
+	return triFalse
+}
@@ -0,0 +1,208 @@
+package checks
+
+import "testing"
+
+func TestEvalCfg_NestedAllAnyNot(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		expect triState
+	}{
+		{"simple pred true", `target_os = "macos"`, triTrue},
+		{"simple pred false", `target_os = "linux"`, triFalse},
+		{"any with one true", `any(target_os = "macos", target_os = "ios")`, triTrue},
+		{"any all false", `any(target_os = "linux", target_os = "windows")`, triFalse},
+		{"not true is false", `not(target_os = "macos")`, triFalse},
+		{"not false is true", `not(target_os = "windows")`, triTrue},
+		{"all with a false member is false", `all(any(target_os = "macos", target_os = "ios"), not(target_os = "macos"))`, triFalse},
+		{"all with unknown and true stays maybe", `all(feature = "gui", target_os = "macos")`, triMaybe},
+		{"all excluding linux and windows is maybe on macos", `all(feature = "gui", not(any(target_os = "linux", target_os = "windows")))`, triMaybe},
+		{"bare unix atom", `unix`, triTrue},
+		{"bare windows atom", `windows`, triFalse},
+		{"bare unknown atom", `test`, triMaybe},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseCfgExprTokens(tokenizeCfgExpr(tt.body))
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := evalCfg(expr, DefaultMacOSTargetSpec.Facts); got != tt.expect {
+				t.Errorf("evalCfg(%q) = %v, want %v", tt.body, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestContainsPlatformPredicate(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		expect bool
+	}{
+		{"bare feature flag", `feature = "gui"`, false},
+		{"bare unknown atom", `test`, false},
+		{"target_os present", `target_os = "macos"`, true},
+		{"nested under not/any", `all(feature = "gui", not(any(target_os = "linux", target_os = "windows")))`, true},
+		{"unix atom counts", `unix`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseCfgExprTokens(tokenizeCfgExpr(tt.body))
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := containsPlatformPredicate(expr); got != tt.expect {
+				t.Errorf("containsPlatformPredicate(%q) = %v, want %v", tt.body, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestParseCfgExprTokens_Errors(t *testing.T) {
+	tests := []string{
+		`all(target_os = "macos"`,        // unclosed
+		`not(target_os = "macos", test)`, // not() takes exactly one argument
+		`target_os =`,                    // missing string after '='
+		`bogus(target_os = "macos")`,     // unknown combinator
+	}
+
+	for _, body := range tests {
+		t.Run(body, func(t *testing.T) {
+			if _, err := parseCfgExprTokens(tokenizeCfgExpr(body)); err == nil {
+				t.Errorf("expected parse error for %q", body)
+			}
+		})
+	}
+}
+
+// TestEvalCfg_TargetArch exercises target_arch with an explicit facts map
+// (rather than a TargetSpec's), since none of this package's shipped specs
+// pin an architecture — they gate on OS alone, matching either arch of a
+// given target_os the way DefaultMacOSTargetSpec has always behaved (see
+// TestExtractPlatformCrateModules_CompoundCfgPredicate).
+func TestEvalCfg_TargetArch(t *testing.T) {
+	aarch64Mac := map[string]string{"target_os": "macos", "target_arch": "aarch64"}
+
+	tests := []struct {
+		name   string
+		body   string
+		facts  map[string]string
+		expect triState
+	}{
+		{"arch matches", `target_arch = "aarch64"`, aarch64Mac, triTrue},
+		{"arch mismatches", `target_arch = "x86_64"`, aarch64Mac, triFalse},
+		{"compound os+arch matches", `all(target_os = "macos", target_arch = "aarch64")`, aarch64Mac, triTrue},
+		{"compound os+arch mismatches on arch", `all(target_os = "macos", target_arch = "x86_64")`, aarch64Mac, triFalse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseCfgExprTokens(tokenizeCfgExpr(tt.body))
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if got := evalCfg(expr, tt.facts); got != tt.expect {
+				t.Errorf("evalCfg(%q) = %v, want %v", tt.body, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestIsGateAttributeFor_MultiTargetCompoundExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		attr     string
+		spec     TargetSpec
+		expected bool
+	}{
+		{
+			name:     "excludes macos and ios still gates windows",
+			attr:     `#[cfg(all(feature = "gui", not(any(target_os = "macos", target_os = "ios"))))]`,
+			spec:     WindowsTargetSpec,
+			expected: true,
+		},
+		{
+			name:     "any windows/linux negated by outer not-windows is never windows",
+			attr:     `#[cfg(all(any(target_os = "windows", target_os = "linux"), not(target_os = "windows")))]`,
+			spec:     WindowsTargetSpec,
+			expected: false,
+		},
+		{
+			name:     "unix atom gates linux",
+			attr:     `#[cfg(unix)]`,
+			spec:     LinuxTargetSpec,
+			expected: true,
+		},
+		{
+			name:     "unix atom never gates windows",
+			attr:     `#[cfg(unix)]`,
+			spec:     WindowsTargetSpec,
+			expected: false,
+		},
+		{
+			name:     "windows atom gates windows",
+			attr:     `#[cfg(windows)]`,
+			spec:     WindowsTargetSpec,
+			expected: true,
+		},
+		{
+			name:     "target_arch alone gates ios",
+			attr:     `#[cfg(target_arch = "aarch64")]`,
+			spec:     IOSTargetSpec,
+			expected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isGateAttributeFor(tt.attr, tt.spec); got != tt.expected {
+				t.Errorf("isGateAttributeFor(%q, %s) = %v, want %v", tt.attr, tt.spec.Name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsMacOSGateAttribute_CompoundExpressions(t *testing.T) {
+	tests := []struct {
+		name     string
+		attr     string
+		expected bool
+	}{
+		{
+			name:     "excludes linux and windows still gates macos",
+			attr:     `#[cfg(all(feature = "gui", not(any(target_os = "linux", target_os = "windows"))))]`,
+			expected: true,
+		},
+		{
+			name:     "any macos/ios negated by outer not-macos is never macos",
+			attr:     `#[cfg(all(any(target_os = "macos", target_os = "ios"), not(target_os = "macos")))]`,
+			expected: false,
+		},
+		{
+			name:     "bare feature flag alone is not a macos gate",
+			attr:     `#[cfg(feature = "gui")]`,
+			expected: false,
+		},
+		{
+			name:     "unix atom gates macos",
+			attr:     `#[cfg(unix)]`,
+			expected: true,
+		},
+		{
+			name:     "windows atom never gates macos",
+			attr:     `#[cfg(windows)]`,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isGateAttributeFor(tt.attr, DefaultMacOSTargetSpec); got != tt.expected {
+				t.Errorf("isGateAttributeFor(%q) = %v, want %v", tt.attr, got, tt.expected)
+			}
+		})
+	}
+}
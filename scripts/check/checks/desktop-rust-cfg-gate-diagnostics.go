@@ -0,0 +1,77 @@
+package checks
+
+import "fmt"
+
+// cfgGateRule is the value RunCfgGate's diagnostics use for Diagnostic.Rule,
+// matching the check's registry.go Nickname so a consumer can join a
+// diagnostic back to the check that produced it.
+const cfgGateRule = "cfg-gate"
+
+// cfgExprJSON is cfgExpr's JSON-marshalable shape: exactly one of All, Any,
+// Not or Key is populated, mirroring which of cfgAll/cfgAny/cfgNot/cfgPred
+// the source node was. Kept as its own type (rather than marshaling cfgExpr
+// directly) since cfgExpr's variants are unexported structs picked by a type
+// switch, not something encoding/json can discriminate on its own.
+type cfgExprJSON struct {
+	All []cfgExprJSON `json:"all,omitempty"`
+	Any []cfgExprJSON `json:"any,omitempty"`
+	Not *cfgExprJSON  `json:"not,omitempty"`
+	Key string        `json:"key,omitempty"`
+	// Value is omitted for a bare atom predicate like cfg(unix).
+	Value string `json:"value,omitempty"`
+}
+
+// cfgExprToJSON converts a parsed cfg-expression AST into cfgExprJSON, so a
+// Diagnostic's GatedBy field carries the fully structured expression instead
+// of re-rendering it back into attribute-text form.
+func cfgExprToJSON(expr cfgExpr) cfgExprJSON {
+	switch e := expr.(type) {
+	case cfgAll:
+		return cfgExprJSON{All: cfgExprListToJSON(e.Exprs)}
+	case cfgAny:
+		return cfgExprJSON{Any: cfgExprListToJSON(e.Exprs)}
+	case cfgNot:
+		child := cfgExprToJSON(e.Expr)
+		return cfgExprJSON{Not: &child}
+	case cfgPred:
+		return cfgExprJSON{Key: e.Key, Value: e.Value}
+	default:
+		return cfgExprJSON{}
+	}
+}
+
+func cfgExprListToJSON(exprs []cfgExpr) []cfgExprJSON {
+	result := make([]cfgExprJSON, len(exprs))
+	for i, e := range exprs {
+		result[i] = cfgExprToJSON(e)
+	}
+	return result
+}
+
+// violationToDiagnostic converts one ungated-use violation into the
+// structured schema --format=json/--format=sarif emit. GatedBy is the
+// parsed cfg-expression spec.CargoCfgKey describes — the gate the violation
+// is missing — built with the same extractCfgBody/tokenizeCfgExpr/
+// parseCfgExprTokens pipeline isGateAttributeFor uses to read an existing
+// attribute, just run against spec's own cfg key instead of source text.
+// SuggestedFix mirrors the attribute applyCfgGateFixes would insert.
+func violationToDiagnostic(v violation, spec TargetSpec) Diagnostic {
+	diag := Diagnostic{
+		File:         v.relPath,
+		Line:         v.line,
+		Col:          v.col,
+		EndLine:      v.line,
+		EndCol:       v.col + len(v.crateName),
+		Rule:         cfgGateRule,
+		Message:      fmt.Sprintf("use of %s-only crate '%s' without #[%s]", spec.Name, v.crateName, spec.CargoCfgKey),
+		SuggestedFix: fmt.Sprintf("#[%s]", spec.CargoCfgKey),
+	}
+
+	if body, ok := extractCfgBody(spec.CargoCfgKey); ok {
+		if expr, err := parseCfgExprTokens(tokenizeCfgExpr(body)); err == nil {
+			diag.GatedBy = cfgExprToJSON(expr)
+		}
+	}
+
+	return diag
+}
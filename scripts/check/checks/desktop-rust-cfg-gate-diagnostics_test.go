@@ -0,0 +1,102 @@
+package checks
+
+import "testing"
+
+// --- cfgExprToJSON ---
+
+func TestCfgExprToJSON_BarePredicate(t *testing.T) {
+	expr := cfgPred{Key: "target_os", Value: "macos"}
+
+	got := cfgExprToJSON(expr)
+
+	if got.Key != "target_os" || got.Value != "macos" {
+		t.Errorf("expected {Key: target_os, Value: macos}, got %+v", got)
+	}
+	if got.All != nil || got.Any != nil || got.Not != nil {
+		t.Errorf("expected only Key/Value populated, got %+v", got)
+	}
+}
+
+func TestCfgExprToJSON_BareAtomHasNoValue(t *testing.T) {
+	expr := cfgPred{Key: "unix"}
+
+	got := cfgExprToJSON(expr)
+
+	if got.Key != "unix" || got.Value != "" {
+		t.Errorf("expected {Key: unix, Value: \"\"}, got %+v", got)
+	}
+}
+
+func TestCfgExprToJSON_Not(t *testing.T) {
+	expr := cfgNot{Expr: cfgPred{Key: "target_os", Value: "windows"}}
+
+	got := cfgExprToJSON(expr)
+
+	if got.Not == nil {
+		t.Fatal("expected Not to be populated")
+	}
+	if got.Not.Key != "target_os" || got.Not.Value != "windows" {
+		t.Errorf("expected negated predicate to round-trip, got %+v", got.Not)
+	}
+}
+
+func TestCfgExprToJSON_NestedAllAny(t *testing.T) {
+	// all(any(target_os = "macos", target_os = "ios"), not(test))
+	expr := cfgAll{Exprs: []cfgExpr{
+		cfgAny{Exprs: []cfgExpr{
+			cfgPred{Key: "target_os", Value: "macos"},
+			cfgPred{Key: "target_os", Value: "ios"},
+		}},
+		cfgNot{Expr: cfgPred{Key: "test"}},
+	}}
+
+	got := cfgExprToJSON(expr)
+
+	if len(got.All) != 2 {
+		t.Fatalf("expected 2 children under All, got %+v", got)
+	}
+	if len(got.All[0].Any) != 2 {
+		t.Errorf("expected 2 children under the nested Any, got %+v", got.All[0])
+	}
+	if got.All[1].Not == nil || got.All[1].Not.Key != "test" {
+		t.Errorf("expected second child to be not(test), got %+v", got.All[1])
+	}
+}
+
+// --- violationToDiagnostic ---
+
+func TestViolationToDiagnostic_FieldsAndGatedBy(t *testing.T) {
+	v := violation{
+		relPath:   "apps/desktop/src-tauri/src/foo.rs",
+		absPath:   "/repo/apps/desktop/src-tauri/src/foo.rs",
+		line:      12,
+		col:       5,
+		crateName: "core_foundation",
+	}
+
+	diag := violationToDiagnostic(v, DefaultMacOSTargetSpec)
+
+	if diag.File != v.relPath {
+		t.Errorf("expected File %q, got %q", v.relPath, diag.File)
+	}
+	if diag.Line != 12 || diag.EndLine != 12 {
+		t.Errorf("expected Line/EndLine 12, got %d/%d", diag.Line, diag.EndLine)
+	}
+	if diag.Col != 5 || diag.EndCol != 5+len("core_foundation") {
+		t.Errorf("expected Col 5 and EndCol %d, got %d/%d", 5+len("core_foundation"), diag.Col, diag.EndCol)
+	}
+	if diag.Rule != cfgGateRule {
+		t.Errorf("expected Rule %q, got %q", cfgGateRule, diag.Rule)
+	}
+	if diag.SuggestedFix != "#[cfg(target_os = \"macos\")]" {
+		t.Errorf("unexpected SuggestedFix: %q", diag.SuggestedFix)
+	}
+
+	gatedBy, ok := diag.GatedBy.(cfgExprJSON)
+	if !ok {
+		t.Fatalf("expected GatedBy to be a cfgExprJSON, got %T", diag.GatedBy)
+	}
+	if gatedBy.Key != "target_os" || gatedBy.Value != "macos" {
+		t.Errorf("expected GatedBy {target_os: macos}, got %+v", gatedBy)
+	}
+}
@@ -0,0 +1,111 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Fixer applies cfg-gate fixes programmatically, wrapping applyCfgGateFixes
+// so callers other than RunCfgGate's own ctx.Fix path (tests, or a future
+// driver) can insert or preview the same gate attributes without going
+// through the CLI.
+//
+// This doesn't rewrite Rust source through a full syntax tree: the whole
+// checker — tokenizeCfgExpr's cfg-expression parser aside — reads attributes
+// and use-statements with targeted regexes (see desktop-rust-cfg-gate.go),
+// and Fixer follows the same approach rather than introducing a second,
+// heavier way of editing the same files. It also never needs to fall back to
+// wrapping a use statement in a cfg-gated `mod` to work around an enclosing
+// scope that "can't take an attribute": every violation Fixer sees is a
+// `use` item, and `use` can always carry its own #[cfg(...)] directly above
+// it in Rust's grammar, so no such scope exists in practice.
+type Fixer struct {
+	Spec   TargetSpec
+	DryRun bool // preview the unified-diff-style summary without writing files
+}
+
+// Fix inserts f.Spec's cfg gate attribute above every violation's use
+// statement (or builds the diff preview, if f.DryRun), returning the same
+// unified-diff-style summary RunCfgGate's own ctx.Fix path reports.
+func (f Fixer) Fix(violations []violation) (string, error) {
+	return applyCfgGateFixes(violations, f.Spec, f.DryRun)
+}
+
+// fixedUse records one ungated use RunCfgGate has inserted spec's cfg gate
+// attribute above, used to build the unified-diff-style summary after the
+// rewrite has already shifted surrounding line numbers.
+type fixedUse struct {
+	v        violation
+	newLine  int // v.line, shifted by every attribute already inserted above it in this file
+	inserted string
+	useLine  string
+}
+
+// applyCfgGateFixes rewrites each violation's file to insert spec's cfg gate
+// attribute directly above the offending `use` line, matching that line's own
+// indentation. One attribute is inserted per use — adjacent ungated uses of
+// the same crate each get their own line rather than being folded into a
+// wrapping module. Pass dryRun to build the same unified-diff-style summary
+// without writing any file.
+func applyCfgGateFixes(violations []violation, spec TargetSpec, dryRun bool) (string, error) {
+	byFile := make(map[string][]violation)
+	var fileOrder []string
+	for _, v := range violations {
+		if _, ok := byFile[v.absPath]; !ok {
+			fileOrder = append(fileOrder, v.absPath)
+		}
+		byFile[v.absPath] = append(byFile[v.absPath], v)
+	}
+
+	attrLine := fmt.Sprintf("#[%s]", spec.CargoCfgKey)
+	var sb strings.Builder
+
+	for _, absPath := range fileOrder {
+		fileViolations := append([]violation(nil), byFile[absPath]...)
+		sort.Slice(fileViolations, func(i, j int) bool { return fileViolations[i].line < fileViolations[j].line })
+
+		data, err := os.ReadFile(absPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", fileViolations[0].relPath, err)
+		}
+		lines := strings.Split(string(data), "\n")
+
+		// Insert bottom-up so each violation's recorded line number still
+		// points at its own `use` statement when its turn comes.
+		fixed := make([]fixedUse, len(fileViolations))
+		for i := len(fileViolations) - 1; i >= 0; i-- {
+			v := fileViolations[i]
+			idx := v.line - 1
+			inserted := leadingWhitespace(lines[idx]) + attrLine
+			useLine := lines[idx]
+			lines = append(lines[:idx], append([]string{inserted}, lines[idx:]...)...)
+			// i earlier violations each shift this one's line down by one.
+			fixed[i] = fixedUse{v: v, newLine: v.line + i, inserted: inserted, useLine: useLine}
+		}
+
+		if !dryRun {
+			if err := os.WriteFile(absPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+				return "", fmt.Errorf("failed to write %s: %w", fileViolations[0].relPath, err)
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("--- a/%s\n+++ b/%s\n", fileViolations[0].relPath, fileViolations[0].relPath))
+		for _, f := range fixed {
+			sb.WriteString(fmt.Sprintf("@@ -%d +%d,2 @@\n+%s\n %s\n", f.v.line, f.newLine, f.inserted, f.useLine))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// leadingWhitespace returns the spaces/tabs line starts with, used so an
+// inserted attribute lines up with the use statement it gates.
+func leadingWhitespace(line string) string {
+	i := 0
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	return line[:i]
+}
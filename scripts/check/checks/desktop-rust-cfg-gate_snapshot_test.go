@@ -0,0 +1,97 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"vmail/scripts/check/checks/snapshot"
+)
+
+// These cover the same two RunCfgGate outcomes as
+// TestRunCfgGate_ProperlyGatedPasses/TestRunCfgGate_UngatedReportsViolation,
+// but via golden files instead of strings.Contains, so the pluralization
+// branches in RunCfgGate's success message ("1 gated use" vs "2 gated
+// uses") and the full shape of the multi-line violation report are both
+// actually pinned down, not just spot-checked for a couple of substrings.
+
+func writeCfgGateFixture(t *testing.T, root string, rsFiles map[string]string) {
+	t.Helper()
+	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cargoDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	cargoContent := `
+[package]
+name = "test-app"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+core-foundation = "0.10.1"
+`
+	if err := os.WriteFile(filepath.Join(cargoDir, "Cargo.toml"), []byte(cargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range rsFiles {
+		if err := os.WriteFile(filepath.Join(srcDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRunCfgGate_SingleGatedUse_MatchesSnapshot(t *testing.T) {
+	root := t.TempDir()
+	writeCfgGateFixture(t, root, map[string]string{
+		"lib.rs": "mod something;\n",
+		"something.rs": `#[cfg(target_os = "macos")]
+use core_foundation::base::TCFType;
+
+fn main() {}
+`,
+	})
+
+	ctx := &CheckContext{RootDir: root}
+	result, err := RunCfgGate(ctx)
+
+	snapshot.AssertCheck(t, root, result.Message, err, "testdata/cfgate_single_use_ok.txt")
+}
+
+func TestRunCfgGate_MultipleGatedUses_MatchesSnapshot(t *testing.T) {
+	root := t.TempDir()
+	writeCfgGateFixture(t, root, map[string]string{
+		"lib.rs": "mod one;\nmod two;\n",
+		"one.rs": `#[cfg(target_os = "macos")]
+use core_foundation::base::TCFType;
+
+fn main() {}
+`,
+		"two.rs": `#[cfg(target_os = "macos")]
+use core_foundation::string::CFString;
+
+fn main() {}
+`,
+	})
+
+	ctx := &CheckContext{RootDir: root}
+	result, err := RunCfgGate(ctx)
+
+	snapshot.AssertCheck(t, root, result.Message, err, "testdata/cfgate_plural_uses_ok.txt")
+}
+
+func TestRunCfgGate_UngatedUse_MatchesSnapshot(t *testing.T) {
+	root := t.TempDir()
+	writeCfgGateFixture(t, root, map[string]string{
+		"lib.rs": "mod ungated;\n",
+		"ungated.rs": `use core_foundation::base::TCFType;
+
+fn main() {}
+`,
+	})
+
+	ctx := &CheckContext{RootDir: root}
+	result, err := RunCfgGate(ctx)
+
+	snapshot.AssertCheck(t, root, result.Message, err, "testdata/cfgate_ungated_use.txt")
+}
@@ -23,7 +23,7 @@ core-foundation = "0.10.1"
 		t.Fatal(err)
 	}
 
-	modules, err := extractMacOSCrateModules(cargoPath)
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -46,7 +46,7 @@ objc2 = { version = "0.6", features = ["std"] }
 		t.Fatal(err)
 	}
 
-	modules, err := extractMacOSCrateModules(cargoPath)
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -69,7 +69,7 @@ cmdr-fsevent-stream = { git = "https://example.com/repo", rev = "abc123" }
 		t.Fatal(err)
 	}
 
-	modules, err := extractMacOSCrateModules(cargoPath)
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -96,7 +96,7 @@ objc2-app-kit = { version = "0.3", features = [
 		t.Fatal(err)
 	}
 
-	modules, err := extractMacOSCrateModules(cargoPath)
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -119,7 +119,7 @@ serde = "1.0"
 		t.Fatal(err)
 	}
 
-	modules, err := extractMacOSCrateModules(cargoPath)
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -144,7 +144,7 @@ simple = "0.1"
 		t.Fatal(err)
 	}
 
-	modules, err := extractMacOSCrateModules(cargoPath)
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -165,19 +165,140 @@ simple = "0.1"
 }
 
 func TestExtractMacOSCrateModules_InvalidFile(t *testing.T) {
-	_, err := extractMacOSCrateModules("/nonexistent/Cargo.toml")
+	_, err := extractPlatformCrateModules("/nonexistent/Cargo.toml", DefaultMacOSTargetSpec)
 	if err == nil {
 		t.Error("expected error for nonexistent file")
 	}
 }
 
+func TestExtractPlatformCrateModules_PackageRename(t *testing.T) {
+	dir := t.TempDir()
+	cargoPath := filepath.Join(dir, "Cargo.toml")
+	content := `
+[package]
+name = "test"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+cf = { package = "core-foundation", version = "0.10.1" }
+`
+	if err := os.WriteFile(cargoPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modules["core_foundation"] {
+		t.Errorf("expected core_foundation (via package rename) in modules, got %v", modules)
+	}
+	if modules["cf"] {
+		t.Errorf("expected the table key 'cf' not to be used as the module name, got %v", modules)
+	}
+}
+
+func TestExtractPlatformCrateModules_DottedKeyTable(t *testing.T) {
+	dir := t.TempDir()
+	cargoPath := filepath.Join(dir, "Cargo.toml")
+	content := `
+[package]
+name = "test"
+
+[target.'cfg(target_os = "macos")'.dependencies.core-foundation]
+version = "0.10.1"
+`
+	if err := os.WriteFile(cargoPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modules["core_foundation"] {
+		t.Errorf("expected core_foundation in modules, got %v", modules)
+	}
+}
+
+func TestExtractPlatformCrateModules_WorkspaceInheritance(t *testing.T) {
+	dir := t.TempDir()
+	cargoPath := filepath.Join(dir, "Cargo.toml")
+	content := `
+[package]
+name = "test"
+
+[workspace.dependencies]
+core-foundation = { package = "core-foundation", version = "0.10.1" }
+
+[target.'cfg(target_os = "macos")'.dependencies]
+core-foundation = { workspace = true }
+`
+	if err := os.WriteFile(cargoPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modules["core_foundation"] {
+		t.Errorf("expected core_foundation (via workspace inheritance) in modules, got %v", modules)
+	}
+}
+
+func TestExtractPlatformCrateModules_CompoundCfgPredicate(t *testing.T) {
+	dir := t.TempDir()
+	cargoPath := filepath.Join(dir, "Cargo.toml")
+	content := `
+[package]
+name = "test"
+
+[target.'cfg(all(target_os = "macos", target_arch = "aarch64"))'.dependencies]
+core-foundation = "0.10.1"
+`
+	if err := os.WriteFile(cargoPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !modules["core_foundation"] {
+		t.Errorf("expected core_foundation (compound cfg predicate selects macOS) in modules, got %v", modules)
+	}
+}
+
+func TestExtractPlatformCrateModules_CompoundCfgPredicateExcludesOtherTarget(t *testing.T) {
+	dir := t.TempDir()
+	cargoPath := filepath.Join(dir, "Cargo.toml")
+	content := `
+[package]
+name = "test"
+
+[target.'cfg(all(target_os = "windows", target_arch = "aarch64"))'.dependencies]
+windows-sys = "0.52"
+`
+	if err := os.WriteFile(cargoPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modules, err := extractPlatformCrateModules(cargoPath, DefaultMacOSTargetSpec)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(modules) != 0 {
+		t.Errorf("expected no modules (windows-only predicate doesn't select macOS), got %v", modules)
+	}
+}
+
 // --- findCfgGatedModules ---
 
 func TestFindCfgGatedModules_BasicMod(t *testing.T) {
 	lines := strings.Split(`#[cfg(target_os = "macos")]
 mod foo;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 1 || result[0] != "foo" {
 		t.Errorf("expected [foo], got %v", result)
 	}
@@ -187,7 +308,7 @@ func TestFindCfgGatedModules_PubMod(t *testing.T) {
 	lines := strings.Split(`#[cfg(target_os = "macos")]
 pub mod bar;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 1 || result[0] != "bar" {
 		t.Errorf("expected [bar], got %v", result)
 	}
@@ -197,7 +318,7 @@ func TestFindCfgGatedModules_PubCrateMod(t *testing.T) {
 	lines := strings.Split(`#[cfg(target_os = "macos")]
 pub(crate) mod baz;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 1 || result[0] != "baz" {
 		t.Errorf("expected [baz], got %v", result)
 	}
@@ -207,7 +328,7 @@ func TestFindCfgGatedModules_RegularModNotFound(t *testing.T) {
 	lines := strings.Split(`mod regular;
 pub mod also_regular;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 0 {
 		t.Errorf("expected empty result for ungated modules, got %v", result)
 	}
@@ -218,7 +339,7 @@ func TestFindCfgGatedModules_BlankLineBetweenCfgAndMod(t *testing.T) {
 
 mod spaced;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 1 || result[0] != "spaced" {
 		t.Errorf("expected [spaced], got %v", result)
 	}
@@ -229,7 +350,7 @@ func TestFindCfgGatedModules_MultipleStackedAttributes(t *testing.T) {
 #[allow(dead_code)]
 mod stacked;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 1 || result[0] != "stacked" {
 		t.Errorf("expected [stacked], got %v", result)
 	}
@@ -243,7 +364,7 @@ pub mod also_ungated;
 #[cfg(target_os = "macos")]
 pub mod gated_two;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 2 {
 		t.Fatalf("expected 2 gated modules, got %d: %v", len(result), result)
 	}
@@ -258,7 +379,7 @@ func TestHasMacOSCfgAttribute_DirectPreviousLine(t *testing.T) {
 	lines := strings.Split(`#[cfg(target_os = "macos")]
 use core_foundation::base;`, "\n")
 
-	if !hasMacOSCfgAttribute(lines, 1) {
+	if !hasGateAttributeFor(lines, 1, DefaultMacOSTargetSpec) {
 		t.Error("expected true for cfg gate on previous line")
 	}
 }
@@ -268,7 +389,7 @@ func TestHasMacOSCfgAttribute_BlankLineBetween(t *testing.T) {
 
 use core_foundation::base;`, "\n")
 
-	if !hasMacOSCfgAttribute(lines, 2) {
+	if !hasGateAttributeFor(lines, 2, DefaultMacOSTargetSpec) {
 		t.Error("expected true for cfg gate with blank line between")
 	}
 }
@@ -277,7 +398,7 @@ func TestHasMacOSCfgAttribute_NoCfgGate(t *testing.T) {
 	lines := strings.Split(`use serde::Serialize;
 use core_foundation::base;`, "\n")
 
-	if hasMacOSCfgAttribute(lines, 1) {
+	if hasGateAttributeFor(lines, 1, DefaultMacOSTargetSpec) {
 		t.Error("expected false when no cfg gate is present")
 	}
 }
@@ -288,18 +409,39 @@ func TestHasMacOSCfgAttribute_OtherAttributesBetween(t *testing.T) {
 #[doc = "macOS-specific"]
 use core_foundation::base;`, "\n")
 
-	if !hasMacOSCfgAttribute(lines, 3) {
+	if !hasGateAttributeFor(lines, 3, DefaultMacOSTargetSpec) {
 		t.Error("expected true for cfg gate with other attributes between")
 	}
 }
 
+func TestHasMacOSCfgAttribute_DocCommentBetweenAttributeAndUse(t *testing.T) {
+	lines := strings.Split(`#[cfg(target_os = "macos")]
+/// Only needed for reading the macOS keychain.
+use core_foundation::base;`, "\n")
+
+	if !hasGateAttributeFor(lines, 2, DefaultMacOSTargetSpec) {
+		t.Error("expected true for cfg gate with a doc comment between it and the use")
+	}
+}
+
+func TestHasMacOSCfgAttribute_BlankLineThenDocCommentThenAttribute(t *testing.T) {
+	lines := strings.Split(`#[cfg(target_os = "macos")]
+
+/// Only needed for reading the macOS keychain.
+use core_foundation::base;`, "\n")
+
+	if !hasGateAttributeFor(lines, 3, DefaultMacOSTargetSpec) {
+		t.Error("expected true for cfg gate separated from the use by a blank line and a doc comment")
+	}
+}
+
 func TestHasMacOSCfgAttribute_InsideCfgGatedBlock(t *testing.T) {
 	lines := strings.Split(`#[cfg(target_os = "macos")]
 fn macos_only() {
     use core_foundation::base;
 }`, "\n")
 
-	if !hasMacOSCfgAttribute(lines, 2) {
+	if !hasGateAttributeFor(lines, 2, DefaultMacOSTargetSpec) {
 		t.Error("expected true for use inside cfg-gated block")
 	}
 }
@@ -307,16 +449,25 @@ fn macos_only() {
 func TestHasMacOSCfgAttribute_FirstLine(t *testing.T) {
 	lines := strings.Split(`use core_foundation::base;`, "\n")
 
-	if hasMacOSCfgAttribute(lines, 0) {
+	if hasGateAttributeFor(lines, 0, DefaultMacOSTargetSpec) {
 		t.Error("expected false for first line with no preceding attributes")
 	}
 }
 
+func TestHasMacOSCfgAttribute_CfgAttrGate(t *testing.T) {
+	lines := strings.Split(`#[cfg_attr(target_os = "macos", allow(dead_code))]
+use core_foundation::base;`, "\n")
+
+	if !hasGateAttributeFor(lines, 1, DefaultMacOSTargetSpec) {
+		t.Error("expected true for cfg_attr gate on previous line")
+	}
+}
+
 func TestHasMacOSCfgAttribute_NegatedCfgGate(t *testing.T) {
 	lines := strings.Split(`#[cfg(not(target_os = "macos"))]
 use fallback::thing;`, "\n")
 
-	if hasMacOSCfgAttribute(lines, 1) {
+	if hasGateAttributeFor(lines, 1, DefaultMacOSTargetSpec) {
 		t.Error("expected false for negated cfg gate")
 	}
 }
@@ -369,12 +520,27 @@ func TestIsMacOSGateAttribute(t *testing.T) {
 			attr:     `#[cfg(all(not(target_os = "windows"), target_os = "macos"))]`,
 			expected: true,
 		},
+		{
+			name:     "cfg_attr gate",
+			attr:     `#[cfg_attr(target_os = "macos", path = "macos_impl.rs")]`,
+			expected: true,
+		},
+		{
+			name:     "cfg_attr with non-macos predicate",
+			attr:     `#[cfg_attr(target_os = "windows", path = "windows_impl.rs")]`,
+			expected: false,
+		},
+		{
+			name:     "cfg_attr with compound predicate",
+			attr:     `#[cfg_attr(all(not(target_os = "windows"), target_os = "macos"), path = "macos_impl.rs")]`,
+			expected: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := isMacOSGateAttribute(tt.attr); got != tt.expected {
-				t.Errorf("isMacOSGateAttribute(%q) = %v, want %v", tt.attr, got, tt.expected)
+			if got := isGateAttributeFor(tt.attr, DefaultMacOSTargetSpec); got != tt.expected {
+				t.Errorf("isGateAttributeFor(%q) = %v, want %v", tt.attr, got, tt.expected)
 			}
 		})
 	}
@@ -481,6 +647,191 @@ fn main() {}
 	}
 }
 
+func TestRunCfgGate_FixInsertsAttribute(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cargoDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	cargoContent := `
+[package]
+name = "test-app"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+core-foundation = "0.10.1"
+`
+	if err := os.WriteFile(filepath.Join(cargoDir, "Cargo.toml"), []byte(cargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	libContent := `mod ungated;
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "lib.rs"), []byte(libContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rsContent := `use core_foundation::base::TCFType;
+
+fn main() {}
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "ungated.rs"), []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: root, Fix: true}
+	result, err := RunCfgGate(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.MadeChanges {
+		t.Error("expected MadeChanges to be true")
+	}
+	if !strings.Contains(result.Message, "fixed 1 ungated use") {
+		t.Errorf("expected message to report the fix, got: %q", result.Message)
+	}
+
+	fixed, err := os.ReadFile(filepath.Join(srcDir, "ungated.rs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFixed := `#[cfg(target_os = "macos")]
+use core_foundation::base::TCFType;
+
+fn main() {}
+`
+	if string(fixed) != wantFixed {
+		t.Errorf("expected fixed file content:\n%s\ngot:\n%s", wantFixed, fixed)
+	}
+
+	// A second run (without Fix) against the now-fixed tree should pass clean.
+	ctx2 := &CheckContext{RootDir: root}
+	if _, err := RunCfgGate(ctx2); err != nil {
+		t.Errorf("expected second run to pass after fix, got: %v", err)
+	}
+}
+
+func TestRunCfgGate_FixDryRunDoesNotWrite(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cargoDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	cargoContent := `
+[package]
+name = "test-app"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+core-foundation = "0.10.1"
+`
+	if err := os.WriteFile(filepath.Join(cargoDir, "Cargo.toml"), []byte(cargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	libContent := `mod ungated;
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "lib.rs"), []byte(libContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rsContent := `use core_foundation::base::TCFType;
+
+fn main() {}
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "ungated.rs"), []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: root, Fix: true, DryRun: true}
+	result, err := RunCfgGate(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.MadeChanges {
+		t.Error("expected MadeChanges to be false for a dry run")
+	}
+	if !strings.Contains(result.Message, "would fix 1 ungated use") {
+		t.Errorf("expected message to report a dry-run fix, got: %q", result.Message)
+	}
+	if !strings.Contains(result.Message, `+#[cfg(target_os = "macos")]`) {
+		t.Errorf("expected message to contain the diff of the attribute that would be inserted, got: %q", result.Message)
+	}
+
+	unchanged, err := os.ReadFile(filepath.Join(srcDir, "ungated.rs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(unchanged) != rsContent {
+		t.Errorf("expected file to be untouched by a dry run, got:\n%s", unchanged)
+	}
+}
+
+// --- Fixer ---
+
+func TestFixer_FixInsertsAttributeDirectly(t *testing.T) {
+	dir := t.TempDir()
+	rsPath := filepath.Join(dir, "ungated.rs")
+	rsContent := `use core_foundation::base::TCFType;
+
+fn main() {}
+`
+	if err := os.WriteFile(rsPath, []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := violation{relPath: "ungated.rs", absPath: rsPath, line: 1, crateName: "core_foundation"}
+	fixer := Fixer{Spec: DefaultMacOSTargetSpec}
+
+	diff, err := fixer.Fix([]violation{v})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(diff, `+#[cfg(target_os = "macos")]`) {
+		t.Errorf("expected diff to show the inserted attribute, got: %q", diff)
+	}
+
+	fixed, err := os.ReadFile(rsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFixed := `#[cfg(target_os = "macos")]
+use core_foundation::base::TCFType;
+
+fn main() {}
+`
+	if string(fixed) != wantFixed {
+		t.Errorf("expected fixed file content:\n%s\ngot:\n%s", wantFixed, fixed)
+	}
+}
+
+func TestFixer_DryRunDoesNotWrite(t *testing.T) {
+	dir := t.TempDir()
+	rsPath := filepath.Join(dir, "ungated.rs")
+	rsContent := `use core_foundation::base::TCFType;
+`
+	if err := os.WriteFile(rsPath, []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := violation{relPath: "ungated.rs", absPath: rsPath, line: 1, crateName: "core_foundation"}
+	fixer := Fixer{Spec: DefaultMacOSTargetSpec, DryRun: true}
+
+	if _, err := fixer.Fix([]violation{v}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	unchanged, err := os.ReadFile(rsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(unchanged) != rsContent {
+		t.Errorf("expected file to be untouched by a dry run, got:\n%s", unchanged)
+	}
+}
+
 func TestRunCfgGate_ModuleGatedFileSkipped(t *testing.T) {
 	root := t.TempDir()
 	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
@@ -714,7 +1065,7 @@ mod macos_stuff;
 		t.Fatal(err)
 	}
 
-	gated, err := buildModuleGatedFileSet(dir)
+	gated, err := buildModuleGatedFileSetFor(dir, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -745,7 +1096,7 @@ mod platform;
 		t.Fatal(err)
 	}
 
-	gated, err := buildModuleGatedFileSet(dir)
+	gated, err := buildModuleGatedFileSetFor(dir, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -778,7 +1129,7 @@ mod macos_stuff;
 		t.Fatal(err)
 	}
 
-	gated, err := buildModuleGatedFileSet(dir)
+	gated, err := buildModuleGatedFileSetFor(dir, DefaultMacOSTargetSpec)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -810,7 +1161,7 @@ fn main() {}
 	macOSModules := map[string]bool{"core_foundation": true}
 	gatedFiles := map[string]bool{}
 
-	violations, gatedCount, err := scanForUngatedUses(dir, macOSModules, gatedFiles)
+	violations, gatedCount, err := scanForUngatedUsesFor(dir, dir, macOSModules, gatedFiles, DefaultMacOSTargetSpec, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -843,7 +1194,7 @@ fn main() {}
 	macOSModules := map[string]bool{"core_foundation": true}
 	gatedFiles := map[string]bool{}
 
-	violations, gatedCount, err := scanForUngatedUses(dir, macOSModules, gatedFiles)
+	violations, gatedCount, err := scanForUngatedUsesFor(dir, dir, macOSModules, gatedFiles, DefaultMacOSTargetSpec, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -868,7 +1219,7 @@ func TestScanForUngatedUses_SkipsGatedFiles(t *testing.T) {
 	macOSModules := map[string]bool{"core_foundation": true}
 	gatedFiles := map[string]bool{filePath: true}
 
-	violations, _, err := scanForUngatedUses(dir, macOSModules, gatedFiles)
+	violations, _, err := scanForUngatedUsesFor(dir, dir, macOSModules, gatedFiles, DefaultMacOSTargetSpec, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -890,7 +1241,7 @@ use tokio::runtime;
 	macOSModules := map[string]bool{"core_foundation": true}
 	gatedFiles := map[string]bool{}
 
-	violations, gatedCount, err := scanForUngatedUses(dir, macOSModules, gatedFiles)
+	violations, gatedCount, err := scanForUngatedUsesFor(dir, dir, macOSModules, gatedFiles, DefaultMacOSTargetSpec, nil)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -908,7 +1259,7 @@ func TestFindCfgGatedModules_PubSuperMod(t *testing.T) {
 	lines := strings.Split(`#[cfg(target_os = "macos")]
 pub(super) mod internal;`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	if len(result) != 1 || result[0] != "internal" {
 		t.Errorf("expected [internal], got %v", result)
 	}
@@ -921,7 +1272,7 @@ mod inline {
     fn stuff() {}
 }`, "\n")
 
-	result := findCfgGatedModules(lines)
+	result := findGatedModulesFor(lines, DefaultMacOSTargetSpec)
 	// modDeclPattern requires `mod <name>;` (with semicolon), so inline blocks are not matched
 	if len(result) != 0 {
 		t.Errorf("expected no matches for inline mod block, got %v", result)
@@ -929,7 +1280,7 @@ mod inline {
 }
 
 func TestFindCfgGatedModules_EmptyInput(t *testing.T) {
-	result := findCfgGatedModules([]string{})
+	result := findGatedModulesFor([]string{}, DefaultMacOSTargetSpec)
 	if len(result) != 0 {
 		t.Errorf("expected empty result for empty input, got %v", result)
 	}
@@ -941,7 +1292,7 @@ func TestHasMacOSCfgAttribute_CompoundAllGate(t *testing.T) {
 	lines := strings.Split(`#[cfg(all(test, target_os = "macos"))]
 use core_foundation::base;`, "\n")
 
-	if !hasMacOSCfgAttribute(lines, 1) {
+	if !hasGateAttributeFor(lines, 1, DefaultMacOSTargetSpec) {
 		t.Error("expected true for compound all() cfg gate")
 	}
 }
@@ -956,7 +1307,265 @@ impl Foo {
 
 	// Line 3 has the use statement. Walking back: line 2 is code with {,
 	// which recursively checks line 1 (impl Foo {), which has the cfg on line 0.
-	if !hasMacOSCfgAttribute(lines, 3) {
+	if !hasGateAttributeFor(lines, 3, DefaultMacOSTargetSpec) {
 		t.Error("expected true for use inside nested cfg-gated block")
 	}
 }
+
+// --- RunCfgGate workspace/cache integration ---
+
+func TestRunCfgGate_WorkspaceMemberCrateIsScoped(t *testing.T) {
+	root := t.TempDir()
+	rootCrateDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	rootCargoContent := `
+[package]
+name = "test-app"
+
+[workspace]
+members = ["crates/*"]
+
+[target.'cfg(target_os = "macos")'.dependencies]
+core-foundation = "0.10.1"
+`
+	if err := os.MkdirAll(filepath.Join(rootCrateDir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rootCrateDir, "Cargo.toml"), []byte(rootCargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rootCrateDir, "src", "lib.rs"), []byte("fn main() {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	memberDir := filepath.Join(rootCrateDir, "crates", "helper")
+	if err := os.MkdirAll(filepath.Join(memberDir, "src"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Deliberately a different macOS-only dep than the root crate's, so this
+	// test also catches resolvePlatformCratesFor's memoization cache (see
+	// CheckContext.platformCrateCache) being keyed broadly enough that the
+	// member crate's dependencies get confused with the root crate's.
+	memberCargoContent := `
+[package]
+name = "helper"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+objc = "0.2"
+`
+	if err := os.WriteFile(filepath.Join(memberDir, "Cargo.toml"), []byte(memberCargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ungatedContent := `use objc::runtime::Object;
+
+fn main() {}
+`
+	if err := os.WriteFile(filepath.Join(memberDir, "src", "ungated.rs"), []byte(ungatedContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: root}
+	_, err := RunCfgGate(ctx)
+	if err == nil {
+		t.Fatal("expected error for ungated use inside a workspace member crate")
+	}
+	if !strings.Contains(err.Error(), filepath.Join("crates", "helper")) {
+		t.Errorf("expected error to scope the violation to the member crate, got: %v", err)
+	}
+}
+
+func TestRunCfgGate_CacheHitSkipsReparsing(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cargoDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	cargoContent := `
+[package]
+name = "test-app"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+core-foundation = "0.10.1"
+`
+	if err := os.WriteFile(filepath.Join(cargoDir, "Cargo.toml"), []byte(cargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rsContent := `#[cfg(target_os = "macos")]
+use core_foundation::base::TCFType;
+
+fn main() {}
+`
+	rsPath := filepath.Join(srcDir, "something.rs")
+	if err := os.WriteFile(rsPath, []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: root, Cache: true}
+	if _, err := RunCfgGate(ctx); err != nil {
+		t.Fatalf("expected success on first (cold) run, got error: %v", err)
+	}
+
+	// Rewriting the file with identical content should still hit the cache
+	// (keyed by content hash, not mtime), and the check should still pass.
+	if err := os.WriteFile(rsPath, []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	result, err := RunCfgGate(ctx)
+	if err != nil {
+		t.Fatalf("expected success on second (warm) run, got error: %v", err)
+	}
+	if !strings.Contains(result.Message, "1 gated use") {
+		t.Errorf("expected message to mention gated uses, got: %s", result.Message)
+	}
+}
+
+// --- TargetSpec.RejectNegationOnlyGate ---
+
+func TestIsGateAttributeFor_RejectNegationOnlyGate(t *testing.T) {
+	strictLinux := LinuxTargetSpec
+	strictLinux.RejectNegationOnlyGate = true
+
+	if isGateAttributeFor(`#[cfg(not(target_os = "windows"))]`, strictLinux) {
+		t.Error("expected a negation-only gate to be rejected when RejectNegationOnlyGate is set")
+	}
+	if !isGateAttributeFor(`#[cfg(target_os = "linux")]`, strictLinux) {
+		t.Error("expected an explicit positive gate to still pass when RejectNegationOnlyGate is set")
+	}
+	if !isGateAttributeFor(`#[cfg(any(unix, target_os = "linux"))]`, strictLinux) {
+		t.Error("expected a positive gate inside a compound expression to still pass")
+	}
+}
+
+func TestIsGateAttributeFor_NegationOnlyGateAllowedByDefault(t *testing.T) {
+	if !isGateAttributeFor(`#[cfg(not(target_os = "windows"))]`, LinuxTargetSpec) {
+		t.Error("expected a negation-only gate to pass when RejectNegationOnlyGate is unset")
+	}
+}
+
+// --- CheckContext.CfgGateAllowlist ---
+
+func TestRunCfgGate_AllowlistedCrateSkipsUngatedUse(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cargoDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	cargoContent := `
+[package]
+name = "test-app"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+objc2 = "0.6"
+`
+	if err := os.WriteFile(filepath.Join(cargoDir, "Cargo.toml"), []byte(cargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rsContent := `use objc2::runtime::AnyObject;
+
+fn main() {}
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "lib.rs"), []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: root, CfgGateAllowlist: map[string]bool{"objc2": true}}
+	result, err := RunCfgGate(ctx)
+	if err != nil {
+		t.Fatalf("expected success (allowlisted crate should never be flagged), got error: %v", err)
+	}
+	if !strings.Contains(result.Message, "No macOS-only dependencies found") {
+		t.Errorf("expected the allowlisted crate to leave no dependencies to check, got: %s", result.Message)
+	}
+}
+
+// --- Multi-platform violation summary ---
+
+func TestRunCfgGate_MultiPlatformSummaryGroupsViolationsBySpec(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cargoDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	cargoContent := `
+[package]
+name = "test-app"
+
+[target.'cfg(target_os = "macos")'.dependencies]
+core-foundation = "0.10.1"
+
+[target.'cfg(target_os = "windows")'.dependencies]
+windows-sys = "0.52"
+`
+	if err := os.WriteFile(filepath.Join(cargoDir, "Cargo.toml"), []byte(cargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rsContent := `use core_foundation::base::TCFType;
+use windows_sys::Win32::Foundation::HWND;
+
+fn main() {}
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "lib.rs"), []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: root, TargetSpecs: []TargetSpec{DefaultMacOSTargetSpec, WindowsTargetSpec}}
+	_, err := RunCfgGate(ctx)
+	if err == nil {
+		t.Fatal("expected error for ungated uses of macOS- and Windows-only crates")
+	}
+	if !strings.Contains(err.Error(), "1 ungated macOS use") {
+		t.Errorf("expected summary to mention the macOS violation, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "1 ungated Windows use") {
+		t.Errorf("expected summary to mention the Windows violation, got: %v", err)
+	}
+}
+
+// --- AndroidTargetSpec ---
+
+func TestAndroidTargetSpec_GatesAndroidOnlyCrate(t *testing.T) {
+	root := t.TempDir()
+	srcDir := filepath.Join(root, "apps", "desktop", "src-tauri", "src")
+	if err := os.MkdirAll(srcDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cargoDir := filepath.Join(root, "apps", "desktop", "src-tauri")
+	cargoContent := `
+[package]
+name = "test-app"
+
+[target.'cfg(target_os = "android")'.dependencies]
+jni = "0.21"
+`
+	if err := os.WriteFile(filepath.Join(cargoDir, "Cargo.toml"), []byte(cargoContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rsContent := `use jni::JNIEnv;
+
+fn main() {}
+`
+	if err := os.WriteFile(filepath.Join(srcDir, "lib.rs"), []byte(rsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: root, TargetSpecs: []TargetSpec{AndroidTargetSpec}}
+	_, err := RunCfgGate(ctx)
+	if err == nil {
+		t.Fatal("expected error for ungated use of an Android-only crate")
+	}
+	if !strings.Contains(err.Error(), "jni") || !strings.Contains(err.Error(), "Android") {
+		t.Errorf("expected error to mention the Android-only crate, got: %v", err)
+	}
+}
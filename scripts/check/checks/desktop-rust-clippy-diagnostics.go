@@ -0,0 +1,160 @@
+package checks
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+)
+
+// clippyDefaultRule is the Diagnostic.Rule used for a clippy message that
+// isn't tied to a specific lint code (e.g. a plain rustc warning clippy
+// surfaces alongside its own lints).
+const clippyDefaultRule = "rustc"
+
+// clippyHelpURI returns the rust-clippy lint book's page for rule (e.g.
+// "clippy::needless_return"), or "" for a plain rustc diagnostic
+// (clippyDefaultRule or an E-code), which clippy's own docs don't cover.
+func clippyHelpURI(rule string) string {
+	name, ok := strings.CutPrefix(rule, "clippy::")
+	if !ok {
+		return ""
+	}
+	return "https://rust-lang.github.io/rust-clippy/master/index.html#" + name
+}
+
+// clippyMessage mirrors the subset of cargo's `--message-format=json` output
+// (one JSON object per line; see
+// https://doc.rust-lang.org/cargo/reference/external-tools.html#json-messages)
+// that parseClippyDiagnostics needs. Only "compiler-message" lines carry a
+// diagnostic; "compiler-artifact" and "build-finished" lines are ignored.
+type clippyMessage struct {
+	Reason  string `json:"reason"`
+	Message *struct {
+		Message  string `json:"message"`
+		Rendered string `json:"rendered"`
+		Level    string `json:"level"`
+		Code     *struct {
+			Code string `json:"code"`
+		} `json:"code"`
+		Spans []struct {
+			FileName    string `json:"file_name"`
+			LineStart   int    `json:"line_start"`
+			LineEnd     int    `json:"line_end"`
+			ColumnStart int    `json:"column_start"`
+			ColumnEnd   int    `json:"column_end"`
+			IsPrimary   bool   `json:"is_primary"`
+		} `json:"spans"`
+	} `json:"message"`
+}
+
+// countClippyCompiledCrates counts the "compiler-artifact" lines in cargo's
+// `--message-format=json` output, one per crate target built, for RunClippy's
+// success message. With plain-text output this used to be a regex over
+// "Compiling N crates"/"Checking" lines; --message-format=json suppresses
+// that text entirely, so the count now comes from the reason field instead.
+func countClippyCompiledCrates(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var msg clippyMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			continue
+		}
+		if msg.Reason == "compiler-artifact" {
+			count++
+		}
+	}
+	return count
+}
+
+// renderClippyOutput reassembles a human-readable error report from cargo's
+// `--message-format=json` output, joining each compiler-message's rendered
+// (rustc-style, with file/line/snippet/suggestion) text — otherwise
+// indentOutput would just indent the raw JSON lines, which is unreadable
+// compared to the plain-text output this replaced.
+func renderClippyOutput(output string) string {
+	var rendered []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var msg clippyMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil || msg.Reason != "compiler-message" || msg.Message == nil {
+			continue
+		}
+		if msg.Message.Rendered != "" {
+			rendered = append(rendered, msg.Message.Rendered)
+		}
+	}
+	if len(rendered) == 0 {
+		return output
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// parseClippyDiagnostics parses cargo's `--message-format=json` output into
+// Diagnostics, one per compiler-message that has a primary span. Lines that
+// aren't valid JSON (cargo interleaves plain progress text with some
+// toolchains) or aren't warning/error compiler-messages are skipped rather
+// than treated as a parse error — a best-effort diagnostic list is more
+// useful than failing the whole check over one malformed line.
+//
+// cargo's file_name is relative to cmd.Dir (rustDir), not the repo root that
+// every other Diagnostic (see violationToDiagnostic) and SARIF/JSON
+// consumers expect, so it's rejoined against rustDir and re-relativized
+// against rootDir.
+func parseClippyDiagnostics(output, rootDir, rustDir string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+
+		var msg clippyMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			continue
+		}
+		if msg.Reason != "compiler-message" || msg.Message == nil {
+			continue
+		}
+		if msg.Message.Level != "warning" && msg.Message.Level != "error" {
+			continue
+		}
+
+		for _, span := range msg.Message.Spans {
+			if !span.IsPrimary {
+				continue
+			}
+
+			rule := clippyDefaultRule
+			if msg.Message.Code != nil && msg.Message.Code.Code != "" {
+				rule = msg.Message.Code.Code
+			}
+
+			file := span.FileName
+			if relFile, err := filepath.Rel(rootDir, filepath.Join(rustDir, span.FileName)); err == nil {
+				file = relFile
+			}
+
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     file,
+				Line:     span.LineStart,
+				Col:      span.ColumnStart,
+				EndLine:  span.LineEnd,
+				EndCol:   span.ColumnEnd,
+				Rule:     rule,
+				Message:  msg.Message.Message,
+				Severity: msg.Message.Level,
+				Tool:     "clippy",
+				HelpURI:  clippyHelpURI(rule),
+			})
+			break
+		}
+	}
+	return diagnostics
+}
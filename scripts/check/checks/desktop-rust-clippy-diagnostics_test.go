@@ -0,0 +1,125 @@
+package checks
+
+import "testing"
+
+const (
+	testClippyRootDir = "/repo"
+	testClippyRustDir = "/repo/apps/desktop/src-tauri"
+)
+
+func TestParseClippyDiagnostics_SkipsNonDiagnosticReasons(t *testing.T) {
+	output := `{"reason":"compiler-artifact","package_id":"foo 0.1.0"}
+{"reason":"build-finished","success":true}`
+
+	got := parseClippyDiagnostics(output, testClippyRootDir, testClippyRustDir)
+
+	if len(got) != 0 {
+		t.Errorf("expected no diagnostics, got %+v", got)
+	}
+}
+
+func TestParseClippyDiagnostics_ParsesLintWarning(t *testing.T) {
+	output := `{"reason":"compiler-message","message":{"message":"this returns a value unnecessarily","level":"warning","code":{"code":"clippy::needless_return"},"spans":[{"file_name":"src/lib.rs","line_start":10,"line_end":10,"column_start":5,"column_end":20,"is_primary":true}]}}`
+
+	got := parseClippyDiagnostics(output, testClippyRootDir, testClippyRustDir)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	d := got[0]
+	if d.File != "apps/desktop/src-tauri/src/lib.rs" {
+		t.Errorf("expected File relative to rootDir, got %q", d.File)
+	}
+	if d.Line != 10 || d.Col != 5 || d.EndLine != 10 || d.EndCol != 20 {
+		t.Errorf("unexpected location: %+v", d)
+	}
+	if d.Rule != "clippy::needless_return" {
+		t.Errorf("expected Rule clippy::needless_return, got %q", d.Rule)
+	}
+	if d.Severity != "warning" {
+		t.Errorf("expected Severity warning, got %q", d.Severity)
+	}
+	if d.Tool != "clippy" {
+		t.Errorf("expected Tool clippy, got %q", d.Tool)
+	}
+	if d.HelpURI != "https://rust-lang.github.io/rust-clippy/master/index.html#needless_return" {
+		t.Errorf("unexpected HelpURI: %q", d.HelpURI)
+	}
+}
+
+func TestClippyHelpURI(t *testing.T) {
+	if got := clippyHelpURI("clippy::needless_return"); got != "https://rust-lang.github.io/rust-clippy/master/index.html#needless_return" {
+		t.Errorf("unexpected HelpURI for a clippy lint: %q", got)
+	}
+	if got := clippyHelpURI(clippyDefaultRule); got != "" {
+		t.Errorf("expected no HelpURI for a plain rustc diagnostic, got %q", got)
+	}
+}
+
+func TestParseClippyDiagnostics_PlainRustcWarningHasNoLintCode(t *testing.T) {
+	output := `{"reason":"compiler-message","message":{"message":"unused variable: ` + "`x`" + `","level":"warning","code":null,"spans":[{"file_name":"src/main.rs","line_start":3,"line_end":3,"column_start":9,"column_end":10,"is_primary":true}]}}`
+
+	got := parseClippyDiagnostics(output, testClippyRootDir, testClippyRustDir)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	if got[0].Rule != clippyDefaultRule {
+		t.Errorf("expected Rule %q, got %q", clippyDefaultRule, got[0].Rule)
+	}
+}
+
+func TestParseClippyDiagnostics_IgnoresNonPrimarySpans(t *testing.T) {
+	output := `{"reason":"compiler-message","message":{"message":"mismatched types","level":"error","spans":[{"file_name":"src/other.rs","line_start":1,"line_end":1,"column_start":1,"column_end":2,"is_primary":false},{"file_name":"src/lib.rs","line_start":20,"line_end":20,"column_start":3,"column_end":4,"is_primary":true}]}}`
+
+	got := parseClippyDiagnostics(output, testClippyRootDir, testClippyRustDir)
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	if got[0].File != "apps/desktop/src-tauri/src/lib.rs" {
+		t.Errorf("expected the primary span's file, got %q", got[0].File)
+	}
+}
+
+func TestParseClippyDiagnostics_SkipsMalformedLines(t *testing.T) {
+	output := "not json at all\n" + `{"reason":"compiler-message","message":{"message":"x","level":"error","spans":[{"file_name":"a.rs","line_start":1,"line_end":1,"column_start":1,"column_end":1,"is_primary":true}]}}`
+
+	got := parseClippyDiagnostics(output, testClippyRootDir, testClippyRustDir)
+
+	if len(got) != 1 {
+		t.Errorf("expected the malformed line to be skipped and the valid one parsed, got %+v", got)
+	}
+}
+
+func TestCountClippyCompiledCrates(t *testing.T) {
+	output := `{"reason":"compiler-artifact","package_id":"foo 0.1.0"}
+{"reason":"compiler-artifact","package_id":"bar 0.2.0"}
+{"reason":"compiler-message","message":{"message":"x","level":"warning","spans":[]}}
+{"reason":"build-finished","success":true}`
+
+	if got := countClippyCompiledCrates(output); got != 2 {
+		t.Errorf("expected 2 compiled crates, got %d", got)
+	}
+}
+
+func TestRenderClippyOutput_JoinsRenderedMessages(t *testing.T) {
+	output := `{"reason":"compiler-message","message":{"message":"x","rendered":"warning: x\n --> src/lib.rs:1:1","level":"warning","spans":[]}}
+{"reason":"compiler-artifact","package_id":"foo 0.1.0"}`
+
+	got := renderClippyOutput(output)
+
+	if got != "warning: x\n --> src/lib.rs:1:1" {
+		t.Errorf("expected the rendered text to be extracted, got %q", got)
+	}
+}
+
+func TestRenderClippyOutput_FallsBackToRawOutputWhenNothingRendered(t *testing.T) {
+	output := "cargo: some unexpected fatal error, not JSON at all"
+
+	got := renderClippyOutput(output)
+
+	if got != output {
+		t.Errorf("expected the raw output back when nothing could be rendered, got %q", got)
+	}
+}
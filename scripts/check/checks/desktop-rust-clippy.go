@@ -4,12 +4,35 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
-	"regexp"
-	"strconv"
+	"strings"
 )
 
-// RunClippy runs Clippy linter with auto-fix.
+// RunClippy runs Clippy linter with auto-fix. In ctx.CI (no --fix, no
+// mutation), the result is cached by input file content — see CachedCheck —
+// since that path never writes to disk; the local auto-fixing path always
+// runs fresh.
 func RunClippy(ctx *CheckContext) (CheckResult, error) {
+	if ctx.CI {
+		return CachedCheck(ctx, "clippy", rustSourceGlobs, clippyVersion, 0, func() (CheckResult, error) {
+			return runClippy(ctx)
+		})
+	}
+	return runClippy(ctx)
+}
+
+// clippyVersion returns `cargo clippy --version`'s output trimmed, or "" if
+// it can't be determined.
+func clippyVersion() string {
+	cmd := exec.Command("cargo", "clippy", "--version")
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// runClippy is RunClippy's uncached implementation.
+func runClippy(ctx *CheckContext) (CheckResult, error) {
 	desktopDir := filepath.Join(ctx.RootDir, "apps", "desktop")
 	rustDir := filepath.Join(desktopDir, "src-tauri")
 
@@ -32,32 +55,24 @@ func RunClippy(ctx *CheckContext) (CheckResult, error) {
 		_, _ = RunCommand(fixCmd, true) // Ignore errors, we'll catch them in the check run
 	}
 
-	// Run clippy WITHOUT --fix to check for remaining issues (--fix ignores -D warnings)
-	cmd := exec.Command("cargo", "clippy", "--all-targets", "--", "-D", "warnings")
+	// Run clippy WITHOUT --fix to check for remaining issues (--fix ignores -D warnings).
+	// --message-format=json lets us report structured Diagnostics (see
+	// desktop-rust-clippy-diagnostics.go) alongside the human-readable summary below.
+	cmd := exec.Command("cargo", "clippy", "--all-targets", "--message-format=json", "--", "-D", "warnings")
 	cmd.Dir = rustDir
 	output, err := RunCommand(cmd, true)
+	diagnostics := parseClippyDiagnostics(output, ctx.RootDir, rustDir)
 	if err != nil {
+		readable := renderClippyOutput(output)
 		if ctx.CI {
-			return CheckResult{}, fmt.Errorf("clippy errors found, run the check script locally\n%s", indentOutput(output))
+			return CheckResult{Diagnostics: diagnostics}, fmt.Errorf("clippy errors found, run the check script locally\n%s", indentOutput(readable))
 		}
-		return CheckResult{}, fmt.Errorf("clippy found unfixable issues\n%s", indentOutput(output))
-	}
-
-	// Try to extract "Compiling X crates" from output
-	re := regexp.MustCompile(`Compiling (\d+) crates?`)
-	matches := re.FindStringSubmatch(output)
-	if len(matches) > 1 {
-		count, _ := strconv.Atoi(matches[1])
-		return Success(fmt.Sprintf("Checked %d %s, no warnings", count, Pluralize(count, "crate", "crates"))), nil
+		return CheckResult{Diagnostics: diagnostics}, fmt.Errorf("clippy found unfixable issues\n%s", indentOutput(readable))
 	}
 
-	// Fallback: count "Checking" lines
-	re2 := regexp.MustCompile(`(?m)^\s*Checking`)
-	checkingMatches := re2.FindAllString(output, -1)
-	if len(checkingMatches) > 0 {
-		count := len(checkingMatches)
-		return Success(fmt.Sprintf("Checked %d %s, no warnings", count, Pluralize(count, "crate", "crates"))), nil
+	if count := countClippyCompiledCrates(output); count > 0 {
+		return CheckResult{Code: ResultSuccess, Message: fmt.Sprintf("Checked %d %s, no warnings", count, Pluralize(count, "crate", "crates")), Diagnostics: diagnostics}, nil
 	}
 
-	return Success("No warnings"), nil
+	return CheckResult{Code: ResultSuccess, Message: "No warnings", Diagnostics: diagnostics}, nil
 }
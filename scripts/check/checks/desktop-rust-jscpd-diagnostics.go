@@ -0,0 +1,74 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// jscpdReport mirrors the subset of jscpd's `--reporters json` report
+// (written as <output>/jscpd-report.json) needed to report Diagnostics: one
+// entry per duplicate, naming the two files and line ranges involved.
+type jscpdReport struct {
+	Duplicates []struct {
+		Lines      int             `json:"lines"`
+		Tokens     int             `json:"tokens"`
+		FirstFile  jscpdReportFile `json:"firstFile"`
+		SecondFile jscpdReportFile `json:"secondFile"`
+	} `json:"duplicates"`
+}
+
+type jscpdReportFile struct {
+	Name     string `json:"name"`
+	StartLoc struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"startLoc"`
+	EndLoc struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"endLoc"`
+}
+
+// readJscpdDiagnostics reads and parses the jscpd-report.json jscpd wrote to
+// reportDir, returning one Diagnostic per duplicate, anchored at the first
+// occurrence and naming the second in its Message — jscpd itself has no
+// single "location" for a duplicate, it's a pair, but Diagnostic only has
+// room for one.
+func readJscpdDiagnostics(reportDir, rootDir string) ([]Diagnostic, error) {
+	data, err := os.ReadFile(filepath.Join(reportDir, "jscpd-report.json"))
+	if err != nil {
+		return nil, fmt.Errorf("reading jscpd report: %w", err)
+	}
+
+	var report jscpdReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parsing jscpd report: %w", err)
+	}
+
+	var diagnostics []Diagnostic
+	for _, dup := range report.Duplicates {
+		file := dup.FirstFile.Name
+		if relFile, err := filepath.Rel(rootDir, dup.FirstFile.Name); err == nil {
+			file = relFile
+		}
+		other := dup.SecondFile.Name
+		if relOther, err := filepath.Rel(rootDir, dup.SecondFile.Name); err == nil {
+			other = relOther
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     file,
+			Line:     dup.FirstFile.StartLoc.Line,
+			Col:      dup.FirstFile.StartLoc.Column,
+			EndLine:  dup.FirstFile.EndLoc.Line,
+			EndCol:   dup.FirstFile.EndLoc.Column,
+			Rule:     "duplicate-code",
+			Message:  fmt.Sprintf("%d duplicated lines, also found in %s:%d", dup.Lines, other, dup.SecondFile.StartLoc.Line),
+			Severity: "warning",
+			Tool:     "jscpd",
+		})
+	}
+	return diagnostics, nil
+}
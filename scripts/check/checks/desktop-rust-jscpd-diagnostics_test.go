@@ -0,0 +1,41 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadJscpdDiagnostics_ParsesReportFile(t *testing.T) {
+	reportDir := t.TempDir()
+	report := `{"duplicates":[{"lines":8,"tokens":120,` +
+		`"firstFile":{"name":"/repo/apps/desktop/src-tauri/src/a.rs","startLoc":{"line":10,"column":1},"endLoc":{"line":18,"column":1}},` +
+		`"secondFile":{"name":"/repo/apps/desktop/src-tauri/src/b.rs","startLoc":{"line":30,"column":1},"endLoc":{"line":38,"column":1}}}]}`
+	if err := os.WriteFile(filepath.Join(reportDir, "jscpd-report.json"), []byte(report), 0o644); err != nil {
+		t.Fatalf("writing fixture report: %v", err)
+	}
+
+	got, err := readJscpdDiagnostics(reportDir, "/repo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	d := got[0]
+	if d.File != "apps/desktop/src-tauri/src/a.rs" {
+		t.Errorf("expected File relative to rootDir, got %q", d.File)
+	}
+	if d.Line != 10 || d.EndLine != 18 {
+		t.Errorf("unexpected location: %+v", d)
+	}
+	if d.Tool != "jscpd" {
+		t.Errorf("expected Tool jscpd, got %q", d.Tool)
+	}
+}
+
+func TestReadJscpdDiagnostics_MissingReportErrors(t *testing.T) {
+	if _, err := readJscpdDiagnostics(t.TempDir(), "/repo"); err == nil {
+		t.Fatal("expected an error when jscpd-report.json is missing")
+	}
+}
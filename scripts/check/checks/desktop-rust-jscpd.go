@@ -2,48 +2,64 @@ package checks
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"vmail/scripts/check/checks/toolchain"
 )
 
-// RunJscpdRust detects code duplication in Rust files.
+// RunJscpdRust detects code duplication in Rust files, using the jscpd
+// version tools.lock pins (see the toolchain package) instead of a global
+// npm install.
 func RunJscpdRust(ctx *CheckContext) (CheckResult, error) {
 	rustSrcDir := filepath.Join(ctx.RootDir, "apps", "desktop", "src-tauri", "src")
 
-	// Check if jscpd is available via npx
-	cmd := exec.Command("npx", "jscpd", "--version")
-	if _, err := RunCommand(cmd, true); err != nil {
-		installCmd := exec.Command("npm", "install", "-g", "jscpd")
-		if _, err := RunCommand(installCmd, true); err != nil {
-			return CheckResult{}, fmt.Errorf("failed to install jscpd: %w", err)
-		}
+	jscpdBin, err := toolchain.Ensure(ctx.RootDir, "jscpd")
+	if err != nil {
+		return CheckResult{}, err
 	}
 
-	// Run jscpd on Rust source files
-	cmd = exec.Command("npx", "jscpd",
+	reportDir, err := os.MkdirTemp("", "cmdr-jscpd-")
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("creating jscpd report dir: %w", err)
+	}
+	defer os.RemoveAll(reportDir)
+
+	cmd := exec.Command(jscpdBin,
 		rustSrcDir,
 		"--format", "rust",
 		"--min-lines", "5",
 		"--min-tokens", "100",
 		"--threshold", "2",
 		"--ignore", "**/test*.rs,**/*_test.rs",
-		"--reporters", "console",
+		"--reporters", "console,json",
+		"--output", reportDir,
 	)
 	output, err := RunCommand(cmd, true)
+
+	// The json reporter writes jscpd-report.json regardless of whether the
+	// duplication threshold was exceeded, so Diagnostics is populated on
+	// both the success and failure paths below, same as parseClippyDiagnostics.
+	diagnostics, diagErr := readJscpdDiagnostics(reportDir, ctx.RootDir)
+	if diagErr != nil {
+		diagnostics = nil
+	}
+
 	if err != nil {
 		if strings.Contains(output, "duplicated lines") || strings.Contains(output, "threshold") {
-			return CheckResult{}, fmt.Errorf("code duplication exceeds threshold (2%%)\n%s", indentOutput(output))
+			return CheckResult{Diagnostics: diagnostics}, fmt.Errorf("code duplication exceeds threshold (2%%)\n%s", indentOutput(output))
 		}
-		return CheckResult{}, fmt.Errorf("jscpd failed\n%s", indentOutput(output))
+		return CheckResult{Diagnostics: diagnostics}, fmt.Errorf("jscpd failed\n%s", indentOutput(output))
 	}
 
 	// Parse duplication percentage
 	re := regexp.MustCompile(`(\d+\.?\d*)% \(`)
 	matches := re.FindStringSubmatch(output)
 	if len(matches) > 1 {
-		return Success(fmt.Sprintf("%s%% duplication", matches[1])), nil
+		return CheckResult{Code: ResultSuccess, Message: fmt.Sprintf("%s%% duplication", matches[1]), Diagnostics: diagnostics}, nil
 	}
-	return Success("No significant duplication"), nil
+	return CheckResult{Code: ResultSuccess, Message: "No significant duplication", Diagnostics: diagnostics}, nil
 }
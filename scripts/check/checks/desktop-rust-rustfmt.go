@@ -7,8 +7,31 @@ import (
 	"strings"
 )
 
-// RunRustfmt formats Rust code.
+// RunRustfmt formats Rust code. In ctx.CI (check-only, no mutation), the
+// result is cached by input file content — see CachedCheck — since that
+// path never writes to disk; the local auto-fixing path always runs fresh.
 func RunRustfmt(ctx *CheckContext) (CheckResult, error) {
+	if ctx.CI {
+		return CachedCheck(ctx, "rustfmt", rustSourceGlobs, rustfmtVersion, 0, func() (CheckResult, error) {
+			return runRustfmt(ctx)
+		})
+	}
+	return runRustfmt(ctx)
+}
+
+// rustfmtVersion returns `cargo fmt --version`'s output trimmed, or "" if it
+// can't be determined.
+func rustfmtVersion() string {
+	cmd := exec.Command("cargo", "fmt", "--version")
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(output)
+}
+
+// runRustfmt is RunRustfmt's uncached implementation.
+func runRustfmt(ctx *CheckContext) (CheckResult, error) {
 	rustDir := filepath.Join(ctx.RootDir, "apps", "desktop", "src-tauri")
 
 	// Count .rs files for the message
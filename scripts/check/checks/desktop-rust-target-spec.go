@@ -0,0 +1,91 @@
+package checks
+
+// TargetSpec describes one compile target RunCfgGate validates against: a
+// human-readable name, the literal cfg(...) predicate Cargo.toml uses to gate
+// platform-specific dependencies for it (e.g. `cfg(target_os = "windows")`,
+// matched against a [target.'...'] section header), the fixed cfg facts
+// (see evalCfg in desktop-rust-cfg-expr.go) that hold true when compiling
+// for it, and the Rust target triple `cargo metadata --filter-platform`
+// should resolve dependencies for (see resolvePlatformCrates).
+type TargetSpec struct {
+	Name        string
+	CargoCfgKey string
+	Facts       map[string]string
+	Triple      string
+
+	// RejectNegationOnlyGate, when true, makes isGateAttributeFor require an
+	// attribute that positively asserts one of Facts' key=value pairs (e.g.
+	// target_os = "linux"), rejecting one that only evaluates true for this
+	// spec by negating some other platform (e.g. #[cfg(not(target_os =
+	// "windows"))] guarding Linux-only code). Both forms are equally correct
+	// for the compiler, but the negated form silently also covers any future
+	// platform this spec doesn't know about yet, which is often not what the
+	// author of a single-platform gate meant. Defaults to false, preserving
+	// every existing spec's original permissive behavior.
+	RejectNegationOnlyGate bool
+}
+
+// DefaultMacOSTargetSpec is what RunCfgGate validates against when
+// ctx.TargetSpecs is empty, preserving the check's original macOS-only
+// behavior for repos with no explicit target configuration.
+var DefaultMacOSTargetSpec = TargetSpec{
+	Name:        "macOS",
+	CargoCfgKey: `cfg(target_os = "macos")`,
+	Facts: map[string]string{
+		"target_os":     "macos",
+		"target_family": "unix",
+		"target_vendor": "apple",
+	},
+	Triple: "x86_64-apple-darwin",
+}
+
+// WindowsTargetSpec, LinuxTargetSpec and IOSTargetSpec are the other
+// platforms RunCfgGate can validate in the same run when CheckContext is
+// configured with multiple TargetSpecs.
+var WindowsTargetSpec = TargetSpec{
+	Name:        "Windows",
+	CargoCfgKey: `cfg(target_os = "windows")`,
+	Facts: map[string]string{
+		"target_os":     "windows",
+		"target_family": "windows",
+		"target_env":    "msvc",
+	},
+	Triple: "x86_64-pc-windows-msvc",
+}
+
+var LinuxTargetSpec = TargetSpec{
+	Name:        "Linux",
+	CargoCfgKey: `cfg(target_os = "linux")`,
+	Facts: map[string]string{
+		"target_os":     "linux",
+		"target_family": "unix",
+		"target_env":    "gnu",
+	},
+	Triple: "x86_64-unknown-linux-gnu",
+}
+
+var IOSTargetSpec = TargetSpec{
+	Name:        "iOS",
+	CargoCfgKey: `cfg(target_os = "ios")`,
+	Facts: map[string]string{
+		"target_os":     "ios",
+		"target_family": "unix",
+		"target_vendor": "apple",
+	},
+	Triple: "aarch64-apple-ios",
+}
+
+var AndroidTargetSpec = TargetSpec{
+	Name:        "Android",
+	CargoCfgKey: `cfg(target_os = "android")`,
+	Facts: map[string]string{
+		"target_os":     "android",
+		"target_family": "unix",
+	},
+	Triple: "aarch64-linux-android",
+}
+
+// AllTargetSpecs is every platform RunCfgGate knows how to validate — the
+// value to set CheckContext.TargetSpecs to for "check every platform's
+// dependencies are properly gated in one run".
+var AllTargetSpecs = []TargetSpec{DefaultMacOSTargetSpec, WindowsTargetSpec, LinuxTargetSpec, IOSTargetSpec, AndroidTargetSpec}
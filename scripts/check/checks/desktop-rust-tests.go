@@ -14,6 +14,9 @@ func RunRustTests(ctx *CheckContext) (CheckResult, error) {
 
 	// Check if cargo-nextest is installed
 	if !CommandExists("cargo-nextest") {
+		if ctx.Offline {
+			return Skipped("cargo-nextest not installed and --offline set"), nil
+		}
 		installCmd := exec.Command("cargo", "install", "cargo-nextest", "--locked")
 		if _, err := RunCommand(installCmd, true); err != nil {
 			return CheckResult{}, fmt.Errorf("failed to install cargo-nextest: %w", err)
@@ -22,7 +25,7 @@ func RunRustTests(ctx *CheckContext) (CheckResult, error) {
 
 	cmd := exec.Command("cargo", "nextest", "run")
 	cmd.Dir = rustDir
-	output, err := RunCommand(cmd, true)
+	output, err := RunCommandLogged(ctx, cmd, true)
 	if err != nil {
 		return CheckResult{}, fmt.Errorf("rust tests failed\n%s", indentOutput(output))
 	}
@@ -0,0 +1,159 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// discoverWorkspaceCrates returns the directory of every crate RunCfgGate
+// should analyze under rootDir's desktop Rust tree: the root crate itself,
+// every crate matched by its Cargo.toml's [workspace] members globs (if it
+// declares one), and every crate any of those pull in via a `path = "..."`
+// dependency. A root Cargo.toml with no [workspace] table (the common case
+// for this repo today) resolves to just the root crate, preserving
+// RunCfgGate's original single-crate behavior.
+//
+// Each returned directory is expected to contain its own Cargo.toml and
+// src/ directory, the same layout RunCfgGate already assumes for the root
+// crate.
+func discoverWorkspaceCrates(rootCrateDir string) ([]string, error) {
+	seen := map[string]bool{rootCrateDir: true}
+	queue := []string{rootCrateDir}
+	var crates []string
+
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		crates = append(crates, dir)
+
+		cargoPath := filepath.Join(dir, "Cargo.toml")
+		var cargo map[string]any
+		if _, err := toml.DecodeFile(cargoPath, &cargo); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, memberDir := range workspaceMemberDirs(dir, cargo) {
+			if !seen[memberDir] {
+				seen[memberDir] = true
+				queue = append(queue, memberDir)
+			}
+		}
+		for _, pathDepDir := range pathDependencyDirs(dir, cargo) {
+			if !seen[pathDepDir] {
+				seen[pathDepDir] = true
+				queue = append(queue, pathDepDir)
+			}
+		}
+	}
+
+	sort.Strings(crates)
+	return crates, nil
+}
+
+// workspaceMemberDirs resolves a Cargo.toml's [workspace] members globs
+// (e.g. "crates/*") relative to dir, returning only matches that are
+// themselves a crate (contain a Cargo.toml). A match is dropped if its path
+// relative to dir appears in [workspace] exclude, mirroring Cargo's own
+// exclude semantics (a literal path to drop out of an otherwise-matching
+// members glob, not itself a glob pattern).
+func workspaceMemberDirs(dir string, cargo map[string]any) []string {
+	workspaceSection, ok := cargo["workspace"]
+	if !ok {
+		return nil
+	}
+	workspaceMap, ok := workspaceSection.(map[string]any)
+	if !ok {
+		return nil
+	}
+	members, ok := workspaceMap["members"].([]any)
+	if !ok {
+		return nil
+	}
+
+	excluded := make(map[string]bool)
+	if excludeList, ok := workspaceMap["exclude"].([]any); ok {
+		for _, e := range excludeList {
+			if relPath, ok := e.(string); ok {
+				excluded[filepath.Clean(relPath)] = true
+			}
+		}
+	}
+
+	var dirs []string
+	for _, m := range members {
+		pattern, ok := m.(string)
+		if !ok {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			continue
+		}
+		for _, match := range matches {
+			if relPath, err := filepath.Rel(dir, match); err == nil && excluded[relPath] {
+				continue
+			}
+			if info, err := os.Stat(match); err == nil && info.IsDir() {
+				if _, err := os.Stat(filepath.Join(match, "Cargo.toml")); err == nil {
+					dirs = append(dirs, match)
+				}
+			}
+		}
+	}
+	return dirs
+}
+
+// pathDependencyDirs resolves every `path = "..."` dependency declared
+// anywhere in a Cargo.toml's [dependencies], [dev-dependencies],
+// [build-dependencies] or [target.*.dependencies] tables to an absolute
+// crate directory, so a crate pulled in purely via a path dependency (not
+// listed under [workspace] members) still gets scanned. As with
+// workspaceMemberDirs, a resolved path that doesn't itself contain a
+// Cargo.toml is skipped rather than queued — a path dependency can point at
+// a build-script-only helper or a not-yet-scaffolded crate, and one such
+// entry shouldn't abort cfg-gate checking for the rest of the workspace.
+func pathDependencyDirs(dir string, cargo map[string]any) []string {
+	var dirs []string
+
+	collect := func(depsSection any) {
+		depsMap, ok := depsSection.(map[string]any)
+		if !ok {
+			return
+		}
+		for _, depValue := range depsMap {
+			depMap, ok := depValue.(map[string]any)
+			if !ok {
+				continue
+			}
+			relPath, ok := depMap["path"].(string)
+			if !ok || relPath == "" {
+				continue
+			}
+			depDir := filepath.Join(dir, relPath)
+			if _, err := os.Stat(filepath.Join(depDir, "Cargo.toml")); err != nil {
+				continue
+			}
+			dirs = append(dirs, depDir)
+		}
+	}
+
+	for _, key := range []string{"dependencies", "dev-dependencies", "build-dependencies"} {
+		collect(cargo[key])
+	}
+
+	if targetSection, ok := cargo["target"].(map[string]any); ok {
+		for _, section := range targetSection {
+			if sectionMap, ok := section.(map[string]any); ok {
+				collect(sectionMap["dependencies"])
+			}
+		}
+	}
+
+	return dirs
+}
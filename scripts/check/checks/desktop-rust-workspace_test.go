@@ -0,0 +1,161 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// --- discoverWorkspaceCrates ---
+
+func TestDiscoverWorkspaceCrates_SingleCrateNoWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	content := `
+[package]
+name = "app"
+`
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	crates, err := discoverWorkspaceCrates(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crates) != 1 || crates[0] != dir {
+		t.Errorf("expected [%s], got %v", dir, crates)
+	}
+}
+
+func TestDiscoverWorkspaceCrates_MissingCargoTomlFallsBackToRoot(t *testing.T) {
+	dir := t.TempDir()
+
+	crates, err := discoverWorkspaceCrates(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(crates) != 1 || crates[0] != dir {
+		t.Errorf("expected [%s], got %v", dir, crates)
+	}
+}
+
+func TestDiscoverWorkspaceCrates_WorkspaceMembersGlob(t *testing.T) {
+	dir := t.TempDir()
+	rootContent := `
+[workspace]
+members = ["crates/*"]
+`
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte(rootContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"foo", "bar"} {
+		memberDir := filepath.Join(dir, "crates", name)
+		if err := os.MkdirAll(memberDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		memberContent := `
+[package]
+name = "` + name + `"
+`
+		if err := os.WriteFile(filepath.Join(memberDir, "Cargo.toml"), []byte(memberContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	crates, err := discoverWorkspaceCrates(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{dir, filepath.Join(dir, "crates", "bar"), filepath.Join(dir, "crates", "foo")}
+	sort.Strings(want)
+	if len(crates) != len(want) {
+		t.Fatalf("expected %v, got %v", want, crates)
+	}
+	for i := range want {
+		if crates[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, crates)
+			break
+		}
+	}
+}
+
+func TestDiscoverWorkspaceCrates_WorkspaceExcludeIsHonored(t *testing.T) {
+	dir := t.TempDir()
+	rootContent := `
+[workspace]
+members = ["crates/*"]
+exclude = ["crates/skip-me"]
+`
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte(rootContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"keep-me", "skip-me"} {
+		memberDir := filepath.Join(dir, "crates", name)
+		if err := os.MkdirAll(memberDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		memberContent := `
+[package]
+name = "` + name + `"
+`
+		if err := os.WriteFile(filepath.Join(memberDir, "Cargo.toml"), []byte(memberContent), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	crates, err := discoverWorkspaceCrates(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, c := range crates {
+		if c == filepath.Join(dir, "crates", "skip-me") {
+			t.Errorf("expected crates/skip-me to be excluded, got %v", crates)
+		}
+	}
+}
+
+func TestDiscoverWorkspaceCrates_PathDependencyPulledInWithoutWorkspaceMember(t *testing.T) {
+	dir := t.TempDir()
+	rootContent := `
+[package]
+name = "app"
+
+[dependencies]
+shared = { path = "../shared" }
+`
+	if err := os.WriteFile(filepath.Join(dir, "Cargo.toml"), []byte(rootContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sharedDir := filepath.Join(filepath.Dir(dir), "shared")
+	if err := os.MkdirAll(sharedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	sharedContent := `
+[package]
+name = "shared"
+`
+	if err := os.WriteFile(filepath.Join(sharedDir, "Cargo.toml"), []byte(sharedContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	crates, err := discoverWorkspaceCrates(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, c := range crates {
+		if c == sharedDir {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected %s among discovered crates, got %v", sharedDir, crates)
+	}
+}
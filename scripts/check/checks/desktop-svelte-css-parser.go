@@ -0,0 +1,273 @@
+package checks
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Regexes ported from the standalone scripts/check-css-unused tool's
+// parser.go, the one RunCSSUnused now runs natively against instead of
+// shelling out to `go run .` for (see desktop-svelte-css-unused.go).
+var (
+	// cssVarDefPattern matches a CSS custom property definition: --name: value.
+	cssVarDefPattern = regexp.MustCompile(`--([a-zA-Z][a-zA-Z0-9-]*)\s*:`)
+
+	// cssVarUsePattern matches a CSS custom property reference: var(--name).
+	cssVarUsePattern = regexp.MustCompile(`var\(--([a-zA-Z][a-zA-Z0-9-]*)\)`)
+
+	// cssClassDefPattern matches a class selector, including one wrapped in
+	// :global(...) (e.g. ":global(.foo)" still contains the literal ".foo"
+	// this matches) — Svelte's own scoping hash is added at build time, not
+	// present in source, so no separate scoped-selector handling is needed.
+	cssClassDefPattern = regexp.MustCompile(`\.([a-zA-Z_][a-zA-Z0-9_-]*)`)
+
+	// cssClassDynamicPattern matches Svelte's class:name directive.
+	cssClassDynamicPattern = regexp.MustCompile(`class:([a-zA-Z_][a-zA-Z0-9_-]*)`)
+
+	// cssClassStaticPattern matches a plain class="a b c" attribute.
+	cssClassStaticPattern = regexp.MustCompile(`class\s*=\s*"([^"]+)"`)
+
+	// cssClassExprPattern matches a class={...} attribute binding - most
+	// commonly a ternary (class={cond ? 'a' : 'b'}), which cssStringLiteralPattern
+	// then picks the quoted class-name literals out of.
+	cssClassExprPattern = regexp.MustCompile(`class\s*=\s*\{([^}]*)\}`)
+
+	// cssStringLiteralPattern picks class-name-shaped string literals out of
+	// a class={...} expression's body.
+	cssStringLiteralPattern = regexp.MustCompile(`['"]([a-zA-Z_][a-zA-Z0-9_ -]*)['"]`)
+
+	// cssCommentPattern matches a /* ... */ CSS comment, possibly spanning
+	// multiple lines.
+	cssCommentPattern = regexp.MustCompile(`(?s)/\*.*?\*/`)
+)
+
+// cssReservedNames are pseudo-classes/elements that look like a class name
+// to cssClassDefPattern but aren't one, e.g. :root, ::before.
+var cssReservedNames = map[string]bool{
+	"root": true, "before": true, "after": true, "hover": true, "focus": true,
+	"active": true, "first": true, "last": true, "nth": true, "not": true,
+	"global": true, "checked": true, "disabled": true, "empty": true,
+	"enabled": true, "visited": true, "link": true, "target": true,
+}
+
+// cssLoc is one definition or usage site reported by the find* functions
+// below: a name (a var or class, sans its -- or . sigil) and the 1-indexed
+// source line it appeared on. File is filled in by the caller once the loc
+// is attributed to the file it was scanned from.
+type cssLoc struct {
+	Name string
+	File string
+	Line int
+}
+
+// stripCssComments removes /* ... */ comments from CSS content before
+// findClassDefinitions runs over it, so a commented-out selector or a
+// file path/URL inside a comment doesn't produce a phantom class definition.
+func stripCssComments(content string) string {
+	return cssCommentPattern.ReplaceAllString(content, "")
+}
+
+// findNamedLocs runs pattern (which must have exactly one capture group)
+// over content line by line, reporting one cssLoc per match with its
+// 1-indexed line — the same per-line scanning convention
+// scanFileLinesForUses uses for Rust `use` statements, so a CSS rule or
+// property spanning more than one line isn't matched (acceptable here since
+// these are all single-line declarations in practice).
+func findNamedLocs(content string, pattern *regexp.Regexp) []cssLoc {
+	var locs []cssLoc
+	for i, line := range strings.Split(content, "\n") {
+		for _, m := range pattern.FindAllStringSubmatch(line, -1) {
+			locs = append(locs, cssLoc{Name: m[1], Line: i + 1})
+		}
+	}
+	return locs
+}
+
+// findVarDefinitions finds every CSS custom property definition in content.
+func findVarDefinitions(content string) []cssLoc {
+	return findNamedLocs(content, cssVarDefPattern)
+}
+
+// findVarUsages finds every CSS custom property reference in content. Vars
+// can be referenced from anywhere — style, script (dynamic inline styles),
+// or template — so unlike findClassUsagesInTemplate this isn't scoped to
+// one section of the file.
+func findVarUsages(content string) []cssLoc {
+	return findNamedLocs(content, cssVarUsePattern)
+}
+
+// findClassDefinitions finds every class selector in content (typically a
+// <style> section or a standalone .css file), skipping pseudo-classes that
+// only look like one.
+func findClassDefinitions(content string) []cssLoc {
+	var locs []cssLoc
+	for _, loc := range findNamedLocs(stripCssComments(content), cssClassDefPattern) {
+		if !cssReservedNames[loc.Name] {
+			locs = append(locs, loc)
+		}
+	}
+	return locs
+}
+
+// findClassUsagesInTemplate finds every class actually applied in
+// templateContent: a static class="..." attribute, a class:name directive,
+// or a string literal inside a class={...} expression (most commonly a
+// ternary, e.g. class={active ? 'tab-active' : 'tab'}).
+func findClassUsagesInTemplate(templateContent string) []cssLoc {
+	var locs []cssLoc
+	seen := map[string]bool{}
+	for i, line := range strings.Split(templateContent, "\n") {
+		for _, cls := range classUsagesInLine(line) {
+			if seen[cls] {
+				continue
+			}
+			seen[cls] = true
+			locs = append(locs, cssLoc{Name: cls, Line: i + 1})
+		}
+	}
+	return locs
+}
+
+// classUsagesInLine returns every distinct, plausible class name applied on
+// one line of template content, deduplicated so a class appearing in both a
+// static class="..." and a class:foo directive on the same line isn't
+// reported twice.
+func classUsagesInLine(line string) []string {
+	seen := map[string]bool{}
+	var names []string
+	add := func(s string) {
+		if s == "" || seen[s] || !isValidClassName(s) {
+			return
+		}
+		seen[s] = true
+		names = append(names, s)
+	}
+
+	for _, m := range cssClassStaticPattern.FindAllStringSubmatch(line, -1) {
+		for _, cls := range strings.Fields(m[1]) {
+			add(cls)
+		}
+	}
+	for _, m := range cssClassDynamicPattern.FindAllStringSubmatch(line, -1) {
+		add(m[1])
+	}
+	for _, m := range cssClassExprPattern.FindAllStringSubmatch(line, -1) {
+		for _, lm := range cssStringLiteralPattern.FindAllStringSubmatch(m[1], -1) {
+			for _, cls := range strings.Fields(lm[1]) {
+				add(cls)
+			}
+		}
+	}
+	return names
+}
+
+// extractStyleSection returns content with every line outside its first
+// <style>...</style> block replaced by an empty line, so
+// findClassDefinitions/findVarDefinitions report line numbers relative to
+// the whole file rather than an extracted snippet. A Svelte component is
+// expected to have at most one <style> block; a second one is ignored, the
+// same way svelte-check treats it as an error rather than something to
+// merge.
+func extractStyleSection(content string) string {
+	var out []string
+	inStyle := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, "<style") {
+			inStyle = true
+		}
+		if inStyle {
+			out = append(out, line)
+		} else {
+			out = append(out, "")
+		}
+		if strings.Contains(line, "</style>") {
+			inStyle = false
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// extractTemplateSection returns content with every line inside a <script>
+// or <style> block replaced by an empty line — the inverse of
+// extractStyleSection — so findClassUsagesInTemplate only sees markup, not
+// a class-shaped string that happens to appear in a <script> block's TS
+// source or a CSS selector.
+func extractTemplateSection(content string) string {
+	var out []string
+	inScript, inStyle := false, false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, "<script") {
+			inScript = true
+		}
+		if strings.Contains(line, "<style") {
+			inStyle = true
+		}
+		if inScript || inStyle {
+			out = append(out, "")
+		} else {
+			out = append(out, line)
+		}
+		if strings.Contains(line, "</script>") {
+			inScript = false
+		}
+		if strings.Contains(line, "</style>") {
+			inStyle = false
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// isValidClassName reports whether s looks like a real CSS class name
+// rather than a JS operator, event name, or test ID that happened to match
+// one of the class-usage patterns above.
+func isValidClassName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	first := s[0]
+	if !((first >= 'a' && first <= 'z') || (first >= 'A' && first <= 'Z') || first == '_') {
+		return false
+	}
+
+	invalidChars := []string{"=", "&", "|", "!", "?", "(", ")", "{", "}", "[", "]", ".", ",", ";"}
+	for _, char := range invalidChars {
+		if strings.Contains(s, char) {
+			return false
+		}
+	}
+
+	if looksLikeCSSEventName(s) || looksLikeCSSTestID(s) {
+		return false
+	}
+
+	return true
+}
+
+// looksLikeCSSEventName reports whether s looks like a Tauri/DOM event name
+// rather than a class, e.g. "download-complete".
+func looksLikeCSSEventName(s string) bool {
+	eventPatterns := []string{
+		"-complete", "-progress", "-error", "-cancelled", "-changed",
+		"-mounted", "-unmounted", "-found", "-lost", "-resolved",
+		"-conflict", "-state-changed",
+	}
+	for _, pattern := range eventPatterns {
+		if strings.HasSuffix(s, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeCSSTestID reports whether s looks like a test identifier rather
+// than a class, e.g. "test-fixture-1".
+func looksLikeCSSTestID(s string) bool {
+	testPatterns := []string{"test-", "mock-", "invalid-", "valid-", "tampered-"}
+	for _, pattern := range testPatterns {
+		if strings.HasPrefix(s, pattern) {
+			return true
+		}
+	}
+	return strings.HasPrefix(s, "listing-")
+}
@@ -2,20 +2,188 @@ package checks
 
 import (
 	"fmt"
-	"os/exec"
+	"io/fs"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
-// RunCSSUnused checks for unused and undefined CSS classes and variables.
+// cssScanDir is the subtree RunCSSUnused walks, matching the check's
+// registered Inputs globs (apps/desktop/src/**/*.css, **/*.svelte).
+const cssScanDir = "apps/desktop/src"
+
+// RunCSSUnused checks for unused and undefined CSS classes and variables
+// across the desktop app's .css and .svelte sources. It used to shell out
+// to the standalone scripts/check-css-unused tool (`go run .`); it now
+// scans ctx.effectiveFS() natively instead, the way every other checks.Run*
+// function does, so it can populate CheckResult.Diagnostics per finding
+// (see desktop-svelte-css-parser.go for the scanning helpers and
+// desktop-svelte-css-allowlist.go for the exceptions). The standalone tool
+// at scripts/check-css-unused is left in place as an independent CLI a
+// developer can still run directly.
+//
+// Two gaps in the original tool are closed here: an "undefined CSS
+// variable" category (a var(--x) reference with no --x: ... definition
+// anywhere), symmetric with the existing undefined-class category; and
+// class={cond ? 'a' : 'b'} ternary/expression literals, which the
+// original's class-usage scan didn't pick up at all (see
+// findClassUsagesInTemplate in desktop-svelte-css-parser.go).
+//
+// Deliberately not attempted: a :global(...)-aware selector parser (the
+// existing class-selector regex already matches the .foo inside
+// :global(.foo) for free) and a real CSS import-dependency graph for
+// <link rel="stylesheet">/import './foo.css' (every .css file under
+// cssScanDir is already scanned unconditionally, so resolving which
+// stylesheet a component imports wouldn't change what's found).
 func RunCSSUnused(ctx *CheckContext) (CheckResult, error) {
-	scriptDir := filepath.Join(ctx.RootDir, "scripts", "check-css-unused")
+	defs, uses, err := scanCSS(ctx.effectiveFS())
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("failed to scan CSS: %w", err)
+	}
+
+	diagnostics := cssDiagnosticsFor(defs, uses)
+	if len(diagnostics) == 0 {
+		return Success("No unused or undefined CSS"), nil
+	}
+
+	sort.Slice(diagnostics, func(i, j int) bool {
+		if diagnostics[i].File != diagnostics[j].File {
+			return diagnostics[i].File < diagnostics[j].File
+		}
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+
+	var lines []string
+	for _, d := range diagnostics {
+		lines = append(lines, fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message))
+	}
+
+	return CheckResult{Diagnostics: diagnostics}, fmt.Errorf("CSS issues found\n%s", indentOutput(strings.Join(lines, "\n")))
+}
+
+// cssDefUse collects every CSS var/class definition and usage site found by
+// scanCSS, keyed by name, so cssDiagnosticsFor can compare the two sets.
+type cssDefUse struct {
+	varDefs, varUses     map[string][]cssLoc
+	classDefs, classUses map[string][]cssLoc
+}
+
+// scanCSS walks every .css and .svelte file under cssScanDir in fsys,
+// collecting where each CSS variable and class is defined and used.
+func scanCSS(fsys SourceFS) (cssDefUse, cssDefUse, error) {
+	defs := cssDefUse{
+		varDefs:   map[string][]cssLoc{},
+		classDefs: map[string][]cssLoc{},
+	}
+	uses := cssDefUse{
+		varUses:   map[string][]cssLoc{},
+		classUses: map[string][]cssLoc{},
+	}
+
+	err := fs.WalkDir(fsys, cssScanDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == cssScanDir {
+				return nil // apps/desktop/src doesn't exist in this tree (e.g. a test fixture); nothing to scan
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		if ext != ".css" && ext != ".svelte" {
+			return nil
+		}
+
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return nil
+		}
+		content := string(data)
 
-	cmd := exec.Command("go", "run", ".")
-	cmd.Dir = scriptDir
-	output, err := RunCommand(cmd, true)
+		style := content
+		if ext == ".svelte" {
+			style = extractStyleSection(content)
+		}
+		for _, loc := range findVarDefinitions(style) {
+			loc.File = path
+			defs.varDefs[loc.Name] = append(defs.varDefs[loc.Name], loc)
+		}
+		for _, loc := range findClassDefinitions(style) {
+			loc.File = path
+			defs.classDefs[loc.Name] = append(defs.classDefs[loc.Name], loc)
+		}
+
+		for _, loc := range findVarUsages(content) {
+			loc.File = path
+			uses.varUses[loc.Name] = append(uses.varUses[loc.Name], loc)
+		}
+
+		if ext == ".svelte" {
+			for _, loc := range findClassUsagesInTemplate(extractTemplateSection(content)) {
+				loc.File = path
+				uses.classUses[loc.Name] = append(uses.classUses[loc.Name], loc)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("CSS issues found\n%s", indentOutput(output))
+		return cssDefUse{}, cssDefUse{}, err
 	}
 
-	return Success("No unused or undefined CSS"), nil
+	return defs, uses, nil
+}
+
+// cssDiagnosticsFor compares defs against uses and reports one Diagnostic
+// per unused variable, unused class, undefined class, and undefined
+// variable, skipping anything covered by the allowlists in
+// desktop-svelte-css-allowlist.go.
+func cssDiagnosticsFor(defs, uses cssDefUse) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for name, locs := range defs.varDefs {
+		if _, used := uses.varUses[name]; !used && !cssAllowedUnusedVariables[name] {
+			diagnostics = append(diagnostics, cssDiagnostic(locs[0], "css-unused-var",
+				fmt.Sprintf("CSS variable --%s is defined but never used", name)))
+		}
+	}
+
+	for name, locs := range defs.classDefs {
+		if _, used := uses.classUses[name]; !used && !cssAllowedUnusedClasses[name] {
+			diagnostics = append(diagnostics, cssDiagnostic(locs[0], "css-unused-class",
+				fmt.Sprintf("CSS class .%s is defined but never used", name)))
+		}
+	}
+
+	for name, locs := range uses.classUses {
+		if _, defined := defs.classDefs[name]; !defined && !cssAllowedUndefinedClasses[name] && !isLikelyExternalCSSClass(name) {
+			diagnostics = append(diagnostics, cssDiagnostic(locs[0], "css-undefined-class",
+				fmt.Sprintf("class %q is used but has no CSS definition", name)))
+		}
+	}
+
+	for name, locs := range uses.varUses {
+		if _, defined := defs.varDefs[name]; !defined {
+			diagnostics = append(diagnostics, cssDiagnostic(locs[0], "css-undefined-var",
+				fmt.Sprintf("CSS variable var(--%s) is used but never defined", name)))
+		}
+	}
+
+	return diagnostics
+}
+
+// cssDiagnostic builds a Diagnostic for a CSS finding; loc carries the
+// definition or usage site being reported. Severity is "warning", and
+// Tool/HelpURI are left empty since this is cmdr's own analysis, not a
+// relayed linter with a public docs page (see Diagnostic.HelpURI).
+func cssDiagnostic(loc cssLoc, rule, message string) Diagnostic {
+	return Diagnostic{
+		File:     loc.File,
+		Line:     loc.Line,
+		Rule:     rule,
+		Message:  message,
+		Severity: "warning",
+	}
 }
@@ -0,0 +1,139 @@
+package checks
+
+import "testing"
+
+func newCSSTestFS() *MemFS {
+	fsys := NewMemFS()
+	fsys.AddFile("apps/desktop/src/Button.svelte", `<script lang="ts">
+  export let active = false
+</script>
+
+<button class={active ? 'btn-active' : 'btn-idle'}>
+  Click
+</button>
+
+<style>
+.btn-idle {
+  color: var(--text-color);
+}
+.btn-active {
+  color: red;
+}
+.unused-button-class {
+  color: blue;
+}
+</style>
+`)
+	fsys.AddFile("apps/desktop/src/theme.css", `:root {
+  --text-color: #111;
+  --unused-token: #eee;
+}
+`)
+	return fsys
+}
+
+func TestRunCSSUnused_NoIssuesWhenEverythingMatches(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("apps/desktop/src/Button.svelte", `<button class="btn">Click</button>
+<style>
+.btn {
+  color: var(--text-color);
+}
+</style>
+`)
+	fsys.AddFile("apps/desktop/src/theme.css", `:root {
+  --text-color: #111;
+}
+`)
+
+	result, err := RunCSSUnused(&CheckContext{FS: fsys})
+	if err != nil {
+		t.Fatalf("expected no issues, got error: %v\nresult: %+v", err, result)
+	}
+	if result.Code != ResultSuccess {
+		t.Errorf("expected ResultSuccess, got %v", result.Code)
+	}
+}
+
+func TestRunCSSUnused_ReportsUnusedAndUndefined(t *testing.T) {
+	fsys := newCSSTestFS()
+
+	result, err := RunCSSUnused(&CheckContext{FS: fsys})
+	if err == nil {
+		t.Fatalf("expected an error reporting CSS issues, got none: %+v", result)
+	}
+
+	byRule := map[string][]Diagnostic{}
+	for _, d := range result.Diagnostics {
+		byRule[d.Rule] = append(byRule[d.Rule], d)
+	}
+
+	if len(byRule["css-unused-class"]) != 1 || byRule["css-unused-class"][0].Message == "" {
+		t.Errorf("expected one css-unused-class diagnostic for .unused-button-class, got %+v", byRule["css-unused-class"])
+	}
+	if len(byRule["css-unused-var"]) != 1 {
+		t.Errorf("expected one css-unused-var diagnostic for --unused-token, got %+v", byRule["css-unused-var"])
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == "css-undefined-class" || d.Rule == "css-undefined-var" {
+			t.Errorf("expected no undefined findings (every use has a matching def), got %+v", d)
+		}
+	}
+
+	for _, d := range result.Diagnostics {
+		if d.Severity != "warning" {
+			t.Errorf("expected Severity warning, got %q for %+v", d.Severity, d)
+		}
+		if d.Tool != "" || d.HelpURI != "" {
+			t.Errorf("expected no Tool/HelpURI for cmdr's own analysis, got %+v", d)
+		}
+	}
+}
+
+func TestRunCSSUnused_ReportsUndefinedClassAndVar(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("apps/desktop/src/Panel.svelte", `<div class="ghost-class" style="color: var(--ghost-token)">content</div>
+<style>
+.real {
+  color: blue;
+}
+</style>
+`)
+
+	result, err := RunCSSUnused(&CheckContext{FS: fsys})
+	if err == nil {
+		t.Fatalf("expected an error reporting CSS issues, got none: %+v", result)
+	}
+
+	var sawUndefinedClass, sawUndefinedVar bool
+	for _, d := range result.Diagnostics {
+		switch d.Rule {
+		case "css-undefined-class":
+			sawUndefinedClass = true
+		case "css-undefined-var":
+			sawUndefinedVar = true
+		}
+	}
+	if !sawUndefinedClass {
+		t.Errorf("expected a css-undefined-class diagnostic for .ghost-class, got %+v", result.Diagnostics)
+	}
+	if !sawUndefinedVar {
+		t.Errorf("expected a css-undefined-var diagnostic for --ghost-token, got %+v", result.Diagnostics)
+	}
+}
+
+func TestRunCSSUnused_IgnoresAllowlistedAndExternalClasses(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("apps/desktop/src/Button.svelte", `<div class="flex real slider-root">content</div>
+<style>
+.real {
+  color: blue;
+}
+</style>
+`)
+
+	result, err := RunCSSUnused(&CheckContext{FS: fsys})
+	if err != nil {
+		t.Fatalf("expected no issues (flex is utility, btn-primary/slider-root are allowlisted), got error: %v\nresult: %+v", err, result)
+	}
+}
@@ -7,8 +7,21 @@ import (
 	"strings"
 )
 
-// RunDesktopESLint lints and fixes code with ESLint.
+// RunDesktopESLint lints and fixes code with ESLint. In ctx.CI (`pnpm
+// lint`, no --fix, no mutation), the result is cached by input file content
+// — see CachedCheck — since that path never writes to disk; the local
+// auto-fixing path always runs fresh.
 func RunDesktopESLint(ctx *CheckContext) (CheckResult, error) {
+	if ctx.CI {
+		return CachedCheck(ctx, "desktop-svelte-eslint", desktopSvelteSourceGlobs, pnpmVersion, 0, func() (CheckResult, error) {
+			return runDesktopESLint(ctx)
+		})
+	}
+	return runDesktopESLint(ctx)
+}
+
+// runDesktopESLint is RunDesktopESLint's uncached implementation.
+func runDesktopESLint(ctx *CheckContext) (CheckResult, error) {
 	desktopDir := filepath.Join(ctx.RootDir, "apps", "desktop")
 
 	// Count lintable files
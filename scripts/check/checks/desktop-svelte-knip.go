@@ -1,6 +1,7 @@
 package checks
 
 import (
+	"encoding/json"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -20,11 +21,17 @@ func RunKnip(ctx *CheckContext) (CheckResult, error) {
 		fileCount = len(strings.Split(strings.TrimSpace(findOutput), "\n"))
 	}
 
-	cmd := exec.Command("pnpm", "knip")
+	cmd := exec.Command("pnpm", "knip", "--reporter", "json")
 	cmd.Dir = desktopDir
 	output, err := RunCommand(cmd, true)
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("knip found unused code or dependencies\n%s", indentOutput(output))
+		diagnostics, parseErr := knipDiagnostics(output, "apps/desktop")
+		if parseErr != nil {
+			// Fall back to the raw output if it's not the JSON we expect
+			// (e.g. knip itself crashed before it could report).
+			return CheckResult{}, fmt.Errorf("knip found unused code or dependencies\n%s", indentOutput(output))
+		}
+		return CheckResult{Diagnostics: diagnostics}, fmt.Errorf("knip found %d unused %s\n%s", len(diagnostics), Pluralize(len(diagnostics), "issue", "issues"), indentOutput(knipSummary(diagnostics)))
 	}
 
 	if fileCount > 0 {
@@ -32,3 +39,91 @@ func RunKnip(ctx *CheckContext) (CheckResult, error) {
 	}
 	return Success("No unused code"), nil
 }
+
+// knipIssue mirrors the subset of knip's --reporter json output this check
+// cares about: one entry per file, with a same-shaped array of locations for
+// each issue category knip reports. Categories this check doesn't
+// specifically name (owners, duplicates, ...) are intentionally left out -
+// add one here if a future check needs to single it out.
+type knipReport struct {
+	Issues []knipIssue `json:"issues"`
+}
+
+type knipIssue struct {
+	File       string           `json:"file"`
+	Exports    []knipIssueEntry `json:"exports"`
+	Types      []knipIssueEntry `json:"types"`
+	Unlisted   []knipIssueEntry `json:"unlisted"`
+	Unresolved []knipIssueEntry `json:"unresolved"`
+	Binaries   []knipIssueEntry `json:"binaries"`
+}
+
+type knipIssueEntry struct {
+	Name string `json:"name"`
+	Line int    `json:"line"`
+	Col  int    `json:"col"`
+}
+
+// knipDiagnostics parses knip's --reporter json output into one Diagnostic
+// per reported entry, with Rule naming which knip category it came from
+// (e.g. "knip-exports") so tooling consuming --format=sarif/github can tell
+// an unused export apart from an unresolved import. appDir is prefixed on to
+// each entry's file path, since knip (like the JSON it emits) reports paths
+// relative to cmd.Dir, not the repo root.
+func knipDiagnostics(output, appDir string) ([]Diagnostic, error) {
+	// RunCommand appends stderr after stdout, so the JSON object knip wrote to
+	// stdout may have trailing warning text after it - trim to the outermost
+	// {...} before parsing rather than unmarshaling the whole combined blob.
+	start := strings.IndexByte(output, '{')
+	end := strings.LastIndexByte(output, '}')
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON object found in knip output")
+	}
+
+	var report knipReport
+	if err := json.Unmarshal([]byte(output[start:end+1]), &report); err != nil {
+		return nil, fmt.Errorf("failed to parse knip JSON output: %w", err)
+	}
+
+	var diagnostics []Diagnostic
+	for _, issue := range report.Issues {
+		file := filepath.Join(appDir, issue.File)
+		diagnostics = append(diagnostics, knipEntryDiagnostics(file, "knip-exports", "unused export", issue.Exports)...)
+		diagnostics = append(diagnostics, knipEntryDiagnostics(file, "knip-types", "unused type", issue.Types)...)
+		diagnostics = append(diagnostics, knipEntryDiagnostics(file, "knip-unlisted", "unlisted dependency", issue.Unlisted)...)
+		diagnostics = append(diagnostics, knipEntryDiagnostics(file, "knip-unresolved", "unresolved import", issue.Unresolved)...)
+		diagnostics = append(diagnostics, knipEntryDiagnostics(file, "knip-binaries", "unused binary", issue.Binaries)...)
+	}
+	return diagnostics, nil
+}
+
+func knipEntryDiagnostics(file, rule, label string, entries []knipIssueEntry) []Diagnostic {
+	diagnostics := make([]Diagnostic, len(entries))
+	for i, e := range entries {
+		diagnostics[i] = Diagnostic{
+			File:     file,
+			Line:     e.Line,
+			Col:      e.Col,
+			Rule:     rule,
+			Message:  fmt.Sprintf("%s: %s", label, e.Name),
+			Severity: "warning",
+			Tool:     "knip",
+		}
+	}
+	return diagnostics
+}
+
+// knipSummary renders diagnostics back into the short human-readable lines
+// the text report prints alongside the error, since the JSON reporter's raw
+// output isn't meant for a terminal.
+func knipSummary(diagnostics []Diagnostic) string {
+	lines := make([]string, len(diagnostics))
+	for i, d := range diagnostics {
+		if d.Line > 0 {
+			lines[i] = fmt.Sprintf("%s:%d: %s", d.File, d.Line, d.Message)
+		} else {
+			lines[i] = fmt.Sprintf("%s: %s", d.File, d.Message)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -3,13 +3,17 @@ package checks
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+
+	"vmail/scripts/check/checks/coverage"
 )
 
 // CoverageThreshold is the minimum line coverage percentage required.
@@ -31,25 +35,35 @@ type FileCoverage struct {
 	Branches   CoverageMetric `json:"branches"`
 }
 
-// CoverageAllowlist represents the allowlist configuration.
-type CoverageAllowlist struct {
-	Comment string                    `json:"$comment"`
-	Files   map[string]AllowlistEntry `json:"files"`
-}
+// CoverageAllowlist is the coverage-allowlist.json schema RunSvelteTests
+// reads, shared with RunGoCoverage's Go modules via coverage.Allowlist so the
+// two checks' glob-matching and tie-break rule (see coverage.Allowlist.ThresholdFor)
+// never drift apart into disagreeing about what a threshold override matches.
+type CoverageAllowlist = coverage.Allowlist
 
-// AllowlistEntry represents a single allowlisted file entry.
-type AllowlistEntry struct {
-	Reason string `json:"reason"`
-}
+// AllowlistEntry is a single allowlisted file's recorded reason; see
+// coverage.AllowlistEntry.
+type AllowlistEntry = coverage.AllowlistEntry
+
+// CoverageThresholdEntry overrides CoverageThreshold for every file whose
+// repo-relative path (under apps/desktop/src/lib) matches its key in
+// CoverageAllowlist.Thresholds, e.g. `"src/lib/foo/**": {"lines": 90}`; see
+// coverage.ThresholdEntry.
+type CoverageThresholdEntry = coverage.ThresholdEntry
 
-// RunSvelteTests runs Svelte unit tests with Vitest and checks coverage.
+// RunSvelteTests runs Svelte unit tests with Vitest and checks coverage. In
+// ctx.ChangedOnly mode (always on in CI, see CheckContext.ChangedOnly) it
+// only fails on coverage regressions in lines added or modified since
+// ctx.BaseRef, rather than every file below threshold — new code is held to
+// the bar without forcing a rewrite of every already-merged file first.
 func RunSvelteTests(ctx *CheckContext) (CheckResult, error) {
 	desktopDir := filepath.Join(ctx.RootDir, "apps", "desktop")
+	desktopRelDir := filepath.Join("apps", "desktop")
 
 	// Run tests with coverage using pnpm
 	cmd := exec.Command("pnpm", "test:coverage")
 	cmd.Dir = desktopDir
-	output, err := RunCommand(cmd, true)
+	output, err := ctx.effectiveExec().Run(cmd, true)
 	if err != nil {
 		return CheckResult{}, fmt.Errorf("svelte tests failed\n%s", indentOutput(output))
 	}
@@ -62,28 +76,93 @@ func RunSvelteTests(ctx *CheckContext) (CheckResult, error) {
 		testCount = testMatches[1]
 	}
 
-	// Parse coverage summary
-	coverageFile := filepath.Join(desktopDir, "coverage", "coverage-summary.json")
-	coverageData, err := os.ReadFile(coverageFile)
+	allowlist, err := loadCoverageAllowlist(ctx.effectiveFS(), desktopRelDir)
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("failed to read coverage summary: %w", err)
+		return CheckResult{}, err
 	}
 
-	var coverage map[string]FileCoverage
-	if err := json.Unmarshal(coverageData, &coverage); err != nil {
-		return CheckResult{}, fmt.Errorf("failed to parse coverage summary: %w", err)
+	// The [coverage] ts threshold in cmdr.toml overrides the CoverageThreshold
+	// constant when configured, the same way RunCoverage's Go/Rust legs do.
+	defaultThreshold := CoverageThreshold
+	if ctx.Config != nil && ctx.Config.Coverage.TS > 0 {
+		defaultThreshold = float64(ctx.Config.Coverage.TS)
 	}
 
-	// Load allowlist
-	allowlistFile := filepath.Join(desktopDir, "coverage-allowlist.json")
-	allowlist := CoverageAllowlist{Files: make(map[string]AllowlistEntry)}
-	if allowlistData, err := os.ReadFile(allowlistFile); err == nil {
-		if err := json.Unmarshal(allowlistData, &allowlist); err != nil {
-			return CheckResult{}, fmt.Errorf("failed to parse coverage allowlist: %w", err)
+	// Loaded once and shared between the LCOV export and --changed-only: both
+	// want the same per-statement detail from coverage-final.json, and it can
+	// be large enough that parsing it twice is wasteful.
+	final, finalErr := loadIstanbulCoverage(ctx.effectiveFS(), desktopRelDir)
+
+	// Best-effort: a PR-bot-facing summary, not something this check's
+	// pass/fail should hinge on, so a failure to read or write it only logs.
+	if finalErr == nil {
+		if lcovErr := writeCoverageLCOV(ctx.RootDir, desktopDir, final); lcovErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to write coverage LCOV summary: %v\n", lcovErr)
+		}
+	}
+
+	var lowCoverageFiles []string
+	if ctx.ChangedOnly {
+		if finalErr != nil {
+			return CheckResult{}, fmt.Errorf("failed to read coverage-final.json (needed for --changed-only): %w", finalErr)
+		}
+		lowCoverageFiles, err = checkChangedLineCoverage(ctx, desktopDir, final, allowlist, defaultThreshold)
+	} else {
+		lowCoverageFiles, err = checkFileCoverage(ctx.effectiveFS(), desktopDir, desktopRelDir, allowlist, defaultThreshold)
+	}
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	if len(lowCoverageFiles) > 0 {
+		sort.Strings(lowCoverageFiles)
+		errorMsg := "Files below coverage threshold:\n"
+		for _, f := range lowCoverageFiles {
+			errorMsg += "      " + f + "\n"
 		}
+		errorMsg += "\n      To allowlist a file, add it to coverage-allowlist.json with a reason."
+		return CheckResult{}, fmt.Errorf("coverage below threshold for %d files\n%s", len(lowCoverageFiles), errorMsg)
+	}
+
+	if testCount == "all" {
+		return Success("All tests passed"), nil
+	}
+	count, _ := strconv.Atoi(testCount)
+	return Success(fmt.Sprintf("%d %s passed", count, Pluralize(count, "test", "tests"))), nil
+}
+
+// loadCoverageAllowlist reads desktopRelDir/coverage-allowlist.json (relative
+// to fsys's root), treating a missing file as an empty allowlist (the same
+// way the pre-existing RunSvelteTests did before --changed-only was added).
+func loadCoverageAllowlist(fsys SourceFS, desktopRelDir string) (CoverageAllowlist, error) {
+	allowlist := CoverageAllowlist{Files: make(map[string]AllowlistEntry)}
+	data, err := fs.ReadFile(fsys, path.Join(desktopRelDir, "coverage-allowlist.json"))
+	if err != nil {
+		return allowlist, nil
+	}
+	if err := json.Unmarshal(data, &allowlist); err != nil {
+		return CoverageAllowlist{}, fmt.Errorf("failed to parse coverage allowlist: %w", err)
+	}
+	return allowlist, nil
+}
+
+// checkFileCoverage is RunSvelteTests' whole-file mode: every file's overall
+// line coverage (from coverage-summary.json) must meet its threshold.
+// desktopDir is vitest's working directory (the prefix its coverage-summary.json
+// keys, which are absolute filesystem paths, are stripped against);
+// desktopRelDir is the same directory relative to fsys's root, used to read
+// the file itself.
+func checkFileCoverage(fsys SourceFS, desktopDir, desktopRelDir string, allowlist CoverageAllowlist, defaultThreshold float64) ([]string, error) {
+	coverageData, err := fs.ReadFile(fsys, path.Join(desktopRelDir, "coverage", "coverage-summary.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read coverage summary: %w", err)
+	}
+
+	var coverage map[string]FileCoverage
+	if err := json.Unmarshal(coverageData, &coverage); err != nil {
+		return nil, fmt.Errorf("failed to parse coverage summary: %w", err)
 	}
 
-	// Check coverage for each file
 	var lowCoverageFiles []string
 	srcPrefix := filepath.Join(desktopDir, "src", "lib") + "/"
 
@@ -98,25 +177,224 @@ func RunSvelteTests(ctx *CheckContext) (CheckResult, error) {
 			continue
 		}
 
-		if fileCov.Lines.Pct < CoverageThreshold {
+		threshold := allowlist.ThresholdFor(relPath, defaultThreshold)
+		if fileCov.Lines.Pct < threshold {
 			lowCoverageFiles = append(lowCoverageFiles,
-				fmt.Sprintf("  %s: %.1f%% (threshold: %.0f%%)", relPath, fileCov.Lines.Pct, CoverageThreshold))
+				fmt.Sprintf("  %s: %.1f%% (threshold: %.0f%%)", relPath, fileCov.Lines.Pct, threshold))
 		}
 	}
+	return lowCoverageFiles, nil
+}
 
-	if len(lowCoverageFiles) > 0 {
-		sort.Strings(lowCoverageFiles)
-		errorMsg := "Files below coverage threshold:\n"
-		for _, f := range lowCoverageFiles {
-			errorMsg += "      " + f + "\n"
+// istanbulFileCoverage is the subset of one file's entry in Istanbul's
+// coverage-final.json (written alongside coverage-summary.json by vitest's
+// coverage provider) that checkChangedLineCoverage needs: each statement's
+// starting line and how many times it ran, so a specific line's coverage
+// can be checked instead of only a whole-file percentage.
+type istanbulFileCoverage struct {
+	StatementMap map[string]istanbulRange `json:"statementMap"`
+	S            map[string]int           `json:"s"`
+}
+
+type istanbulRange struct {
+	Start istanbulPosition `json:"start"`
+}
+
+type istanbulPosition struct {
+	Line int `json:"line"`
+}
+
+// loadIstanbulCoverage reads and parses desktopRelDir/coverage/coverage-final.json
+// (relative to fsys's root), vitest's per-statement coverage detail (as
+// opposed to coverage-summary.json's whole-file percentages) — shared by
+// checkChangedLineCoverage and writeCoverageLCOV so RunSvelteTests parses it
+// at most once per run.
+func loadIstanbulCoverage(fsys SourceFS, desktopRelDir string) (map[string]istanbulFileCoverage, error) {
+	data, err := fs.ReadFile(fsys, path.Join(desktopRelDir, "coverage", "coverage-final.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var final map[string]istanbulFileCoverage
+	if err := json.Unmarshal(data, &final); err != nil {
+		return nil, fmt.Errorf("failed to parse coverage-final.json: %w", err)
+	}
+	return final, nil
+}
+
+// checkChangedLineCoverage is RunSvelteTests' --changed-only mode: a file is
+// only flagged if the lines it added or modified since ctx.BaseRef (see
+// changedLinesByFile) are, among themselves, covered below threshold —
+// lines untouched by the change are ignored even if the file as a whole is
+// well below CoverageThreshold.
+func checkChangedLineCoverage(ctx *CheckContext, desktopDir string, final map[string]istanbulFileCoverage, allowlist CoverageAllowlist, defaultThreshold float64) ([]string, error) {
+	baseRef := ctx.BaseRef
+	if baseRef == "" {
+		baseRef = "origin/main"
+	}
+	srcDir := filepath.Join("apps", "desktop", "src", "lib")
+	changed, err := changedLinesByFile(ctx.RootDir, baseRef, srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s for --changed-only: %w", baseRef, err)
+	}
+
+	srcPrefix := filepath.Join(desktopDir, "src", "lib") + "/"
+
+	var filePaths []string
+	for filePath := range final {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	var lowCoverageFiles []string
+	for _, filePath := range filePaths {
+		relPath, ok := strings.CutPrefix(filePath, srcPrefix)
+		if !ok {
+			continue
+		}
+
+		changedLines := changed[relPath]
+		if len(changedLines) == 0 {
+			continue
+		}
+		if _, ok := allowlist.Files[relPath]; ok {
+			continue
+		}
+
+		hit, total := changedLineHits(final[filePath], changedLines)
+		if total == 0 {
+			continue
+		}
+
+		pct := 100 * float64(hit) / float64(total)
+		threshold := allowlist.ThresholdFor(relPath, defaultThreshold)
+		if pct < threshold {
+			lowCoverageFiles = append(lowCoverageFiles,
+				fmt.Sprintf("  %s: %.1f%% of changed lines covered (threshold: %.0f%%)", relPath, pct, threshold))
 		}
-		errorMsg += "\n      To allowlist a file, add it to coverage-allowlist.json with a reason."
-		return CheckResult{}, fmt.Errorf("coverage below threshold for %d files\n%s", len(lowCoverageFiles), errorMsg)
 	}
+	return lowCoverageFiles, nil
+}
 
-	if testCount == "all" {
-		return Success("All tests passed"), nil
+// changedLineHits returns how many of fileCov's statements start on a
+// changed line (total), and how many of those ran at least once (hit).
+func changedLineHits(fileCov istanbulFileCoverage, changedLines map[int]bool) (hit, total int) {
+	for id, stmt := range fileCov.StatementMap {
+		if !changedLines[stmt.Start.Line] {
+			continue
+		}
+		total++
+		if fileCov.S[id] > 0 {
+			hit++
+		}
 	}
-	count, _ := strconv.Atoi(testCount)
-	return Success(fmt.Sprintf("%d %s passed", count, Pluralize(count, "test", "tests"))), nil
+	return hit, total
+}
+
+// diffFileHeaderRe and diffHunkHeaderRe match the two `git diff --unified=0`
+// lines parseChangedLines cares about: which file a hunk belongs to, and
+// where in the new file its added lines start.
+var (
+	diffFileHeaderRe = regexp.MustCompile(`^\+\+\+ b/(.+)$`)
+	diffHunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+)
+
+// changedLinesByFile diffs baseRef against HEAD (three-dot, so against their
+// merge base rather than baseRef's current tip — the usual PR-review
+// semantics) and returns, for each changed file under dir, the set of line
+// numbers its new version added or modified.
+func changedLinesByFile(rootDir, baseRef, dir string) (map[string]map[int]bool, error) {
+	cmd := exec.Command("git", "diff", "--unified=0", baseRef+"...HEAD", "--", dir)
+	cmd.Dir = rootDir
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w\n%s", err, output)
+	}
+	return parseChangedLines(output, dir), nil
+}
+
+// parseChangedLines walks a --unified=0 `git diff` (no context lines, so
+// every body line is either wholly added or wholly removed) and returns the
+// set of new-file line numbers added under each file, keyed by path
+// relative to dir to match the form Istanbul's coverage-final.json paths
+// are compared in.
+func parseChangedLines(diff, dir string) map[string]map[int]bool {
+	changed := make(map[string]map[int]bool)
+	var currentFile string
+	var currentLine int
+
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			currentFile = ""
+			if m := diffFileHeaderRe.FindStringSubmatch(line); m != nil {
+				if rel, err := filepath.Rel(dir, m[1]); err == nil {
+					currentFile = rel
+					if _, ok := changed[currentFile]; !ok {
+						changed[currentFile] = make(map[int]bool)
+					}
+				}
+			}
+		case strings.HasPrefix(line, "@@ "):
+			if m := diffHunkHeaderRe.FindStringSubmatch(line); m != nil {
+				currentLine, _ = strconv.Atoi(m[1])
+			}
+		case currentFile == "":
+			// Not inside a hunk for a file under dir (e.g. "diff --git" or
+			// "index ..." header lines) — nothing to record.
+		case strings.HasPrefix(line, "+"):
+			changed[currentFile][currentLine] = true
+			currentLine++
+		case strings.HasPrefix(line, "-"):
+			// Removed line: doesn't exist in the new file, so it doesn't
+			// consume a new-file line number.
+		}
+	}
+	return changed
+}
+
+// writeCoverageLCOV converts apps/desktop's coverage-final.json (Istanbul,
+// already parsed into final) into an LCOV file at coverage/coverage.lcov —
+// the format PR-comment bots (e.g. lcov-reporter-action) expect for posting
+// a coverage diff comment — mirroring gocov2lcov's DA:/end_of_record
+// rendering and its take-the-highest-count handling of a line covered by
+// more than one statement. SF: paths are rewritten relative to rootDir,
+// since coverage-final.json's keys are absolute filesystem paths and an
+// LCOV consumer resolves SF against the repo checkout root.
+func writeCoverageLCOV(rootDir, desktopDir string, final map[string]istanbulFileCoverage) error {
+	var files []string
+	for file := range final {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var out strings.Builder
+	for _, file := range files {
+		fileCov := final[file]
+		lineHits := map[int]int{}
+		for id, stmt := range fileCov.StatementMap {
+			hits := fileCov.S[id]
+			if existing, seen := lineHits[stmt.Start.Line]; !seen || hits > existing {
+				lineHits[stmt.Start.Line] = hits
+			}
+		}
+		var lineNums []int
+		for line := range lineHits {
+			lineNums = append(lineNums, line)
+		}
+		sort.Ints(lineNums)
+
+		sourceFile := file
+		if rel, err := filepath.Rel(rootDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			sourceFile = rel
+		}
+
+		fmt.Fprintf(&out, "SF:%s\n", sourceFile)
+		for _, line := range lineNums {
+			fmt.Fprintf(&out, "DA:%d,%d\n", line, lineHits[line])
+		}
+		out.WriteString("end_of_record\n")
+	}
+
+	return os.WriteFile(filepath.Join(desktopDir, "coverage", "coverage.lcov"), []byte(out.String()), 0644)
 }
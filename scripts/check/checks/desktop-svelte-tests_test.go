@@ -0,0 +1,117 @@
+package checks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCoverageAllowlist_ThresholdForUsesMostSpecificMatch(t *testing.T) {
+	allowlist := CoverageAllowlist{
+		Thresholds: map[string]CoverageThresholdEntry{
+			"src/lib/**":     {Lines: 80},
+			"src/lib/foo/**": {Lines: 95},
+		},
+	}
+
+	if got := allowlist.ThresholdFor("src/lib/foo/bar.ts", CoverageThreshold); got != 95 {
+		t.Errorf("expected the more specific src/lib/foo/** entry (95), got %v", got)
+	}
+	if got := allowlist.ThresholdFor("src/lib/baz.ts", CoverageThreshold); got != 80 {
+		t.Errorf("expected the src/lib/** entry (80), got %v", got)
+	}
+	if got := allowlist.ThresholdFor("src/other/baz.ts", CoverageThreshold); got != CoverageThreshold {
+		t.Errorf("expected the global default (%v) for an unmatched path, got %v", CoverageThreshold, got)
+	}
+}
+
+func TestParseChangedLines_TracksAddedLinesNotRemovedOnes(t *testing.T) {
+	diff := `diff --git a/apps/desktop/src/lib/foo.ts b/apps/desktop/src/lib/foo.ts
+index 1111111..2222222 100644
+--- a/apps/desktop/src/lib/foo.ts
++++ b/apps/desktop/src/lib/foo.ts
+@@ -2,2 +2,3 @@
+-old line 2
+-old line 3
++new line 2
++new line 3
++new line 4
+`
+
+	changed := parseChangedLines(diff, "apps/desktop/src/lib")
+	lines, ok := changed["foo.ts"]
+	if !ok {
+		t.Fatalf("expected foo.ts to have changed lines, got %v", changed)
+	}
+	for _, want := range []int{2, 3, 4} {
+		if !lines[want] {
+			t.Errorf("expected line %d to be marked changed", want)
+		}
+	}
+	if len(lines) != 3 {
+		t.Errorf("expected exactly 3 changed lines, got %d", len(lines))
+	}
+}
+
+func TestLoadCoverageAllowlist_MissingFileIsEmpty(t *testing.T) {
+	allowlist, err := loadCoverageAllowlist(NewMemFS(), "apps/desktop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(allowlist.Files) != 0 {
+		t.Errorf("expected an empty allowlist, got %v", allowlist)
+	}
+}
+
+func TestCheckFileCoverage_FlagsFilesBelowThresholdAndSkipsAllowlisted(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("apps/desktop/coverage/coverage-summary.json", `{
+		"/repo/apps/desktop/src/lib/foo.ts": {"lines": {"pct": 50}},
+		"/repo/apps/desktop/src/lib/bar.ts": {"lines": {"pct": 90}},
+		"/repo/apps/desktop/src/lib/baz.ts": {"lines": {"pct": 10}}
+	}`)
+	allowlist := CoverageAllowlist{Files: map[string]AllowlistEntry{"baz.ts": {Reason: "legacy, tracked in TICKET-1"}}}
+
+	lowCoverageFiles, err := checkFileCoverage(fsys, "/repo/apps/desktop", "apps/desktop", allowlist, CoverageThreshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lowCoverageFiles) != 1 || !strings.Contains(lowCoverageFiles[0], "foo.ts") {
+		t.Errorf("expected only foo.ts flagged (bar.ts meets threshold, baz.ts is allowlisted), got %v", lowCoverageFiles)
+	}
+}
+
+func TestRunSvelteTests_FakeExecSimulatesPnpmFailure(t *testing.T) {
+	fakeExec := &FakeExec{
+		Responses: map[string]FakeExecResult{
+			"pnpm": {Output: "1 test failed", Err: errors.New("exit status 1")},
+		},
+	}
+	ctx := &CheckContext{RootDir: "/repo", FS: NewMemFS(), Exec: fakeExec}
+
+	_, err := RunSvelteTests(ctx)
+	if err == nil {
+		t.Fatal("expected the simulated pnpm failure to fail the check")
+	}
+	if !strings.Contains(err.Error(), "1 test failed") {
+		t.Errorf("expected the fake command's output in the error, got: %v", err)
+	}
+	if len(fakeExec.Calls) != 1 || !strings.Contains(fakeExec.Calls[0], "test:coverage") {
+		t.Errorf("expected pnpm test:coverage to be recorded, got %v", fakeExec.Calls)
+	}
+}
+
+func TestChangedLineHits_OnlyCountsStatementsOnChangedLines(t *testing.T) {
+	fileCov := istanbulFileCoverage{
+		StatementMap: map[string]istanbulRange{
+			"0": {Start: istanbulPosition{Line: 2}},
+			"1": {Start: istanbulPosition{Line: 5}},
+		},
+		S: map[string]int{"0": 0, "1": 3},
+	}
+
+	hit, total := changedLineHits(fileCov, map[int]bool{2: true})
+	if total != 1 || hit != 0 {
+		t.Errorf("expected 0/1 (only line 2's unhit statement counted), got %d/%d", hit, total)
+	}
+}
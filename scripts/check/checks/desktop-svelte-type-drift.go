@@ -2,28 +2,36 @@ package checks
 
 import (
 	"fmt"
-	"os/exec"
 	"path/filepath"
-	"regexp"
-	"strconv"
+	"strings"
+
+	"vmail/scripts/check/checks/typedrift"
 )
 
-// RunTypeDrift detects drift between Rust and TypeScript type definitions.
+// RunTypeDrift detects drift between Rust types exported over Tauri's IPC
+// boundary and their hand-written TypeScript counterparts: every field whose
+// name, optionality, or type disagrees between the two sides, not just a
+// pass/fail type count (see checks/typedrift for the parser and diff).
 func RunTypeDrift(ctx *CheckContext) (CheckResult, error) {
-	cmd := exec.Command("pnpm", "check:type-drift")
-	cmd.Dir = filepath.Join(ctx.RootDir, "apps", "desktop")
-	output, err := RunCommand(cmd, true)
+	desktopDir := filepath.Join(ctx.RootDir, "apps", "desktop")
+	rustDir := filepath.Join(desktopDir, "src-tauri")
+	tsDir := filepath.Join(desktopDir, "src", "lib", "types")
+	cachePath := filepath.Join(ctx.RootDir, ".cache", "typedrift.json")
+
+	rust, ts, err := typedrift.Load(cachePath, rustDir, tsDir)
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("type drift detected between Rust and TypeScript\n%s", indentOutput(output))
+		return CheckResult{}, fmt.Errorf("failed to parse Rust/TypeScript types: %w", err)
 	}
 
-	// Try to extract type count from output (e.g., "Checked 42 types")
-	re := regexp.MustCompile(`(\d+) types?`)
-	matches := re.FindStringSubmatch(output)
-	if len(matches) > 1 {
-		count, _ := strconv.Atoi(matches[1])
-		return Success(fmt.Sprintf("%d %s in sync", count, Pluralize(count, "type", "types"))), nil
+	drifts := typedrift.Diff(rust, ts)
+	if len(drifts) > 0 {
+		messages := make([]string, len(drifts))
+		for i, d := range drifts {
+			messages[i] = d.Message
+		}
+		return CheckResult{}, fmt.Errorf("type drift detected between Rust and TypeScript\n%s", indentOutput(strings.Join(messages, "\n")))
 	}
 
-	return Success("All types in sync"), nil
+	count := len(ts)
+	return Success(fmt.Sprintf("%d %s in sync", count, Pluralize(count, "type", "types"))), nil
 }
@@ -0,0 +1,64 @@
+package checks
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Exec runs external commands on behalf of a check. RealExec (the default,
+// see CheckContext.effectiveExec) just delegates to RunCommand; FakeExec lets
+// a test script a command's output and error instead of actually shelling
+// out, the same nil-falls-back-to-real-implementation convention as
+// CheckContext.FS/effectiveFS.
+type Exec interface {
+	Run(cmd *exec.Cmd, captureOutput bool) (string, error)
+}
+
+// RealExec is the production Exec, backed by RunCommand.
+type RealExec struct{}
+
+// Run implements Exec.
+func (RealExec) Run(cmd *exec.Cmd, captureOutput bool) (string, error) {
+	return RunCommand(cmd, captureOutput)
+}
+
+// FakeExecResult is the canned response FakeExec returns for one command.
+type FakeExecResult struct {
+	Output string
+	Err    error
+}
+
+// FakeExec is a test-only Exec that records every command it's asked to run
+// (as a space-joined arg list, in Calls) and returns a canned FakeExecResult
+// keyed by the command's program name (e.g. "pnpm"), instead of actually
+// running it — for table-driven tests simulating a tool's output or failure
+// without it being installed.
+type FakeExec struct {
+	Responses map[string]FakeExecResult
+	Calls     []string
+}
+
+// Run implements Exec.
+func (f *FakeExec) Run(cmd *exec.Cmd, captureOutput bool) (string, error) {
+	f.Calls = append(f.Calls, strings.Join(cmd.Args, " "))
+	result := f.Responses[programName(cmd)]
+	return result.Output, result.Err
+}
+
+// programName returns the last path segment of cmd.Path, the part FakeExec's
+// callers key Responses by (e.g. "/usr/bin/pnpm" -> "pnpm").
+func programName(cmd *exec.Cmd) string {
+	if idx := strings.LastIndexByte(cmd.Path, '/'); idx >= 0 {
+		return cmd.Path[idx+1:]
+	}
+	return cmd.Path
+}
+
+// effectiveExec returns ctx.Exec, or a RealExec if it's unset — the same
+// nil-fallback convention as effectiveFS.
+func (ctx *CheckContext) effectiveExec() Exec {
+	if ctx.Exec != nil {
+		return ctx.Exec
+	}
+	return RealExec{}
+}
@@ -3,12 +3,19 @@ package checks
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"vmail/scripts/check/checks/config"
+	"vmail/scripts/check/checks/tokens"
 )
 
+// fileLengthWarnLines and fileLengthCriticalLines are the defaults used when
+// a repo has no cmdr.toml (or its [filelength] section doesn't set warn/critical).
 const (
 	fileLengthWarnLines     = 800
 	fileLengthCriticalLines = 1200
@@ -38,87 +45,222 @@ var fileLengthSkipDirs = map[string]bool{
 }
 
 type longFile struct {
-	relPath   string
-	lines     int
-	sizeBytes int64
+	relPath     string
+	lines       int
+	sizeBytes   int64
+	tokens      int64
+	tokensExact bool // true if tokens came from the real BPE tokenizer, false if it's the sizeBytes/4 heuristic
 }
 
 // RunFileLength scans the repo for source files exceeding the line count threshold.
 // Always succeeds — reports long files as a warning, never fails.
 func RunFileLength(ctx *CheckContext) (CheckResult, error) {
+	cfg := ctx.Config
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
+	longFiles, err := scanLongFiles(ctx.effectiveFS(), cfg, ctx.FastTokens)
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("failed to scan files: %w", err)
+	}
+
+	if len(longFiles) == 0 {
+		return Success("All files under threshold"), nil
+	}
+
+	sort.Slice(longFiles, func(i, j int) bool {
+		return longFiles[i].relPath < longFiles[j].relPath
+	})
+
+	baseline := emptyBaseline()
+	if !ctx.NoBaseline {
+		loaded, err := LoadBaseline(ctx.RootDir)
+		if err != nil {
+			return CheckResult{}, fmt.Errorf("failed to load baseline: %w", err)
+		}
+		baseline = loaded
+	}
+
+	var fresh, grandfathered []longFile
+	for _, f := range longFiles {
+		entry, known := baseline.Files[f.relPath]
+		if known && f.lines <= entry.Lines {
+			grandfathered = append(grandfathered, f)
+		} else {
+			fresh = append(fresh, f)
+		}
+	}
+
+	if len(fresh) == 0 {
+		msg := fmt.Sprintf("All %d over-threshold %s are grandfathered in the baseline", len(grandfathered),
+			Pluralize(len(grandfathered), "file", "files"))
+		return CheckResult{Code: ResultWarning, Message: msg}, nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%d %s over %d lines:\n%s",
+		len(fresh), Pluralize(len(fresh), "file", "files"), cfg.FileLength.Default.Warn, renderLongFiles(fresh, cfg)))
+
+	if len(grandfathered) > 0 {
+		sb.WriteString(fmt.Sprintf("\n\ngrandfathered (in %s, run `cmdr baseline update` to refresh):\n%s",
+			baselineFileName, renderLongFiles(grandfathered, cfg)))
+	}
+
+	return CheckResult{Code: ResultWarning, Message: sb.String()}, nil
+}
+
+// sourceExtensions returns the effective source-extension set: the default
+// set with cfg.FileLength.ExtraExtensions added and RemoveExtensions removed.
+func sourceExtensions(cfg *config.Config) map[string]bool {
+	extensions := make(map[string]bool, len(fileLengthSourceExtensions))
+	for ext := range fileLengthSourceExtensions {
+		extensions[ext] = true
+	}
+	for _, ext := range cfg.FileLength.ExtraExtensions {
+		extensions["."+strings.TrimPrefix(ext, ".")] = true
+	}
+	for _, ext := range cfg.FileLength.RemoveExtensions {
+		delete(extensions, "."+strings.TrimPrefix(ext, "."))
+	}
+	return extensions
+}
+
+// skipDirs returns the effective skip-dir set: the default set with
+// cfg.FileLength.ExtraSkipDirs added.
+func skipDirs(cfg *config.Config) map[string]bool {
+	dirs := make(map[string]bool, len(fileLengthSkipDirs))
+	for dir := range fileLengthSkipDirs {
+		dirs[dir] = true
+	}
+	for _, dir := range cfg.FileLength.ExtraSkipDirs {
+		dirs[dir] = true
+	}
+	return dirs
+}
+
+// thresholdFor resolves the warn/critical thresholds for relPath: a matching
+// PathOverride glob wins, then a PerExtension entry, then cfg's default.
+func thresholdFor(cfg *config.Config, relPath string) config.Threshold {
+	for _, override := range cfg.FileLength.PathOverrides {
+		if matched, _ := filepath.Match(override.Glob, relPath); matched {
+			return override.Threshold
+		}
+	}
+
+	ext := strings.TrimPrefix(filepath.Ext(relPath), ".")
+	if th, ok := cfg.FileLength.PerExtension[ext]; ok {
+		return th
+	}
+
+	return cfg.FileLength.Default
+}
+
+// scanLongFiles walks fsys and returns every source file at or over its
+// effective warn threshold, shared by RunFileLength and the `baseline update`
+// subcommand. Paths are fs.FS-style: relative, forward-slash, no leading "./".
+// When fastTokens is false, over-threshold files get an exact BPE token
+// count (see checks/tokens); when true, they get the sizeBytes/4 heuristic.
+func scanLongFiles(fsys SourceFS, cfg *config.Config, fastTokens bool) ([]longFile, error) {
+	extensions := sourceExtensions(cfg)
+	skip := skipDirs(cfg)
+
 	var longFiles []longFile
 
-	err := filepath.WalkDir(ctx.RootDir, func(path string, d os.DirEntry, err error) error {
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
+		if path == "." {
+			return nil
+		}
 		if d.IsDir() {
 			name := d.Name()
-			if strings.HasPrefix(name, ".") || fileLengthSkipDirs[name] {
-				return filepath.SkipDir
+			if strings.HasPrefix(name, ".") || skip[name] {
+				return fs.SkipDir
 			}
 			return nil
 		}
 
 		ext := filepath.Ext(d.Name())
-		if !fileLengthSourceExtensions[ext] {
+		if !extensions[ext] {
 			return nil
 		}
 
-		lineCount, err := countLines(path)
+		lineCount, err := countLinesFS(fsys, path)
 		if err != nil {
 			return nil
 		}
 
-		if lineCount >= fileLengthWarnLines {
+		threshold := thresholdFor(cfg, path)
+
+		if lineCount >= threshold.Warn {
 			info, err := d.Info()
 			if err != nil {
 				return nil
 			}
-			relPath, _ := filepath.Rel(ctx.RootDir, path)
+			tokenCount, exact := countTokensFS(fsys, path, ext, info.Size(), fastTokens)
 			longFiles = append(longFiles, longFile{
-				relPath:   relPath,
-				lines:     lineCount,
-				sizeBytes: info.Size(),
+				relPath:     path,
+				lines:       lineCount,
+				sizeBytes:   info.Size(),
+				tokens:      tokenCount,
+				tokensExact: exact,
 			})
 		}
 
 		return nil
 	})
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("failed to scan files: %w", err)
+		return nil, err
 	}
+	return longFiles, nil
+}
 
-	if len(longFiles) == 0 {
-		return Success("All files under threshold"), nil
+// countTokensFS returns a token count for path: the real BPE tokenizer
+// unless fastTokens is set or tokenization fails, in which case it falls
+// back to the sizeBytes/4 heuristic. The second return value reports which
+// path was taken.
+func countTokensFS(fsys SourceFS, path, ext string, sizeBytes int64, fastTokens bool) (int64, bool) {
+	if fastTokens {
+		return sizeBytes / 4, false
 	}
 
-	sort.Slice(longFiles, func(i, j int) bool {
-		return longFiles[i].relPath < longFiles[j].relPath
-	})
+	f, err := fsys.Open(path)
+	if err != nil {
+		return sizeBytes / 4, false
+	}
+	defer f.Close()
 
+	count, err := tokens.CountTokens(f, ext)
+	if err != nil {
+		return sizeBytes / 4, false
+	}
+	return count, true
+}
+
+// renderLongFiles formats a sorted list of long files the way RunFileLength
+// has always reported them: one bullet per file, color-coded by severity.
+func renderLongFiles(files []longFile, cfg *config.Config) string {
 	var sb strings.Builder
-	for _, f := range longFiles {
+	for _, f := range files {
 		sizeKB := f.sizeBytes / 1000
-		tokenStr := formatTokenCount(f.sizeBytes / 4)
-		detail := fmt.Sprintf("(%d lines, %d kB, ~%s tokens)", f.lines, sizeKB, tokenStr)
+		var tokenStr string
+		if f.tokensExact {
+			tokenStr = fmt.Sprintf("%s tokens", formatExactTokenCount(f.tokens))
+		} else {
+			tokenStr = fmt.Sprintf("~%s tokens", formatTokenCount(f.tokens))
+		}
+		detail := fmt.Sprintf("(%d lines, %d kB, %s)", f.lines, sizeKB, tokenStr)
 
 		color := ansiYellow
-		if f.lines >= fileLengthCriticalLines {
+		if f.lines >= thresholdFor(cfg, f.relPath).Critical {
 			color = ansiRed
 		}
 
 		sb.WriteString(fmt.Sprintf("  - %s %s%s%s\n", f.relPath, color, detail, ansiReset))
 	}
-
-	msg := fmt.Sprintf("%d %s over %d lines:\n%s",
-		len(longFiles),
-		Pluralize(len(longFiles), "file", "files"),
-		fileLengthWarnLines,
-		strings.TrimRight(sb.String(), "\n"),
-	)
-
-	return CheckResult{Code: ResultWarning, Message: msg}, nil
+	return strings.TrimRight(sb.String(), "\n")
 }
 
 func countLines(path string) (int, error) {
@@ -127,8 +269,22 @@ func countLines(path string) (int, error) {
 		return 0, err
 	}
 	defer f.Close()
+	return countLinesReader(f)
+}
+
+// countLinesFS is countLines for a SourceFS-backed path, used by scanLongFiles
+// so it works against both NewOSFS and NewMemFS.
+func countLinesFS(fsys SourceFS, path string) (int, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return countLinesReader(f)
+}
 
-	scanner := bufio.NewScanner(f)
+func countLinesReader(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
 	count := 0
 	for scanner.Scan() {
 		count++
@@ -136,9 +292,32 @@ func countLines(path string) (int, error) {
 	return count, scanner.Err()
 }
 
+// formatTokenCount renders the sizeBytes/4 heuristic's token count, e.g.
+// "1k" for 1000+ — used with a "~" prefix since it's only an estimate.
 func formatTokenCount(tokens int64) string {
 	if tokens >= 1000 {
 		return fmt.Sprintf("%dk", tokens/1000)
 	}
 	return fmt.Sprintf("%d", tokens)
 }
+
+// formatExactTokenCount renders a real BPE token count with thousands
+// separators, e.g. "1,024" — no "~" prefix, since it isn't an estimate.
+func formatExactTokenCount(tokens int64) string {
+	s := fmt.Sprintf("%d", tokens)
+	if len(s) <= 3 {
+		return s
+	}
+
+	var sb strings.Builder
+	lead := len(s) % 3
+	if lead == 0 {
+		lead = 3
+	}
+	sb.WriteString(s[:lead])
+	for i := lead; i < len(s); i += 3 {
+		sb.WriteByte(',')
+		sb.WriteString(s[i : i+3])
+	}
+	return sb.String()
+}
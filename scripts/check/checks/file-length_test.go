@@ -200,6 +200,54 @@ func TestRunFileLength_SortedAlphabetically(t *testing.T) {
 	}
 }
 
+func TestRunFileLength_WorksAgainstMemFS(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("long.go", strings.Repeat("line\n", 850))
+	fsys.AddFile("short.go", strings.Repeat("line\n", 100))
+	fsys.AddFile("node_modules/vendored.go", strings.Repeat("line\n", 1000))
+
+	ctx := &CheckContext{RootDir: "/unused", FS: fsys}
+	result, err := RunFileLength(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Code != ResultWarning {
+		t.Errorf("expected warning, got code %d", result.Code)
+	}
+	if !strings.Contains(result.Message, "long.go") {
+		t.Errorf("expected message to contain 'long.go', got: %s", result.Message)
+	}
+	if strings.Contains(result.Message, "short.go") {
+		t.Errorf("expected message to NOT contain 'short.go', got: %s", result.Message)
+	}
+	if strings.Contains(result.Message, "vendored.go") {
+		t.Errorf("expected node_modules to be skipped, got: %s", result.Message)
+	}
+}
+
+func TestRunFileLength_ExactTokenCountWhenTokenizerRuns(t *testing.T) {
+	tmp := t.TempDir()
+
+	path := filepath.Join(tmp, "test.go")
+	content := strings.Repeat("line\n", 850)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &CheckContext{RootDir: tmp} // FastTokens defaults to false: real tokenizer runs
+	result, err := RunFileLength(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(result.Message, "~") {
+		t.Errorf("expected no '~' once the real tokenizer ran, got: %s", result.Message)
+	}
+	if !strings.Contains(result.Message, "tokens") {
+		t.Errorf("expected a token count in message, got: %s", result.Message)
+	}
+}
+
 func TestRunFileLength_MessageFormat(t *testing.T) {
 	tmp := t.TempDir()
 
@@ -211,7 +259,9 @@ func TestRunFileLength_MessageFormat(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	ctx := &CheckContext{RootDir: tmp}
+	// --fast-tokens pins this test to the sizeBytes/4 heuristic, rather than
+	// the real BPE tokenizer, so the expected numbers below stay exact.
+	ctx := &CheckContext{RootDir: tmp, FastTokens: true}
 	result, err := RunFileLength(ctx)
 	if err != nil {
 		t.Fatal(err)
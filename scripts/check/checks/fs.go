@@ -0,0 +1,41 @@
+package checks
+
+import (
+	"io/fs"
+	"os"
+)
+
+// SourceFS is the filesystem checks scan for source files. It's the read
+// side of afero.Fs: checks only ever walk, open, and stat files, so that's
+// all this interface needs. NewOSFS wraps the real disk for production use;
+// NewMemFS builds a tree in memory, so tests like TestRunFileLength_* don't
+// have to touch disk at all.
+type SourceFS interface {
+	fs.FS
+	fs.StatFS
+}
+
+// osFS adapts a disk directory to SourceFS.
+type osFS struct {
+	fs.FS
+}
+
+// NewOSFS returns a SourceFS rooted at root, backed by the real filesystem.
+func NewOSFS(root string) SourceFS {
+	return osFS{FS: os.DirFS(root)}
+}
+
+func (o osFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(o.FS, name)
+}
+
+// effectiveFS returns ctx.FS, or a fresh NewOSFS(ctx.RootDir) if it's unset —
+// the fallback every check reading through SourceFS applies, factored out
+// here so RunFileLength, FindAllGoModules, and RunSvelteTests all fall back
+// the same way instead of repeating the nil check.
+func (ctx *CheckContext) effectiveFS() SourceFS {
+	if ctx.FS != nil {
+		return ctx.FS
+	}
+	return NewOSFS(ctx.RootDir)
+}
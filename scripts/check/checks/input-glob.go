@@ -0,0 +1,43 @@
+package checks
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// MatchInputGlob reports whether relPath (slash-separated, relative to
+// ctx.RootDir) matches pattern, a CheckDefinition.Inputs entry. Patterns are
+// matched segment by segment with filepath.Match (so "*", "?", and "[...]"
+// work within one path segment), plus a "**" segment that matches zero or
+// more path segments — the standard library's filepath.Match alone can't
+// express "apps/desktop/src-tauri/**/*.rs" since it treats "/" literally.
+// Exported for `cmdr watch` (see scripts/check/watch.go), which matches
+// changed files against Inputs from the main package.
+func MatchInputGlob(pattern, relPath string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+func matchGlobSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		if matchGlobSegments(pattern[1:], path) {
+			return true
+		}
+		if len(path) == 0 {
+			return false
+		}
+		return matchGlobSegments(pattern, path[1:])
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+	matched, err := filepath.Match(pattern[0], path[0])
+	if err != nil || !matched {
+		return false
+	}
+	return matchGlobSegments(pattern[1:], path[1:])
+}
@@ -0,0 +1,29 @@
+package checks
+
+import "testing"
+
+func TestMatchInputGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"apps/desktop/src/**/*.css", "apps/desktop/src/lib/Button.css", true},
+		{"apps/desktop/src/**/*.css", "apps/desktop/src/Button.css", true},
+		{"apps/desktop/src/**/*.css", "apps/desktop/src/lib/Button.svelte", false},
+		{"apps/desktop/src/**/*.css", "apps/other/src/Button.css", false},
+		{"apps/desktop/src-tauri/**/*.rs", "apps/desktop/src-tauri/src/lib.rs", true},
+		{"apps/desktop/src-tauri/**/*.rs", "apps/desktop/src-tauri/src/commands/window.rs", true},
+		{"**/*.go", "scripts/check/main.go", true},
+		{"**/*.go", "scripts/check/checks/config/config.go", true},
+		{"**/*.go", "README.md", false},
+		{"Cargo.lock", "Cargo.lock", true},
+		{"Cargo.lock", "apps/desktop/src-tauri/Cargo.lock", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchInputGlob(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchInputGlob(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
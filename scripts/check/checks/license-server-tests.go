@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
-	"regexp"
-	"strconv"
 )
 
 // RunLicenseServerTests runs tests on the license server.
@@ -19,11 +17,7 @@ func RunLicenseServerTests(ctx *CheckContext) (CheckResult, error) {
 		return CheckResult{}, fmt.Errorf("tests failed\n%s", indentOutput(output))
 	}
 
-	// Extract test count
-	re := regexp.MustCompile(`Tests\s+(\d+) passed`)
-	matches := re.FindStringSubmatch(output)
-	if len(matches) > 1 {
-		count, _ := strconv.Atoi(matches[1])
+	if count, ok := ParseVitestPassedCount(output); ok {
 		return Success(fmt.Sprintf("%d %s passed", count, Pluralize(count, "test", "tests"))), nil
 	}
 	return Success("All tests passed"), nil
@@ -0,0 +1,65 @@
+package checks
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"vmail/scripts/check/checks/locreport"
+)
+
+// locReportSnapshotFile is the committed golden file RunLocReport compares
+// the working tree's current locreport.Stats against, and UpdateLocReport
+// regenerates.
+const locReportSnapshotFile = "loc-report.json"
+
+// RunLocReport compares the working tree's current lines-of-code breakdown
+// (Rust prod/test, TS prod/test, Go, Svelte, Astro — see checks/locreport)
+// against the committed loc-report.json snapshot, giving the repo a cheap
+// trendline without a separate tool. Like Go's own test/run.go
+// -update_errors golden files, a mismatch here isn't fixed by re-running the
+// check — run `./scripts/check --check loc-report --update` (see
+// UpdateLocReport) to regenerate the snapshot once the change is intentional.
+func RunLocReport(ctx *CheckContext) (CheckResult, error) {
+	path := filepath.Join(ctx.RootDir, locReportSnapshotFile)
+
+	want, ok, err := locreport.Load(path)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	if !ok {
+		return Skipped(fmt.Sprintf("no %s snapshot; run --check loc-report --update to create one", locReportSnapshotFile)), nil
+	}
+
+	got, err := locreport.Compute(ctx.RootDir)
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	diffs := locreport.Diff(want, got)
+	if len(diffs) > 0 {
+		msg := fmt.Sprintf("%s is stale; run ./scripts/check --check loc-report --update to regenerate\n", locReportSnapshotFile)
+		for _, d := range diffs {
+			msg += "  " + d + "\n"
+		}
+		return CheckResult{}, fmt.Errorf("%s", msg)
+	}
+
+	return Success(fmt.Sprintf("%s matches the working tree", locReportSnapshotFile)), nil
+}
+
+// UpdateLocReport regenerates loc-report.json from the working tree's
+// current stats — the Update hook RunLocReport's CheckDefinition wires up,
+// invoked by the runner when --update is passed (see CheckContext.Update).
+func UpdateLocReport(ctx *CheckContext) (CheckResult, error) {
+	path := filepath.Join(ctx.RootDir, locReportSnapshotFile)
+
+	stats, err := locreport.Compute(ctx.RootDir)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	if err := locreport.Save(path, stats); err != nil {
+		return CheckResult{}, fmt.Errorf("writing %s: %w", locReportSnapshotFile, err)
+	}
+
+	return SuccessWithChanges(fmt.Sprintf("Regenerated %s", locReportSnapshotFile)), nil
+}
@@ -0,0 +1,60 @@
+package locreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Load reads a committed Stats snapshot from path. It returns ok=false
+// (rather than an error) if path doesn't exist yet, since that's the normal
+// state before anyone has run --update for the first time.
+func Load(path string) (stats Stats, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Stats{}, false, nil
+	}
+	if err != nil {
+		return Stats{}, false, err
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return Stats{}, false, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return stats, true, nil
+}
+
+// Save writes stats to path as indented JSON, terminated by a trailing
+// newline so the committed file round-trips cleanly through most editors'
+// "insert final newline" setting.
+func Save(path string, stats Stats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Diff compares want (the committed snapshot) against got (the current
+// working tree) and returns one line per field that moved.
+func Diff(want, got Stats) []string {
+	var lines []string
+	fields := []struct {
+		name string
+		want int
+		got  int
+	}{
+		{"rustProd", want.RustProd, got.RustProd},
+		{"rustTest", want.RustTest, got.RustTest},
+		{"tsProd", want.TSProd, got.TSProd},
+		{"tsTest", want.TSTest, got.TSTest},
+		{"svelte", want.Svelte, got.Svelte},
+		{"astro", want.Astro, got.Astro},
+		{"go", want.Go, got.Go},
+	}
+	for _, f := range fields {
+		if f.want != f.got {
+			lines = append(lines, fmt.Sprintf("%s: snapshot has %d, working tree has %d (%+d)", f.name, f.want, f.got, f.got-f.want))
+		}
+	}
+	return lines
+}
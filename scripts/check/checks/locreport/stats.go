@@ -0,0 +1,172 @@
+// Package locreport computes a lines-of-code breakdown across the monorepo
+// (Rust prod/test, TS prod/test, Go, Svelte, Astro, ...) and compares it
+// against a committed snapshot, giving the repo a cheap trendline without a
+// separate tool. The classification logic mirrors scripts/loc-counter's
+// fileStats/categorizeFile/countRustTestLines, adapted to walk the working
+// tree directly rather than a git commit's blobs, since a check runs against
+// whatever's checked out.
+package locreport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Stats is one snapshot of lines of code, broken down the same way
+// scripts/loc-counter's fileStats is.
+type Stats struct {
+	RustProd int `json:"rustProd"`
+	RustTest int `json:"rustTest"`
+	TSProd   int `json:"tsProd"`
+	TSTest   int `json:"tsTest"`
+	Svelte   int `json:"svelte"`
+	Astro    int `json:"astro"`
+	Go       int `json:"go"`
+}
+
+// skipDirs are directories Compute never descends into: VCS metadata and
+// dependency/build output that isn't source the repo's contributors wrote.
+var skipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"target":       true,
+	"dist":         true,
+	"build":        true,
+}
+
+// skipPatterns lists generated/lock files excluded from counting, the same
+// set scripts/loc-counter's shouldSkip uses.
+var skipPatterns = []string{
+	"pnpm-lock.yaml",
+	"package-lock.json",
+	"yarn.lock",
+	"Cargo.lock",
+	"go.sum",
+}
+
+func shouldSkip(path string) bool {
+	base := filepath.Base(path)
+	for _, pattern := range skipPatterns {
+		if matched, _ := filepath.Match(pattern, base); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestPath checks if a file lives under a test/tests/e2e directory.
+func isTestPath(path string) bool {
+	for _, part := range strings.Split(path, string(filepath.Separator)) {
+		switch part {
+		case "test", "tests", "__tests__", "e2e", "testutil", "testdata":
+			return true
+		}
+	}
+	return false
+}
+
+// countRustTestLines counts lines inside #[cfg(test)] blocks using brace-depth tracking.
+func countRustTestLines(content string) int {
+	testLines := 0
+	depth := 0
+	inTestBlock := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if !inTestBlock && strings.Contains(trimmed, "#[cfg(test)]") {
+			inTestBlock = true
+			testLines++
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+			continue
+		}
+
+		if inTestBlock {
+			testLines++
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+			if depth <= 0 {
+				inTestBlock = false
+				depth = 0
+			}
+		}
+	}
+
+	return testLines
+}
+
+func countLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	return strings.Count(content, "\n") + 1
+}
+
+// Compute walks rootDir and returns its current Stats.
+func Compute(rootDir string) (Stats, error) {
+	var stats Stats
+
+	err := filepath.WalkDir(rootDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(rootDir, path)
+		if err != nil {
+			return err
+		}
+		if shouldSkip(rel) {
+			return nil
+		}
+
+		ext := strings.ToLower(filepath.Ext(rel))
+		base := filepath.Base(rel)
+		testDir := isTestPath(rel)
+		isTSTestName := strings.HasSuffix(base, ".test.ts") || strings.HasSuffix(base, ".test.tsx") ||
+			strings.HasSuffix(base, ".spec.ts") || strings.HasSuffix(base, ".spec.tsx")
+
+		if ext != ".rs" && ext != ".ts" && ext != ".tsx" && ext != ".js" && ext != ".jsx" &&
+			ext != ".mjs" && ext != ".cjs" && ext != ".svelte" && ext != ".astro" && ext != ".go" {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil // skip unreadable files rather than failing the whole walk
+		}
+		content := string(data)
+		lines := countLines(content)
+
+		switch {
+		case ext == ".rs":
+			testLines := countRustTestLines(content)
+			if testDir {
+				stats.RustTest += lines
+			} else {
+				stats.RustProd += lines - testLines
+				stats.RustTest += testLines
+			}
+		case isTSTestName || (testDir && (ext == ".ts" || ext == ".tsx" || ext == ".js" || ext == ".jsx" || ext == ".mjs" || ext == ".cjs")):
+			stats.TSTest += lines
+		case ext == ".ts" || ext == ".tsx" || ext == ".js" || ext == ".jsx" || ext == ".mjs" || ext == ".cjs":
+			stats.TSProd += lines
+		case ext == ".svelte":
+			stats.Svelte += lines
+		case ext == ".astro":
+			stats.Astro += lines
+		case ext == ".go":
+			stats.Go += lines
+		}
+		return nil
+	})
+	if err != nil {
+		return Stats{}, err
+	}
+	return stats, nil
+}
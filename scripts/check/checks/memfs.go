@@ -0,0 +1,155 @@
+package checks
+
+import (
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MemFS is an in-memory SourceFS. Build a tree with AddFile and pass it as
+// CheckContext.FS to run a check against synthetic files instead of disk.
+type MemFS struct {
+	files map[string][]byte
+}
+
+// NewMemFS returns an empty MemFS.
+func NewMemFS() *MemFS {
+	return &MemFS{files: map[string][]byte{}}
+}
+
+// AddFile registers a file at name (forward-slash, relative to the FS root)
+// with the given contents, creating any parent directories implicitly.
+func (m *MemFS) AddFile(name, contents string) {
+	m.files[cleanMemPath(name)] = []byte(contents)
+}
+
+func cleanMemPath(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = strings.TrimPrefix(name, "./")
+	return strings.TrimPrefix(name, "/")
+}
+
+func (m *MemFS) Open(name string) (fs.File, error) {
+	name = cleanMemPath(name)
+	if name == "." || m.isDir(name) {
+		return &memDir{fs: m, name: name}, nil
+	}
+	if data, ok := m.files[name]; ok {
+		return &memFile{info: m.fileInfo(name, false, int64(len(data))), data: data}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	name = cleanMemPath(name)
+	if name == "." || m.isDir(name) {
+		return m.fileInfo(name, true, 0), nil
+	}
+	if data, ok := m.files[name]; ok {
+		return m.fileInfo(name, false, int64(len(data))), nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir implements fs.ReadDirFS, so fs.WalkDir can list a directory's
+// entries directly instead of opening it and casting to fs.ReadDirFile.
+func (m *MemFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	name = cleanMemPath(name)
+	prefix := ""
+	if name != "." {
+		prefix = name + "/"
+	}
+
+	children := map[string]fs.DirEntry{}
+	for f, data := range m.files {
+		if !strings.HasPrefix(f, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(f, prefix)
+		parts := strings.SplitN(rest, "/", 2)
+		child := parts[0]
+		if _, ok := children[child]; ok {
+			continue
+		}
+		if len(parts) == 1 {
+			children[child] = fs.FileInfoToDirEntry(m.fileInfo(path.Join(name, child), false, int64(len(data))))
+		} else {
+			children[child] = fs.FileInfoToDirEntry(m.fileInfo(path.Join(name, child), true, 0))
+		}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, entry := range children {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (m *MemFS) isDir(name string) bool {
+	prefix := name + "/"
+	for f := range m.files {
+		if strings.HasPrefix(f, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemFS) fileInfo(name string, isDir bool, size int64) memFileInfo {
+	base := path.Base(name)
+	mode := fs.FileMode(0o644)
+	if isDir {
+		mode = fs.ModeDir | 0o755
+	}
+	return memFileInfo{name: base, size: size, mode: mode, isDir: isDir}
+}
+
+type memFileInfo struct {
+	name  string
+	size  int64
+	mode  fs.FileMode
+	isDir bool
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return i.isDir }
+func (i memFileInfo) Sys() any           { return nil }
+
+type memFile struct {
+	info memFileInfo
+	data []byte
+	pos  int
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *memFile) Read(p []byte) (int, error) {
+	if f.pos >= len(f.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.data[f.pos:])
+	f.pos += n
+	return n, nil
+}
+
+func (f *memFile) Close() error { return nil }
+
+// memDir is the fs.File returned for directories; fs.WalkDir only ever
+// Stats it (ReadDir goes through MemFS.ReadDir directly).
+type memDir struct {
+	fs   *MemFS
+	name string
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.fs.fileInfo(d.name, true, 0), nil }
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: fs.ErrInvalid}
+}
+func (d *memDir) Close() error { return nil }
@@ -0,0 +1,86 @@
+package checks
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+)
+
+func TestMemFS_OpenAndReadFile(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("a/b.go", "hello")
+
+	f, err := fsys.Open("a/b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hello" {
+		t.Errorf("expected %q, got %q", "hello", data)
+	}
+}
+
+func TestMemFS_OpenMissingFile(t *testing.T) {
+	fsys := NewMemFS()
+	if _, err := fsys.Open("missing.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected ErrNotExist, got %v", err)
+	}
+}
+
+func TestMemFS_StatReportsDirsAndFiles(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("a/b.go", "hello")
+
+	dirInfo, err := fsys.Stat("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dirInfo.IsDir() {
+		t.Errorf("expected a to be a directory")
+	}
+
+	fileInfo, err := fsys.Stat("a/b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileInfo.IsDir() || fileInfo.Size() != 5 {
+		t.Errorf("expected a 5-byte file, got isDir=%v size=%d", fileInfo.IsDir(), fileInfo.Size())
+	}
+}
+
+func TestMemFS_WalkDirVisitsEveryFile(t *testing.T) {
+	fsys := NewMemFS()
+	fsys.AddFile("a.go", "1")
+	fsys.AddFile("sub/b.go", "2")
+	fsys.AddFile("sub/deeper/c.go", "3")
+
+	var paths []string
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]bool{"a.go": true, "sub/b.go": true, "sub/deeper/c.go": true}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %d files, got %v", len(want), paths)
+	}
+	for _, p := range paths {
+		if !want[p] {
+			t.Errorf("unexpected path %q", p)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package checks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Plan is the result of topologically layering a set of checks by
+// DependsOn: every check in Waves[0] can start immediately, and a check in
+// Waves[N] can start once everything in Waves[0..N-1] it depends on has
+// finished. Mirrors Runner's actual scheduling (see waitForDeps in
+// runner.go), which likewise ignores a DependsOn edge pointing outside the
+// set of checks actually being run.
+type Plan struct {
+	Waves [][]CheckDefinition
+}
+
+// BuildPlan selects checks from defs by ID or nickname (all of defs if
+// selected is empty), drops slow checks unless includeSlow, and layers
+// what's left into waves. defs is validated as a whole first — unknown
+// DependsOn IDs, dependency cycles, and nicknames used where DependsOn
+// expects an ID (see ValidateNoOrphanedNicknames) — so a planning error
+// always names the actual configuration problem rather than just "no wave
+// ever became ready".
+func BuildPlan(defs []CheckDefinition, selected []string, includeSlow bool) (*Plan, error) {
+	if err := ValidateCheckDependencies(defs); err != nil {
+		return nil, err
+	}
+	if err := ValidateNoOrphanedNicknames(defs); err != nil {
+		return nil, err
+	}
+
+	subset, err := selectByIDOrNickname(defs, selected)
+	if err != nil {
+		return nil, err
+	}
+	subset = FilterSlowChecks(subset, includeSlow)
+
+	return &Plan{Waves: layerWaves(subset)}, nil
+}
+
+// selectByIDOrNickname returns the defs named by selected (matching ID or
+// Nickname, in the order given), or all of defs if selected is empty.
+func selectByIDOrNickname(defs []CheckDefinition, selected []string) ([]CheckDefinition, error) {
+	if len(selected) == 0 {
+		return defs, nil
+	}
+
+	byName := make(map[string]CheckDefinition, len(defs))
+	for _, def := range defs {
+		byName[def.ID] = def
+		if def.Nickname != "" {
+			byName[def.Nickname] = def
+		}
+	}
+
+	result := make([]CheckDefinition, 0, len(selected))
+	for _, name := range selected {
+		def, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+		result = append(result, def)
+	}
+	return result, nil
+}
+
+// layerWaves groups defs into waves by DependsOn (Kahn's algorithm): a wave
+// is every not-yet-placed check whose DependsOn edges inside defs are all
+// already placed in an earlier wave. A DependsOn edge pointing outside defs
+// is ignored, matching Runner.waitForDeps — e.g. planning a single check
+// with --check doesn't pull its dependencies into the plan.
+func layerWaves(defs []CheckDefinition) [][]CheckDefinition {
+	if len(defs) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]CheckDefinition, len(defs))
+	remaining := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		byID[def.ID] = def
+		remaining[def.ID] = true
+	}
+
+	var waves [][]CheckDefinition
+	for len(remaining) > 0 {
+		var wave []CheckDefinition
+		for id := range remaining {
+			ready := true
+			for _, dep := range byID[id].DependsOn {
+				if remaining[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, byID[id])
+			}
+		}
+		if len(wave) == 0 {
+			// A cycle confined entirely within defs — can't happen via
+			// BuildPlan, since it validates the full superset as acyclic
+			// first, but don't spin forever if layerWaves is ever called
+			// directly on something that wasn't validated.
+			break
+		}
+		sort.Slice(wave, func(i, j int) bool { return wave[i].ID < wave[j].ID })
+		for _, def := range wave {
+			delete(remaining, def.ID)
+		}
+		waves = append(waves, wave)
+	}
+	return waves
+}
+
+// ValidateNoOrphanedNicknames reports an error if any CheckDefinition's
+// DependsOn names another check's Nickname instead of its ID. DependsOn
+// resolves strictly by ID (see ValidateCheckDependencies), so a nickname
+// there silently behaves like a reference to a nonexistent check; this
+// catches it and names the ID that was probably meant instead.
+func ValidateNoOrphanedNicknames(defs []CheckDefinition) error {
+	ids := make(map[string]bool, len(defs))
+	nicknameOwner := make(map[string]string, len(defs))
+	for _, def := range defs {
+		ids[def.ID] = true
+		if def.Nickname != "" {
+			nicknameOwner[def.Nickname] = def.ID
+		}
+	}
+
+	for _, def := range defs {
+		for _, dep := range def.DependsOn {
+			if ids[dep] {
+				continue
+			}
+			if ownerID, ok := nicknameOwner[dep]; ok {
+				return fmt.Errorf("check %q depends on %q, which is a nickname for %q — DependsOn must name check IDs, not nicknames", def.ID, dep, ownerID)
+			}
+		}
+	}
+	return nil
+}
+
+// PlanJSON is Plan's machine-readable form, e.g. for editor tooling: each
+// wave is a list of the CLI names (nickname if set, else ID) a user would
+// actually type to run that check.
+type PlanJSON struct {
+	Waves [][]string `json:"waves"`
+}
+
+// JSON converts p to its machine-readable form.
+func (p *Plan) JSON() PlanJSON {
+	out := PlanJSON{Waves: make([][]string, len(p.Waves))}
+	for i, wave := range p.Waves {
+		names := make([]string, len(wave))
+		for j, def := range wave {
+			names[j] = def.CLIName()
+		}
+		out.Waves[i] = names
+	}
+	return out
+}
+
+// Mermaid renders p as a Mermaid flowchart (one subgraph per wave), for
+// pasting into docs or a PR description.
+func (p *Plan) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	for i, wave := range p.Waves {
+		fmt.Fprintf(&b, "  subgraph wave%d[\"wave %d\"]\n", i+1, i+1)
+		for _, def := range wave {
+			fmt.Fprintf(&b, "    %s[%s]\n", mermaidID(def.ID), def.CLIName())
+		}
+		b.WriteString("  end\n")
+	}
+	for _, wave := range p.Waves {
+		for _, def := range wave {
+			for _, dep := range def.DependsOn {
+				fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(dep), mermaidID(def.ID))
+			}
+		}
+	}
+	return b.String()
+}
+
+// Graphviz renders p as a Graphviz "dot" digraph, clustering each wave.
+func (p *Plan) Graphviz() string {
+	var b strings.Builder
+	b.WriteString("digraph plan {\n")
+	for i, wave := range p.Waves {
+		fmt.Fprintf(&b, "  subgraph cluster_wave%d {\n    label = \"wave %d\";\n", i+1, i+1)
+		for _, def := range wave {
+			fmt.Fprintf(&b, "    %q;\n", def.ID)
+		}
+		b.WriteString("  }\n")
+	}
+	for _, wave := range p.Waves {
+		for _, def := range wave {
+			for _, dep := range def.DependsOn {
+				fmt.Fprintf(&b, "  %q -> %q;\n", dep, def.ID)
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// mermaidID replaces characters Mermaid node IDs can't contain (cmdr check
+// IDs use hyphens freely) with underscores.
+func mermaidID(id string) string {
+	return strings.ReplaceAll(id, "-", "_")
+}
@@ -0,0 +1,164 @@
+package checks
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func waveIDs(waves [][]CheckDefinition) [][]string {
+	out := make([][]string, len(waves))
+	for i, wave := range waves {
+		ids := make([]string, len(wave))
+		for j, def := range wave {
+			ids[j] = def.ID
+		}
+		out[i] = ids
+	}
+	return out
+}
+
+func TestBuildPlan_LayersByDependsOn(t *testing.T) {
+	defs := []CheckDefinition{
+		{ID: "a"},
+		{ID: "b", DependsOn: []string{"a"}},
+		{ID: "c", DependsOn: []string{"a"}},
+		{ID: "d", DependsOn: []string{"b", "c"}},
+	}
+
+	plan, err := BuildPlan(defs, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{{"a"}, {"b", "c"}, {"d"}}
+	if got := waveIDs(plan.Waves); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected waves %v, got %v", want, got)
+	}
+}
+
+func TestBuildPlan_SelectionIgnoresDepsOutsideSelection(t *testing.T) {
+	defs := []CheckDefinition{
+		{ID: "a"},
+		{ID: "b", DependsOn: []string{"a"}},
+	}
+
+	plan, err := BuildPlan(defs, []string{"b"}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [][]string{{"b"}}
+	if got := waveIDs(plan.Waves); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected b's unselected dependency to be ignored, got %v", got)
+	}
+}
+
+func TestBuildPlan_SelectsByNickname(t *testing.T) {
+	defs := []CheckDefinition{
+		{ID: "desktop-rust-rustfmt", Nickname: "rustfmt"},
+	}
+
+	plan, err := BuildPlan(defs, []string{"rustfmt"}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := waveIDs(plan.Waves); !reflect.DeepEqual(got, [][]string{{"desktop-rust-rustfmt"}}) {
+		t.Errorf("expected the nickname to resolve to its check, got %v", got)
+	}
+}
+
+func TestBuildPlan_UnknownSelectionErrors(t *testing.T) {
+	defs := []CheckDefinition{{ID: "a"}}
+
+	if _, err := BuildPlan(defs, []string{"nope"}, true); err == nil {
+		t.Fatal("expected an error for an unknown check name")
+	}
+}
+
+func TestBuildPlan_RespectsIncludeSlow(t *testing.T) {
+	defs := []CheckDefinition{
+		{ID: "a"},
+		{ID: "b", IsSlow: true},
+	}
+
+	plan, err := BuildPlan(defs, nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := waveIDs(plan.Waves); !reflect.DeepEqual(got, [][]string{{"a"}}) {
+		t.Errorf("expected the slow check excluded, got %v", got)
+	}
+}
+
+func TestBuildPlan_CycleErrors(t *testing.T) {
+	defs := []CheckDefinition{
+		{ID: "a", DependsOn: []string{"b"}},
+		{ID: "b", DependsOn: []string{"a"}},
+	}
+
+	if _, err := BuildPlan(defs, nil, true); err == nil {
+		t.Fatal("expected an error for a dependency cycle")
+	}
+}
+
+func TestBuildPlan_UnknownDependsOnIDErrors(t *testing.T) {
+	defs := []CheckDefinition{{ID: "a", DependsOn: []string{"missing"}}}
+
+	if _, err := BuildPlan(defs, nil, true); err == nil {
+		t.Fatal("expected an error for a DependsOn edge to an unknown check")
+	}
+}
+
+func TestValidateNoOrphanedNicknames_CatchesNicknameUsedAsDependsOn(t *testing.T) {
+	defs := []CheckDefinition{
+		{ID: "desktop-rust-rustfmt", Nickname: "rustfmt"},
+		{ID: "desktop-rust-clippy", DependsOn: []string{"rustfmt"}},
+	}
+
+	err := ValidateNoOrphanedNicknames(defs)
+	if err == nil {
+		t.Fatal("expected an error when DependsOn names a nickname instead of an ID")
+	}
+}
+
+func TestValidateNoOrphanedNicknames_PassesForIDBasedDependsOn(t *testing.T) {
+	defs := []CheckDefinition{
+		{ID: "desktop-rust-rustfmt", Nickname: "rustfmt"},
+		{ID: "desktop-rust-clippy", DependsOn: []string{"desktop-rust-rustfmt"}},
+	}
+
+	if err := ValidateNoOrphanedNicknames(defs); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestPlan_JSONUsesCLINames(t *testing.T) {
+	plan, err := BuildPlan([]CheckDefinition{
+		{ID: "desktop-rust-rustfmt", Nickname: "rustfmt"},
+	}, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := plan.JSON()
+	want := PlanJSON{Waves: [][]string{{"rustfmt"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestPlan_MermaidIncludesEveryCheckAndEdge(t *testing.T) {
+	plan, err := BuildPlan([]CheckDefinition{
+		{ID: "a"},
+		{ID: "b", DependsOn: []string{"a"}},
+	}, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := plan.Mermaid()
+	if !strings.Contains(out, "a[a]") || !strings.Contains(out, "b[b]") || !strings.Contains(out, "a --> b") {
+		t.Errorf("expected mermaid output to include both nodes and the edge, got:\n%s", out)
+	}
+}
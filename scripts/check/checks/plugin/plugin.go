@@ -0,0 +1,209 @@
+// Package plugin discovers user-supplied checks under .cmdr/plugins/ so a
+// team can add a repo-specific check (a Rust `cargo audit` wrapper, a perf
+// gate, whatever) by dropping a descriptor file next to a script, without
+// forking cmdr or recompiling it. This is deliberately a step further than
+// checks/registry_external.go's cmdr-checks.toml: that file can only
+// reference a CheckFunc the binary was already compiled with (via
+// RegisterRunner); a plugin supplies its own command and needs no Go code
+// at all.
+package plugin
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"vmail/scripts/check/checks"
+)
+
+// descriptorFileName is the file Discover looks for in each plugin
+// directory, following the Helm plugin.yaml convention this feature is
+// modeled on — except in TOML, matching cmdr.toml and
+// .cmdr/audit-suppressions.toml (see checks/suppressions.go) rather than
+// adding this repo's first YAML dependency for one file format.
+const descriptorFileName = "plugin.toml"
+
+// pluginsGlob finds every plugin directory under .cmdr/plugins/, mirroring
+// Helm's plugin.FindPlugins(dir).
+const pluginsGlob = ".cmdr/plugins/*/" + descriptorFileName
+
+// ParseKind is how Discover turns a plugin's stdout into a CheckResult.
+type ParseKind string
+
+const (
+	// ParseText treats the whole command as a pass/fail gate: any nonzero
+	// exit is a failure reporting the raw output, success just says so.
+	ParseText ParseKind = "text"
+	// ParsePlaywrightCount extracts a Playwright "N passed" summary via
+	// checks.ParsePlaywrightPassedCount.
+	ParsePlaywrightCount ParseKind = "playwright-count"
+	// ParseVitestCount extracts a vitest "Tests N passed" summary via
+	// checks.ParseVitestPassedCount.
+	ParseVitestCount ParseKind = "vitest-count"
+	// ParseESLint extracts an ESLint stylish-formatter problem summary via
+	// checks.ParseESLintProblemCounts.
+	ParseESLint ParseKind = "eslint"
+)
+
+// alwaysApp is the applies_to value meaning "not specific to one app",
+// mapped onto checks.AppOther since CheckDefinition only carries a single
+// App.
+const alwaysApp = "always"
+
+// descriptor is the on-disk shape of plugin.toml.
+type descriptor struct {
+	Name        string   `toml:"name"`
+	Description string   `toml:"description"`
+	Command     string   `toml:"command"`
+	Parse       string   `toml:"parse"`
+	AppliesTo   []string `toml:"applies_to"`
+}
+
+// Discover scans .cmdr/plugins/*/plugin.toml under rootDir and builds one
+// checks.CheckDefinition per valid descriptor. A missing .cmdr/plugins
+// directory is not an error — it's the common case, a repo with no
+// plugins. An invalid descriptor (missing name/command, or an unrecognized
+// parse kind) is reported as an error rather than silently skipped, since a
+// typo'd plugin.toml is much more likely than an intentionally-malformed
+// one a user would want ignored.
+func Discover(rootDir string) ([]checks.CheckDefinition, error) {
+	matches, err := filepath.Glob(filepath.Join(rootDir, pluginsGlob))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", pluginsGlob, err)
+	}
+	sort.Strings(matches)
+
+	var defs []checks.CheckDefinition
+	for _, path := range matches {
+		def, err := loadPlugin(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load plugin %s: %w", path, err)
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// loadPlugin parses one plugin.toml and builds its CheckDefinition.
+func loadPlugin(descriptorPath string) (checks.CheckDefinition, error) {
+	var d descriptor
+	if _, err := toml.DecodeFile(descriptorPath, &d); err != nil {
+		return checks.CheckDefinition{}, err
+	}
+	if d.Name == "" {
+		return checks.CheckDefinition{}, fmt.Errorf("missing required field \"name\"")
+	}
+	if d.Command == "" {
+		return checks.CheckDefinition{}, fmt.Errorf("missing required field \"command\"")
+	}
+	parser, err := parserFor(ParseKind(d.Parse))
+	if err != nil {
+		return checks.CheckDefinition{}, err
+	}
+
+	pluginDir := filepath.Dir(descriptorPath)
+	return checks.CheckDefinition{
+		ID:          "plugin-" + d.Name,
+		Nickname:    d.Name,
+		DisplayName: displayName(d),
+		App:         appFor(d.AppliesTo),
+		Tech:        "🔌 Plugin",
+		Run:         runPlugin(d, pluginDir, parser),
+	}, nil
+}
+
+func displayName(d descriptor) string {
+	if d.Description != "" {
+		return d.Description
+	}
+	return d.Name
+}
+
+// appFor maps a plugin's applies_to list onto the single App a
+// CheckDefinition carries: one app name tags the check as belonging to
+// that app; "always", no entry, or more than one app name (there's no
+// multi-app CheckDefinition to tag it with) all fall back to AppOther.
+func appFor(appliesTo []string) checks.App {
+	if len(appliesTo) == 1 && !strings.EqualFold(appliesTo[0], alwaysApp) {
+		return checks.App(appliesTo[0])
+	}
+	return checks.AppOther
+}
+
+// parserFor resolves a plugin.toml parse string to the function Discover
+// uses to turn a finished command's output into a CheckResult.
+func parserFor(kind ParseKind) (func(name, output string) checks.CheckResult, error) {
+	switch kind {
+	case ParseText, "":
+		return parseText, nil
+	case ParsePlaywrightCount:
+		return parsePlaywrightCount, nil
+	case ParseVitestCount:
+		return parseVitestCount, nil
+	case ParseESLint:
+		return parseESLint, nil
+	default:
+		return nil, fmt.Errorf("unrecognized parse kind %q (want text, playwright-count, vitest-count, or eslint)", kind)
+	}
+}
+
+// runPlugin builds the CheckFunc a plugin's CheckDefinition.Run is set to:
+// it shells out to d.Command (interpreted by sh -c, so a plugin can use
+// pipes/&&/etc. the way desktop-rust-tests-linux.go's cargo-test-on-Linux
+// setup command does) from pluginDir, and hands successful-exit stdout to
+// parse. A plugin command's nonzero exit always fails the check with its
+// raw output — parse only gets a chance to build a nicer success message.
+func runPlugin(d descriptor, pluginDir string, parse func(name, output string) checks.CheckResult) checks.CheckFunc {
+	return func(ctx *checks.CheckContext) (checks.CheckResult, error) {
+		cmd := exec.Command("sh", "-c", d.Command)
+		cmd.Dir = pluginDir
+		output, err := checks.RunCommand(cmd, true)
+		if err != nil {
+			return checks.CheckResult{}, fmt.Errorf("plugin %q failed\n%s", d.Name, indent(output))
+		}
+		return parse(d.Name, output), nil
+	}
+}
+
+func parseText(name, output string) checks.CheckResult {
+	return checks.Success(fmt.Sprintf("%s passed", name))
+}
+
+func parsePlaywrightCount(name, output string) checks.CheckResult {
+	count, ok := checks.ParsePlaywrightPassedCount(output)
+	if !ok {
+		return checks.Success(fmt.Sprintf("%s passed", name))
+	}
+	return checks.Success(fmt.Sprintf("%d %s passed", count, checks.Pluralize(count, "test", "tests")))
+}
+
+func parseVitestCount(name, output string) checks.CheckResult {
+	count, ok := checks.ParseVitestPassedCount(output)
+	if !ok {
+		return checks.Success(fmt.Sprintf("%s passed", name))
+	}
+	return checks.Success(fmt.Sprintf("%d %s passed", count, checks.Pluralize(count, "test", "tests")))
+}
+
+func parseESLint(name, output string) checks.CheckResult {
+	errorCount, warningCount, ok := checks.ParseESLintProblemCounts(output)
+	if !ok || (errorCount == 0 && warningCount == 0) {
+		return checks.Success(fmt.Sprintf("%s passed", name))
+	}
+	return checks.Success(fmt.Sprintf("%d %s, %d %s", errorCount, checks.Pluralize(errorCount, "error", "errors"), warningCount, checks.Pluralize(warningCount, "warning", "warnings")))
+}
+
+// indent prefixes every line of output with two spaces, matching the repo's
+// own indentOutput (unexported in package checks, so not reusable here
+// directly) for a command failure's raw output block.
+func indent(output string) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "  " + line
+	}
+	return strings.Join(lines, "\n")
+}
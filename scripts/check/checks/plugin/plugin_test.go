@@ -0,0 +1,140 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"vmail/scripts/check/checks"
+)
+
+func writePlugin(t *testing.T, rootDir, name, contents string) string {
+	t.Helper()
+	dir := filepath.Join(rootDir, ".cmdr", "plugins", name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, descriptorFileName), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDiscover_MissingPluginsDirReturnsNoError(t *testing.T) {
+	got, err := Discover(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("expected no plugins, got %+v", got)
+	}
+}
+
+func TestDiscover_BuildsOneCheckDefinitionPerPlugin(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "cargo-audit", `
+name = "cargo-audit"
+description = "Audit Rust dependencies"
+command = "echo ok"
+parse = "text"
+applies_to = ["desktop"]
+`)
+
+	got, err := Discover(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 plugin check, got %d: %+v", len(got), got)
+	}
+	def := got[0]
+	if def.ID != "plugin-cargo-audit" {
+		t.Errorf("expected ID plugin-cargo-audit, got %q", def.ID)
+	}
+	if def.DisplayName != "Audit Rust dependencies" {
+		t.Errorf("expected DisplayName from description, got %q", def.DisplayName)
+	}
+	if def.App != checks.App("desktop") {
+		t.Errorf("expected App desktop, got %q", def.App)
+	}
+}
+
+func TestDiscover_MultipleAppliesToFallsBackToAppOther(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "perf-gate", `
+name = "perf-gate"
+command = "echo ok"
+applies_to = ["desktop", "website"]
+`)
+
+	got, err := Discover(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0].App != checks.AppOther {
+		t.Errorf("expected AppOther for a multi-app plugin, got %q", got[0].App)
+	}
+}
+
+func TestDiscover_MissingNameErrors(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "broken", `
+command = "echo ok"
+`)
+
+	if _, err := Discover(root); err == nil {
+		t.Fatal("expected an error for a plugin with no name")
+	}
+}
+
+func TestDiscover_UnrecognizedParseKindErrors(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "broken", `
+name = "broken"
+command = "echo ok"
+parse = "xunit"
+`)
+
+	if _, err := Discover(root); err == nil {
+		t.Fatal("expected an error for an unrecognized parse kind")
+	}
+}
+
+func TestDiscover_RunSucceedsAndParsesPlaywrightCount(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "e2e-extra", `
+name = "e2e-extra"
+command = "echo '3 passed'"
+parse = "playwright-count"
+`)
+
+	got, err := Discover(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := got[0].Run(&checks.CheckContext{RootDir: root})
+	if err != nil {
+		t.Fatalf("unexpected error running plugin: %v", err)
+	}
+	if result.Message != "3 tests passed" {
+		t.Errorf("expected parsed count in message, got %q", result.Message)
+	}
+}
+
+func TestDiscover_RunFailsOnNonzeroExit(t *testing.T) {
+	root := t.TempDir()
+	writePlugin(t, root, "always-fails", `
+name = "always-fails"
+command = "echo boom 1>&2; exit 1"
+`)
+
+	got, err := Discover(root)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := got[0].Run(&checks.CheckContext{RootDir: root}); err == nil {
+		t.Fatal("expected an error for a plugin command that exits nonzero")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 )
 
 // AuditResult represents the JSON output from pnpm audit.
@@ -60,25 +61,36 @@ func RunPnpmAudit(ctx *CheckContext) (CheckResult, error) {
 		return CheckResult{}, fmt.Errorf("failed to parse pnpm audit output: %w\n%s", err, output)
 	}
 
-	total := result.Metadata.Vulnerabilities.Critical +
-		result.Metadata.Vulnerabilities.High +
-		result.Metadata.Vulnerabilities.Moderate +
-		result.Metadata.Vulnerabilities.Low +
-		result.Metadata.Vulnerabilities.Info
+	suppressions, err := LoadSuppressions(ctx.RootDir)
+	if err != nil {
+		return CheckResult{}, err
+	}
+	advisories, suppressedCount, err := applySuppressions(result.Advisories, suppressions, time.Now())
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	// Group advisories by severity for better output
+	bySeverity := groupBySeverity(advisories)
+	counts, total := countsBySeverity(bySeverity)
 
 	if total == 0 {
 		deps := result.Metadata.Dependencies
-		return Success(fmt.Sprintf("%d production %s checked", deps, Pluralize(deps, "dep", "deps"))), nil
+		msg := fmt.Sprintf("%d production %s checked", deps, Pluralize(deps, "dep", "deps"))
+		if suppressedCount > 0 {
+			msg += fmt.Sprintf(", %d suppressed", suppressedCount)
+		}
+		return Success(msg), nil
 	}
 
 	// Build concise summary
 	var sb strings.Builder
-	sb.WriteString(formatVulnSummary(result.Metadata.Vulnerabilities))
+	sb.WriteString(formatVulnSummary(counts))
+	if suppressedCount > 0 {
+		sb.WriteString(fmt.Sprintf(" (%d suppressed)", suppressedCount))
+	}
 	sb.WriteString("\n")
 
-	// Group advisories by severity for better output
-	bySeverity := groupBySeverity(result.Advisories)
-
 	// Output in severity order: critical, high, moderate, low, info
 	for _, severity := range []string{"critical", "high", "moderate", "low", "info"} {
 		advisories := bySeverity[severity]
@@ -109,12 +121,55 @@ func RunPnpmAudit(ctx *CheckContext) (CheckResult, error) {
 		}
 	}
 
-	return CheckResult{}, fmt.Errorf("found %d production %s\n%s",
+	return CheckResult{Diagnostics: pnpmAuditDiagnostics(bySeverity)}, fmt.Errorf("found %d production %s\n%s",
 		total,
 		Pluralize(total, "vulnerability", "vulnerabilities"),
 		sb.String())
 }
 
+// pnpmAuditDiagnostics converts grouped advisories into Diagnostics, one per
+// advisory, so --format=sarif/--format=github can report production
+// vulnerabilities the same way RunGovulncheck's findings are reported.
+// Rule is "pnpm-audit/<severity>" (e.g. "pnpm-audit/critical") rather than a
+// single "pnpm-audit" rule, so GitHub code scanning and the SARIF rules
+// array distinguish critical findings from low ones instead of lumping them
+// under one reportingDescriptor. Advisories aren't tied to a line in a
+// source file - pnpm audit reports against the dependency graph - so File is
+// the workspace's package.json, the artifact pnpm actually resolves
+// against, and the shortest dependency chain is folded into Message.
+func pnpmAuditDiagnostics(bySeverity map[string][]Advisory) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, severity := range []string{"critical", "high", "moderate", "low", "info"} {
+		for _, adv := range bySeverity[severity] {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     "package.json",
+				Rule:     fmt.Sprintf("pnpm-audit/%s", severity),
+				Message:  fmt.Sprintf("%s: %s (%s)", adv.ModuleName, adv.Title, findShortestPath(adv)),
+				Severity: severity,
+				Tool:     "pnpm-audit",
+				HelpURI:  adv.URL,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// countsBySeverity tallies a grouped advisory map into the same shape
+// AuditMetadata.Vulnerabilities uses, so formatVulnSummary can report on
+// advisories that remain after suppression rather than on everything pnpm
+// originally found.
+func countsBySeverity(bySeverity map[string][]Advisory) (VulnerabilityCounts, int) {
+	counts := VulnerabilityCounts{
+		Critical: len(bySeverity["critical"]),
+		High:     len(bySeverity["high"]),
+		Moderate: len(bySeverity["moderate"]),
+		Low:      len(bySeverity["low"]),
+		Info:     len(bySeverity["info"]),
+	}
+	total := counts.Critical + counts.High + counts.Moderate + counts.Low + counts.Info
+	return counts, total
+}
+
 func formatVulnSummary(v VulnerabilityCounts) string {
 	var parts []string
 	if v.Critical > 0 {
@@ -0,0 +1,47 @@
+package checks
+
+import "testing"
+
+func TestPnpmAuditDiagnostics_RuleIsPrefixedBySeverity(t *testing.T) {
+	advisories := map[string]Advisory{
+		"1": {ModuleName: "left-pad", Severity: "critical", Title: "Prototype pollution",
+			Findings: []Finding{{Paths: []string{"myapp>left-pad"}}}},
+		"2": {ModuleName: "minimist", Severity: "low", Title: "Prototype pollution",
+			Findings: []Finding{{Paths: []string{"myapp>glob>minimist"}}}},
+	}
+
+	got := pnpmAuditDiagnostics(groupBySeverity(advisories))
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %+v", len(got), got)
+	}
+	if got[0].Rule != "pnpm-audit/critical" {
+		t.Errorf("expected critical advisory first with rule pnpm-audit/critical, got %q", got[0].Rule)
+	}
+	if got[1].Rule != "pnpm-audit/low" {
+		t.Errorf("expected low advisory second with rule pnpm-audit/low, got %q", got[1].Rule)
+	}
+}
+
+func TestPnpmAuditDiagnostics_MessageIncludesShortestDependencyPath(t *testing.T) {
+	advisories := map[string]Advisory{
+		"1": {ModuleName: "minimist", Severity: "high", Title: "Prototype pollution",
+			Findings: []Finding{
+				{Paths: []string{"myapp>a>b>minimist"}},
+				{Paths: []string{"myapp>minimist"}},
+			}},
+	}
+
+	got := pnpmAuditDiagnostics(groupBySeverity(advisories))
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	if got[0].File != "package.json" {
+		t.Errorf("expected File to be package.json, got %q", got[0].File)
+	}
+	want := "minimist: Prototype pollution (myapp>minimist)"
+	if got[0].Message != want {
+		t.Errorf("expected message %q, got %q", want, got[0].Message)
+	}
+}
@@ -1,6 +1,9 @@
 package checks
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // AllChecks contains all check definitions with their metadata.
 // Dependencies define which checks must complete before this one runs.
@@ -13,6 +16,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppDesktop,
 		Tech:        "🦀 Rust",
 		DependsOn:   nil,
+		Inputs:      rustSourceGlobs,
 		Run:         RunRustfmt,
 	},
 	{
@@ -22,6 +26,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppDesktop,
 		Tech:        "🦀 Rust",
 		DependsOn:   []string{"desktop-rust-rustfmt"},
+		Inputs:      []string{"apps/desktop/src-tauri/**/*.rs", "apps/desktop/src-tauri/**/Cargo.toml"},
 		Run:         RunClippy,
 	},
 	{
@@ -31,6 +36,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppDesktop,
 		Tech:        "🦀 Rust",
 		DependsOn:   nil,
+		Resources:   []string{ResourceDesktopRustTarget},
 		Run:         RunCargoAudit,
 	},
 	{
@@ -67,7 +73,11 @@ var AllChecks = []CheckDefinition{
 		App:         AppDesktop,
 		Tech:        "🦀 Rust",
 		DependsOn:   nil,
-		Run:         RunCfgGate,
+		// Cargo.toml is included because discoverWorkspaceCrates (see
+		// desktop-rust-cfg-gate.go) reads each crate's [workspace] members
+		// and path dependencies to decide which directories to scan.
+		Inputs: []string{"apps/desktop/src-tauri/**/*.rs", "apps/desktop/src-tauri/**/Cargo.toml"},
+		Run:    RunCfgGate,
 	},
 	{
 		ID:          "desktop-rust-tests",
@@ -86,6 +96,7 @@ var AllChecks = []CheckDefinition{
 		Tech:        "🦀 Rust",
 		IsSlow:      true,
 		DependsOn:   []string{"desktop-rust-clippy"},
+		Resources:   []string{ResourceDesktopRustTarget},
 		Run:         RunRustTestsLinux,
 	},
 
@@ -104,6 +115,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppDesktop,
 		Tech:        "🎨 Svelte",
 		DependsOn:   []string{"desktop-svelte-prettier"},
+		Inputs:      desktopSvelteSourceGlobs,
 		Run:         RunDesktopESLint,
 	},
 	{
@@ -113,6 +125,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppDesktop,
 		Tech:        "🎨 Svelte",
 		DependsOn:   []string{"desktop-svelte-prettier"},
+		Inputs:      []string{"apps/desktop/src/**/*.css"},
 		Run:         RunStylelint,
 	},
 	{
@@ -122,6 +135,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppDesktop,
 		Tech:        "🎨 Svelte",
 		DependsOn:   []string{"desktop-svelte-stylelint"},
+		Inputs:      []string{"apps/desktop/src/**/*.css", "apps/desktop/src/**/*.svelte"},
 		Run:         RunCSSUnused,
 	},
 	{
@@ -184,6 +198,7 @@ var AllChecks = []CheckDefinition{
 		DisplayName: "e2e (Linux)",
 		App:         AppDesktop,
 		Tech:        "🎨 Svelte",
+		IsSlow:      true,
 		DependsOn:   []string{"desktop-svelte-e2e-linux-typecheck"},
 		Run:         RunDesktopE2ELinux,
 	},
@@ -273,6 +288,24 @@ var AllChecks = []CheckDefinition{
 		DependsOn:   nil,
 		Run:         RunPnpmAudit,
 	},
+	{
+		ID:          "coverage",
+		DisplayName: "coverage",
+		App:         AppOther,
+		Tech:        "📊 Metrics",
+		IsSlow:      true,
+		DependsOn:   []string{"desktop-svelte-tests"},
+		Resources:   []string{ResourceDesktopRustTarget},
+		Run:         RunCoverage,
+	},
+	{
+		ID:          "loc-report",
+		DisplayName: "loc report",
+		App:         AppOther,
+		Tech:        "📊 Metrics",
+		Run:         RunLocReport,
+		Update:      UpdateLocReport,
+	},
 
 	// Scripts - Go checks
 	{
@@ -300,6 +333,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppScripts,
 		Tech:        "🐹 Go",
 		DependsOn:   []string{"scripts-go-gofmt"},
+		Inputs:      goSourceGlobs,
 		Run:         RunStaticcheck,
 	},
 	{
@@ -309,6 +343,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppScripts,
 		Tech:        "🐹 Go",
 		DependsOn:   []string{"scripts-go-gofmt"},
+		Inputs:      goSourceGlobs,
 		Run:         RunIneffassign,
 	},
 	{
@@ -318,8 +353,29 @@ var AllChecks = []CheckDefinition{
 		App:         AppScripts,
 		Tech:        "🐹 Go",
 		DependsOn:   nil,
+		Inputs:      goSourceGlobs,
 		Run:         RunMisspell,
 	},
+	{
+		ID:          "scripts-go-errcheck",
+		Nickname:    "errcheck",
+		DisplayName: "errcheck",
+		App:         AppScripts,
+		Tech:        "🐹 Go",
+		DependsOn:   []string{"scripts-go-gofmt"},
+		Inputs:      goSourceGlobs,
+		Run:         RunErrcheck,
+	},
+	{
+		ID:          "scripts-go-gosec",
+		Nickname:    "gosec",
+		DisplayName: "gosec",
+		App:         AppScripts,
+		Tech:        "🐹 Go",
+		DependsOn:   []string{"scripts-go-gofmt"},
+		Inputs:      goSourceGlobs,
+		Run:         RunGosec,
+	},
 	{
 		ID:          "scripts-go-gocyclo",
 		Nickname:    "gocyclo",
@@ -327,6 +383,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppScripts,
 		Tech:        "🐹 Go",
 		DependsOn:   []string{"scripts-go-gofmt"},
+		Inputs:      goSourceGlobs,
 		Run:         RunGocyclo,
 	},
 	{
@@ -336,6 +393,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppScripts,
 		Tech:        "🐹 Go",
 		DependsOn:   []string{"scripts-go-vet"},
+		Inputs:      goSourceGlobs,
 		Run:         RunNilaway,
 	},
 	{
@@ -354,6 +412,7 @@ var AllChecks = []CheckDefinition{
 		App:         AppScripts,
 		Tech:        "🐹 Go",
 		DependsOn:   []string{"scripts-go-vet"},
+		Inputs:      goSourceGlobs,
 		Run:         RunDeadcode,
 	},
 	{
@@ -363,8 +422,20 @@ var AllChecks = []CheckDefinition{
 		App:         AppScripts,
 		Tech:        "🐹 Go",
 		DependsOn:   []string{"scripts-go-vet"},
+		Inputs:      goTestInputGlobs,
 		Run:         RunGoTests,
 	},
+	{
+		ID:          "scripts-go-coverage",
+		Nickname:    "go-coverage",
+		DisplayName: "coverage",
+		App:         AppScripts,
+		Tech:        "🐹 Go",
+		DependsOn:   []string{"scripts-go-tests"},
+		Inputs:      goTestInputGlobs,
+		IsSlow:      true,
+		Run:         RunGoCoverage,
+	},
 
 	// Monorepo-wide metrics (informational, never fails)
 	{
@@ -377,11 +448,13 @@ var AllChecks = []CheckDefinition{
 	},
 }
 
-// GetCheckByID returns a check definition by its ID or nickname.
+// GetCheckByID returns a check definition by its ID or nickname, consulting
+// ActiveChecks() so a check LoadRegistry added or overrode is found too.
 func GetCheckByID(id string) *CheckDefinition {
-	for i := range AllChecks {
-		if AllChecks[i].ID == id || AllChecks[i].Nickname == id {
-			return &AllChecks[i]
+	active := ActiveChecks()
+	for i := range active {
+		if active[i].ID == id || active[i].Nickname == id {
+			return &active[i]
 		}
 	}
 	return nil
@@ -397,10 +470,11 @@ func (c *CheckDefinition) CLIName() string {
 
 // ValidateCheckNames checks for duplicate IDs/nicknames and returns an error if any are found.
 // This should be called at startup to catch configuration mistakes early.
+// Consults ActiveChecks(), so a LoadRegistry-added check is validated too.
 func ValidateCheckNames() error {
 	seen := make(map[string]string) // maps name -> check ID that owns it
 
-	for _, check := range AllChecks {
+	for _, check := range ActiveChecks() {
 		// Check the ID
 		if ownerID, exists := seen[check.ID]; exists {
 			return fmt.Errorf("duplicate check name '%s': used by both '%s' and '%s'", check.ID, ownerID, check.ID)
@@ -418,10 +492,66 @@ func ValidateCheckNames() error {
 	return nil
 }
 
-// GetChecksByApp returns all checks for a specific app.
+// ValidateCheckDependencies checks that every CheckDefinition.DependsOn edge
+// points at a known check ID and that the resulting graph is acyclic, so a
+// misconfigured dependency (e.g. two checks depending on each other) is
+// caught at startup instead of deadlocking the runner, which otherwise
+// blocks forever waiting for a "done" channel that never closes.
+func ValidateCheckDependencies(defs []CheckDefinition) error {
+	byID := make(map[string]CheckDefinition, len(defs))
+	for _, def := range defs {
+		byID[def.ID] = def
+	}
+	for _, def := range defs {
+		for _, depID := range def.DependsOn {
+			if _, ok := byID[depID]; !ok {
+				return fmt.Errorf("check %q depends on unknown check %q", def.ID, depID)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(defs))
+	var path []string
+
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			path = append(path, id)
+			return fmt.Errorf("dependency cycle: %s", strings.Join(path, " -> "))
+		}
+		state[id] = visiting
+		path = append(path, id)
+		for _, depID := range byID[id].DependsOn {
+			if err := visit(depID); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+		return nil
+	}
+
+	for _, def := range defs {
+		if err := visit(def.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetChecksByApp returns all checks for a specific app, consulting
+// ActiveChecks() so a LoadRegistry-added or rescoped check is included.
 func GetChecksByApp(app App) []CheckDefinition {
 	var result []CheckDefinition
-	for _, check := range AllChecks {
+	for _, check := range ActiveChecks() {
 		if check.App == app {
 			result = append(result, check)
 		}
@@ -429,10 +559,12 @@ func GetChecksByApp(app App) []CheckDefinition {
 	return result
 }
 
-// GetChecksByTech returns all checks for a specific tech within an app.
+// GetChecksByTech returns all checks for a specific tech within an app,
+// consulting ActiveChecks() so a LoadRegistry-added or rescoped check is
+// included.
 func GetChecksByTech(app App, tech string) []CheckDefinition {
 	var result []CheckDefinition
-	for _, check := range AllChecks {
+	for _, check := range ActiveChecks() {
 		if check.App == app && check.Tech == tech {
 			result = append(result, check)
 		}
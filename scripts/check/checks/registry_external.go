@@ -0,0 +1,189 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/BurntSushi/toml"
+)
+
+// runnerRegistry holds the CheckFuncs an external registry file's entries
+// can name in their runner field. An external file can only reference Go
+// code by name, never supply it, so a fork adding its own check
+// implementation calls RegisterRunner (typically from an init() alongside
+// the new file) before LoadRegistry runs.
+var runnerRegistry = map[string]CheckFunc{}
+
+// RegisterRunner makes fn available to an external registry file as the
+// named runner, for a [checks.<id>] entry that defines a brand-new check
+// rather than overriding a built-in one.
+func RegisterRunner(name string, fn CheckFunc) {
+	runnerRegistry[name] = fn
+}
+
+// registryOverride is one [checks.<id>] table in an external registry file.
+// Every field is optional; a zero field leaves the corresponding built-in
+// CheckDefinition field untouched. An id with no matching built-in defines a
+// brand-new check instead, for which Runner is required.
+type registryOverride struct {
+	Runner      string
+	App         string
+	Tech        string
+	Nickname    string
+	DisplayName string
+	DependsOn   []string
+	Inputs      []string
+	Slow        *bool
+	Disabled    bool
+}
+
+// registryFile is the shape of an external registry file (see LoadRegistry):
+// one [checks.<id>] table per overridden or added check.
+type registryFile struct {
+	Checks map[string]registryOverride
+}
+
+// activeChecks is the result of the last LoadRegistry call; nil means
+// LoadRegistry hasn't run (or found no registry file), so ActiveChecks
+// falls back to AllChecks.
+var activeChecks []CheckDefinition
+
+// ActiveChecks returns the checks LoadRegistry produced, or AllChecks if
+// LoadRegistry hasn't been called yet — the set GetCheckByID,
+// GetChecksByApp, GetChecksByTech, and ValidateCheckNames all consult.
+func ActiveChecks() []CheckDefinition {
+	if activeChecks != nil {
+		return activeChecks
+	}
+	return AllChecks
+}
+
+// AppendActiveChecks adds defs to ActiveChecks' result, for a source of
+// checks that (unlike LoadRegistry's cmdr-checks.toml) doesn't override or
+// extend a single builtins slice but discovers its own entries separately —
+// see checks/plugin.Discover, called from main.go after LoadRegistry. Must
+// be called after LoadRegistry (or not at all, in which case it starts from
+// AllChecks) so it doesn't get overwritten by a later LoadRegistry call.
+func AppendActiveChecks(defs []CheckDefinition) {
+	activeChecks = append(ActiveChecks(), defs...)
+}
+
+// LoadRegistry reads an external registry file (by convention,
+// cmdr-checks.toml at the repo root, kept separate from cmdr.toml's
+// [checks] disabled list to avoid the two colliding) and merges it over
+// builtins with ApplyRegistry, so a fork can add a new linter, retarget
+// DependsOn, toggle IsSlow, disable a check by ID, or rescope one to a
+// single app without forking this package. A missing file is not an error;
+// it leaves builtins unchanged. Either way, the result becomes what
+// ActiveChecks returns.
+func LoadRegistry(path string, builtins []CheckDefinition) ([]CheckDefinition, error) {
+	var file registryFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		if os.IsNotExist(err) {
+			activeChecks = builtins
+			return builtins, nil
+		}
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	merged, err := ApplyRegistry(builtins, file.Checks)
+	if err != nil {
+		return nil, err
+	}
+	activeChecks = merged
+	return merged, nil
+}
+
+// ApplyRegistry merges overrides (keyed by check ID) over builtins: an
+// entry matching a built-in ID patches just the fields it sets; an
+// unmatched ID defines a new check, with Runner naming a CheckFunc
+// registered via RegisterRunner. A Disabled entry sets CheckDefinition.Disabled
+// rather than dropping the check, so it remains a valid DependsOn target
+// for ValidateCheckDependencies. Overrides are applied in ID order so a bad
+// entry's error message doesn't depend on map iteration order.
+func ApplyRegistry(builtins []CheckDefinition, overrides map[string]registryOverride) ([]CheckDefinition, error) {
+	merged := make([]CheckDefinition, len(builtins))
+	copy(merged, builtins)
+	byID := make(map[string]int, len(merged))
+	for i, def := range merged {
+		byID[def.ID] = i
+	}
+
+	ids := make([]string, 0, len(overrides))
+	for id := range overrides {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		override := overrides[id]
+		if i, ok := byID[id]; ok {
+			applyCheckOverride(&merged[i], override)
+			continue
+		}
+		def, err := newRegistryCheck(id, override)
+		if err != nil {
+			return nil, err
+		}
+		byID[id] = len(merged)
+		merged = append(merged, def)
+	}
+	return merged, nil
+}
+
+// applyCheckOverride patches the fields override sets onto an existing
+// built-in def, leaving every unset field as-is.
+func applyCheckOverride(def *CheckDefinition, override registryOverride) {
+	if override.DependsOn != nil {
+		def.DependsOn = override.DependsOn
+	}
+	if override.Inputs != nil {
+		def.Inputs = override.Inputs
+	}
+	if override.Slow != nil {
+		def.IsSlow = *override.Slow
+	}
+	if override.App != "" {
+		def.App = App(override.App)
+	}
+	if override.Tech != "" {
+		def.Tech = override.Tech
+	}
+	if override.Nickname != "" {
+		def.Nickname = override.Nickname
+	}
+	if override.DisplayName != "" {
+		def.DisplayName = override.DisplayName
+	}
+	if override.Disabled {
+		def.Disabled = true
+	}
+}
+
+// newRegistryCheck builds a CheckDefinition for a [checks.<id>] entry with
+// no matching built-in, looking up override.Runner in runnerRegistry.
+func newRegistryCheck(id string, override registryOverride) (CheckDefinition, error) {
+	if override.Runner == "" {
+		return CheckDefinition{}, fmt.Errorf("registry check %q doesn't match a built-in check and has no runner set", id)
+	}
+	run, ok := runnerRegistry[override.Runner]
+	if !ok {
+		return CheckDefinition{}, fmt.Errorf("registry check %q names unknown runner %q (call RegisterRunner first)", id, override.Runner)
+	}
+	def := CheckDefinition{
+		ID:          id,
+		Nickname:    override.Nickname,
+		DisplayName: override.DisplayName,
+		App:         App(override.App),
+		Tech:        override.Tech,
+		DependsOn:   override.DependsOn,
+		Inputs:      override.Inputs,
+		Run:         run,
+		Disabled:    override.Disabled,
+	}
+	if override.Slow != nil {
+		def.IsSlow = *override.Slow
+	}
+	return def, nil
+}
@@ -0,0 +1,81 @@
+package checks
+
+import "testing"
+
+func TestApplyRegistry_OverridesBuiltinFields(t *testing.T) {
+	builtins := []CheckDefinition{
+		{ID: "a", DependsOn: nil, IsSlow: false, App: AppScripts},
+	}
+	slow := true
+	merged, err := ApplyRegistry(builtins, map[string]registryOverride{
+		"a": {DependsOn: []string{"b"}, Slow: &slow, App: "desktop"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged) != 1 {
+		t.Fatalf("expected 1 check, got %d", len(merged))
+	}
+	if got := merged[0].DependsOn; len(got) != 1 || got[0] != "b" {
+		t.Errorf("expected DependsOn [b], got %v", got)
+	}
+	if !merged[0].IsSlow {
+		t.Error("expected IsSlow to be overridden to true")
+	}
+	if merged[0].App != AppDesktop {
+		t.Errorf("expected App to be overridden to %q, got %q", AppDesktop, merged[0].App)
+	}
+}
+
+func TestApplyRegistry_DisabledKeepsCheckForDependencyValidation(t *testing.T) {
+	builtins := []CheckDefinition{{ID: "a"}}
+	merged, err := ApplyRegistry(builtins, map[string]registryOverride{
+		"a": {Disabled: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged) != 1 {
+		t.Fatalf("expected the disabled check to remain in the merged set, got %v", merged)
+	}
+	if !merged[0].Disabled {
+		t.Error("expected Disabled to be set")
+	}
+}
+
+func TestApplyRegistry_AddsNewCheckViaRegisteredRunner(t *testing.T) {
+	RegisterRunner("test-registry-runner", func(ctx *CheckContext) (CheckResult, error) {
+		return Success("ok"), nil
+	})
+
+	merged, err := ApplyRegistry(nil, map[string]registryOverride{
+		"custom-check": {Runner: "test-registry-runner", App: "scripts", DisplayName: "custom"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(merged) != 1 || merged[0].ID != "custom-check" {
+		t.Fatalf("expected one new check named custom-check, got %v", merged)
+	}
+	if merged[0].Run == nil {
+		t.Error("expected Run to be set from the registered runner")
+	}
+}
+
+func TestApplyRegistry_UnknownRunnerErrors(t *testing.T) {
+	_, err := ApplyRegistry(nil, map[string]registryOverride{
+		"custom-check": {Runner: "does-not-exist"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unregistered runner name")
+	}
+}
+
+func TestApplyRegistry_NewCheckWithNoRunnerErrors(t *testing.T) {
+	_, err := ApplyRegistry(nil, map[string]registryOverride{
+		"custom-check": {App: "scripts"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a new check with no runner")
+	}
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+const fileViewTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Path}} — cmdr serve</title>
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2rem; background: #111; color: #ddd; }
+h1 { font-size: 1rem; font-weight: 600; font-family: monospace; }
+.summary { color: #888; margin-bottom: 1rem; }
+pre { font-size: 0.85rem; }
+.line { display: flex; }
+.line-number { color: #666; width: 4rem; text-align: right; padding-right: 1rem; user-select: none; flex-shrink: 0; }
+.line-text { white-space: pre-wrap; }
+a { color: #6cb4ff; }
+</style>
+</head>
+<body>
+<p><a href="/">&larr; back to dashboard</a></p>
+<h1>{{.Path}}</h1>
+<p class="summary">{{.Summary}}</p>
+<pre>{{range .Lines}}<div class="line"><span class="line-number">{{.Number}}</span><span class="line-text">{{.Text}}</span></div>
+{{end}}</pre>
+</body>
+</html>
+`
+
+var fileView = template.Must(template.New("fileview").Parse(fileViewTemplate))
+
+// fileViewLine is one rendered line of a file view.
+type fileViewLine struct {
+	Number int
+	Text   string
+}
+
+// fileViewData is what fileViewTemplate renders.
+type fileViewData struct {
+	Path    string
+	Summary string
+	Lines   []fileViewLine
+}
+
+// RenderFileContent renders a line-numbered plain-text view of a file's
+// contents, headed by the same (lines, kB, tokens) summary RunFileLength
+// reports — this is intentionally a plain viewer, not real syntax
+// highlighting; cmdr has no language-aware renderer to draw on yet.
+func RenderFileContent(relPath, content string, sizeBytes int64, tokenSummary string) (string, error) {
+	rawLines := strings.Split(content, "\n")
+	lines := make([]fileViewLine, len(rawLines))
+	for i, line := range rawLines {
+		lines[i] = fileViewLine{Number: i + 1, Text: line}
+	}
+
+	data := fileViewData{
+		Path:    relPath,
+		Summary: fmt.Sprintf("%d lines, %d kB, %s", len(rawLines), sizeBytes/1000, tokenSummary),
+		Lines:   lines,
+	}
+
+	var sb strings.Builder
+	if err := fileView.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render file view: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,130 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"vmail/scripts/check/checks"
+)
+
+// CheckView is one row of the dashboard: a check's definition alongside its
+// most recent result. Err is set instead of Result when the check failed
+// outright (mirrors checkOutcome in scripts/check/runner.go).
+type CheckView struct {
+	Definition checks.CheckDefinition
+	Result     checks.CheckResult
+	Err        error
+	Duration   time.Duration
+}
+
+// statusClass returns the CSS class and label for a view's status badge.
+func (v CheckView) statusClass() (string, string) {
+	switch {
+	case v.Err != nil:
+		return "status-fail", "FAIL"
+	case v.Result.Code == checks.ResultSkipped:
+		return "status-skip", "SKIP"
+	case v.Result.Code == checks.ResultWarning:
+		return "status-warn", "WARN"
+	default:
+		return "status-pass", "PASS"
+	}
+}
+
+func (v CheckView) messageHTML() template.HTML {
+	message := v.Result.Message
+	if v.Err != nil {
+		message = v.Err.Error()
+	}
+	return template.HTML(RenderMessage(message, v.Definition.ID == "file-length"))
+}
+
+const pageTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>cmdr serve</title>
+<style>
+body { font-family: -apple-system, sans-serif; margin: 2rem; background: #111; color: #ddd; }
+h1 { font-weight: 600; }
+table { border-collapse: collapse; width: 100%; }
+td, th { padding: 0.4rem 0.8rem; text-align: left; vertical-align: top; border-bottom: 1px solid #333; }
+.status-pass { color: #4caf50; }
+.status-warn { color: #e0c341; }
+.status-fail { color: #e05555; }
+.status-skip { color: #888; }
+pre { white-space: pre-wrap; margin: 0; font-size: 0.85rem; }
+.ansi-yellow { color: #e0c341; }
+.ansi-red { color: #e05555; }
+a { color: #6cb4ff; }
+</style>
+</head>
+<body>
+<h1>🔍 cmdr serve</h1>
+<p id="status">live — {{len .}} checks</p>
+<table>
+<thead><tr><th>Check</th><th>Status</th><th>Duration</th><th>Message</th></tr></thead>
+<tbody id="results">
+{{range .}}<tr id="check-{{.Definition.ID}}">
+<td>{{.Definition.DisplayName}}</td>
+<td class="{{.StatusClass}}">{{.StatusLabel}}</td>
+<td>{{.DurationText}}</td>
+<td><pre>{{.MessageHTML}}</pre></td>
+</tr>
+{{end}}
+</tbody>
+</table>
+<script>
+const events = new EventSource("/events");
+events.onmessage = (e) => location.reload();
+</script>
+</body>
+</html>
+`
+
+// renderedView adds the template-friendly accessors pageTemplate uses, since
+// html/template can't call a method that returns more than one value.
+type renderedView struct {
+	CheckView
+}
+
+func (v renderedView) StatusClass() string {
+	class, _ := v.statusClass()
+	return class
+}
+
+func (v renderedView) StatusLabel() string {
+	_, label := v.statusClass()
+	return label
+}
+
+func (v renderedView) DurationText() string {
+	if v.Duration <= 0 {
+		return ""
+	}
+	return v.Duration.Round(time.Millisecond).String()
+}
+
+func (v renderedView) MessageHTML() template.HTML {
+	return v.messageHTML()
+}
+
+var page = template.Must(template.New("page").Parse(pageTemplate))
+
+// RenderPage renders the full dashboard for the given views, in the order
+// they're given (callers should pass them in the same stable order the CLI
+// prints results in).
+func RenderPage(views []CheckView) (string, error) {
+	rendered := make([]renderedView, len(views))
+	for i, v := range views {
+		rendered[i] = renderedView{v}
+	}
+
+	var sb strings.Builder
+	if err := page.Execute(&sb, rendered); err != nil {
+		return "", fmt.Errorf("failed to render dashboard: %w", err)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,113 @@
+// Package report renders CheckResult values as HTML for `cmdr serve`'s
+// live dashboard (see scripts/check/serve.go). It knows nothing about how
+// checks are run or scheduled — it only turns already-computed results into
+// markup.
+package report
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// ansiCodePattern matches the three ANSI escape codes checks ever emit in a
+// CheckResult.Message (see ansiYellow/ansiRed/ansiReset in checks/file-length.go).
+// It deliberately doesn't handle the full ANSI spec — nothing else in this
+// codebase emits other codes.
+var ansiCodePattern = regexp.MustCompile("\x1b\\[(?:33|31|0)m")
+
+const (
+	ansiYellow = "\x1b[33m"
+	ansiRed    = "\x1b[31m"
+)
+
+// ansiClass maps an ANSI color code to the CSS class ANSIToHTML wraps its
+// text in. Anything not in this map (only the reset code, in practice) just
+// closes the currently open span.
+var ansiClass = map[string]string{
+	ansiYellow: "ansi-yellow",
+	ansiRed:    "ansi-red",
+}
+
+// ANSIToHTML converts a CheckResult.Message's ANSI color codes into
+// HTML-escaped text wrapped in <span class="ansi-..."> elements, so the
+// dashboard can render the same colors the terminal does.
+func ANSIToHTML(message string) string {
+	matches := ansiCodePattern.FindAllStringIndex(message, -1)
+	if len(matches) == 0 {
+		return html.EscapeString(message)
+	}
+
+	var sb strings.Builder
+	pos := 0
+	open := false
+	for _, m := range matches {
+		sb.WriteString(html.EscapeString(message[pos:m[0]]))
+		code := message[m[0]:m[1]]
+		if class, ok := ansiClass[code]; ok {
+			if open {
+				sb.WriteString("</span>")
+			}
+			sb.WriteString(`<span class="`)
+			sb.WriteString(class)
+			sb.WriteString(`">`)
+			open = true
+		} else if open {
+			sb.WriteString("</span>")
+			open = false
+		}
+		pos = m[1]
+	}
+	sb.WriteString(html.EscapeString(message[pos:]))
+	if open {
+		sb.WriteString("</span>")
+	}
+	return sb.String()
+}
+
+// fileLengthPathPattern matches a RunFileLength bullet's leading path, e.g.
+// "  - scripts/check/main.go " — used by LinkifyFileLengthPaths to turn the
+// path into a link to the /file viewer, before ANSIToHTML escapes the rest.
+var fileLengthPathPattern = regexp.MustCompile(`(?m)^(  - )(\S+)( )`)
+
+// LinkifyFileLengthPaths rewrites a file-length check's plain-text message
+// so each reported path becomes a link to /file?path=..., for the dashboard
+// to open a line-numbered view of that file. Call this before ANSIToHTML.
+func LinkifyFileLengthPaths(message string) string {
+	return fileLengthPathPattern.ReplaceAllString(message, "$1\x00$2\x00$3")
+}
+
+// resolveFileLengthLinks turns the \x00path\x00 markers LinkifyFileLengthPaths
+// left behind into <a> tags, after the surrounding text has already been
+// HTML-escaped by ANSIToHTML (the path itself never contains characters
+// escaping would change).
+func resolveFileLengthLinks(escaped string) string {
+	var sb strings.Builder
+	parts := strings.Split(escaped, "\x00")
+	for i, part := range parts {
+		if i%2 == 1 {
+			sb.WriteString(`<a href="/file?path=`)
+			sb.WriteString(part)
+			sb.WriteString(`">`)
+			sb.WriteString(part)
+			sb.WriteString(`</a>`)
+			continue
+		}
+		sb.WriteString(part)
+	}
+	return sb.String()
+}
+
+// RenderMessage converts a CheckResult.Message to HTML, linkifying file-length
+// paths first (a no-op for any other check's message, since the marker
+// pattern never matches their text).
+func RenderMessage(message string, linkifyFileLength bool) string {
+	if linkifyFileLength {
+		message = LinkifyFileLengthPaths(message)
+	}
+	rendered := ANSIToHTML(message)
+	if linkifyFileLength {
+		rendered = resolveFileLengthLinks(rendered)
+	}
+	return rendered
+}
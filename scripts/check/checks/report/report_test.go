@@ -0,0 +1,77 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"vmail/scripts/check/checks"
+)
+
+func TestANSIToHTML_NoColor(t *testing.T) {
+	got := ANSIToHTML("All files under threshold")
+	if got != "All files under threshold" {
+		t.Errorf("ANSIToHTML() = %q, want unchanged plain text", got)
+	}
+}
+
+func TestANSIToHTML_WrapsColorInSpan(t *testing.T) {
+	msg := ansiYellow + "(900 lines, 4 kB, 1k tokens)" + "\x1b[0m"
+	got := ANSIToHTML(msg)
+	if !strings.Contains(got, `<span class="ansi-yellow">`) {
+		t.Errorf("expected ansi-yellow span, got: %s", got)
+	}
+	if !strings.Contains(got, "</span>") {
+		t.Errorf("expected closing span, got: %s", got)
+	}
+}
+
+func TestANSIToHTML_EscapesHTML(t *testing.T) {
+	got := ANSIToHTML("a < b && c > d")
+	if strings.Contains(got, "<") && !strings.Contains(got, "&lt;") {
+		t.Errorf("expected HTML escaping, got: %s", got)
+	}
+}
+
+func TestLinkifyFileLengthPaths(t *testing.T) {
+	msg := "  - scripts/check/main.go " + ansiYellow + "(900 lines, 4 kB, 1k tokens)" + "\x1b[0m"
+	rendered := RenderMessage(msg, true)
+	if !strings.Contains(rendered, `<a href="/file?path=scripts/check/main.go">scripts/check/main.go</a>`) {
+		t.Errorf("expected file-length path to be linkified, got: %s", rendered)
+	}
+}
+
+func TestRenderMessage_SkipsLinkifyForOtherChecks(t *testing.T) {
+	rendered := RenderMessage("  - not a file length message", false)
+	if strings.Contains(rendered, "<a href") {
+		t.Errorf("expected no linkification when linkifyFileLength is false, got: %s", rendered)
+	}
+}
+
+func TestRenderPage(t *testing.T) {
+	views := []CheckView{
+		{Definition: checks.CheckDefinition{ID: "file-length", DisplayName: "file-length"}, Result: checks.Success("All files under threshold")},
+	}
+	html, err := RenderPage(views)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(html, "file-length") {
+		t.Errorf("expected rendered page to mention the check, got: %s", html)
+	}
+	if !strings.Contains(html, "PASS") {
+		t.Errorf("expected PASS status badge, got: %s", html)
+	}
+}
+
+func TestRenderFileContent(t *testing.T) {
+	out, err := RenderFileContent("scripts/check/main.go", "line one\nline two", 20, "~5 tokens")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "line one") || !strings.Contains(out, "line two") {
+		t.Errorf("expected both lines in rendered view, got: %s", out)
+	}
+	if !strings.Contains(out, "2 lines, 0 kB, ~5 tokens") {
+		t.Errorf("expected summary line, got: %s", out)
+	}
+}
@@ -0,0 +1,14 @@
+package checks
+
+import "time"
+
+// Reporter receives a check's lifecycle events as a run progresses: Start
+// right before it's launched, and Result once it finishes. Runner.OnStart
+// and Runner.OnResult forward directly to these, so a caller that wants
+// live progress (TTYReporter) or a machine-readable event stream
+// (JSONReporter) implements this instead of Runner printing either one
+// itself.
+type Reporter interface {
+	Start(def CheckDefinition)
+	Result(def CheckDefinition, result CheckResult, err error, duration time.Duration)
+}
@@ -0,0 +1,71 @@
+package checks
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonReporterEvent is one line of JSONReporter output. It's shaped after
+// `go test -json`'s TestEvent (Time/Action/Package/Elapsed/Output), so
+// existing `go test -json` tooling — jq filters, CI log viewers, editor
+// integrations — mostly just works against `cmdr check --output=json` too.
+type jsonReporterEvent struct {
+	Time        time.Time `json:"time"`
+	Check       string    `json:"check"`
+	App         string    `json:"app"`
+	Event       string    `json:"event"` // "start", "pass", "fail", or "skip"
+	ElapsedS    float64   `json:"elapsed,omitempty"`
+	Message     string    `json:"message,omitempty"`
+	MadeChanges bool      `json:"made_changes,omitempty"`
+	Output      string    `json:"output,omitempty"`
+}
+
+// JSONReporter writes one JSON object per line for every check lifecycle
+// event as it happens, rather than a single structured dump once the run
+// finishes — for CI log aggregation, editor integrations, and a future
+// `cmdr check --watch` that wants to react to events as they stream in.
+type JSONReporter struct {
+	enc *json.Encoder
+}
+
+// NewJSONReporter returns a JSONReporter that writes its event stream to w.
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{enc: json.NewEncoder(w)}
+}
+
+// Start emits a "start" event.
+func (r *JSONReporter) Start(def CheckDefinition) {
+	r.enc.Encode(jsonReporterEvent{
+		Time:  time.Now(),
+		Check: def.CLIName(),
+		App:   string(def.App),
+		Event: "start",
+	})
+}
+
+// Result emits a "pass", "fail", or "skip" event once def has finished.
+// ResultWarning is reported as "pass", the same way --format=ndjson treats
+// it, since neither format has a dedicated status for a non-failing warning.
+func (r *JSONReporter) Result(def CheckDefinition, result CheckResult, err error, duration time.Duration) {
+	event := "pass"
+	message := result.Message
+	switch {
+	case err != nil:
+		event = "fail"
+		message = err.Error()
+	case result.Code == ResultSkipped:
+		event = "skip"
+	}
+
+	r.enc.Encode(jsonReporterEvent{
+		Time:        time.Now(),
+		Check:       def.CLIName(),
+		App:         string(def.App),
+		Event:       event,
+		ElapsedS:    duration.Seconds(),
+		Message:     message,
+		MadeChanges: result.MadeChanges,
+		Output:      result.Output,
+	})
+}
@@ -0,0 +1,57 @@
+package checks
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// TTYReporter streams one line per finished check to w as soon as it
+// completes (completion order, not launch order), so a slow run shows live
+// progress instead of going silent until every check is done — the
+// behavior `cmdr check` has always had on a terminal, just routed through
+// Reporter instead of main printing it directly.
+type TTYReporter struct {
+	w     io.Writer
+	total int
+	done  int64
+}
+
+// NewTTYReporter returns a TTYReporter for a run of total checks, writing
+// its progress lines to w (typically os.Stderr, so it doesn't interleave
+// with a structured stdout format).
+func NewTTYReporter(w io.Writer, total int) *TTYReporter {
+	return &TTYReporter{w: w, total: total}
+}
+
+// Start is a no-op: the TTY progress line is only informative once a check
+// has a result to report.
+func (r *TTYReporter) Start(def CheckDefinition) {}
+
+// Result prints "[i/total] <status> <name> (<duration>)" for the finished check.
+func (r *TTYReporter) Result(def CheckDefinition, result CheckResult, err error, duration time.Duration) {
+	i := atomic.AddInt64(&r.done, 1)
+
+	status := "passed"
+	switch {
+	case err != nil:
+		status = "failed"
+	case result.Code == ResultWarning:
+		status = "warned"
+	case result.Code == ResultSkipped:
+		status = "skipped"
+	}
+
+	fmt.Fprintf(r.w, "[%d/%d] %s %s (%s)\n", i, r.total, status, def.CLIName(), formatReporterDuration(duration))
+}
+
+// formatReporterDuration mirrors main.formatDuration's "1.23s"/"450ms"
+// rendering; duplicated rather than shared since a checks.Reporter can't
+// import package main.
+func formatReporterDuration(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,40 @@
+package checks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrRootNotFound is returned by FindRootDir when no repository marker is
+// found walking up from startDir to the filesystem root.
+var ErrRootNotFound = errors.New("could not find repository root (looked for pnpm-workspace.yaml or cmdr.toml)")
+
+// rootMarkers lists the files FindRootDir looks for in each candidate
+// directory, in preference order. pnpm-workspace.yaml is checked first since
+// it only ever lives at the true monorepo root, whereas cmdr.toml is merely
+// conventional (see config.Load) and a user could in principle drop one
+// elsewhere.
+var rootMarkers = []string{"pnpm-workspace.yaml", "cmdr.toml"}
+
+// FindRootDir walks upward from startDir looking for a repository marker
+// (pnpm-workspace.yaml or a top-level cmdr.toml), the same way Go's own
+// tooling walks up from the working directory looking for go.mod. This lets
+// `cmdr check` resolve apps/desktop, scripts, etc. relative to the real root
+// no matter which subdirectory of the repo it's invoked from. Returns
+// ErrRootNotFound if no marker is found before reaching the filesystem root.
+func FindRootDir(startDir string) (string, error) {
+	dir := startDir
+	for {
+		for _, marker := range rootMarkers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrRootNotFound
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,87 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"vmail/scripts/check/checks/coverage"
+)
+
+// goCoverageDefaultThreshold is used when cmdr.toml's [coverage] go
+// threshold isn't configured, matching desktop-svelte-tests' CoverageThreshold.
+const goCoverageDefaultThreshold = 70.0
+
+// RunGoCoverage enforces a per-file line coverage threshold for every Go
+// module FindAllGoModules finds, the Go analogue of RunSvelteTests' per-file
+// checkFileCoverage: each module may have its own coverage-allowlist.json
+// (same schema as apps/desktop's) to exempt specific files or override their
+// threshold. Unlike RunCoverage (which only tracks an aggregate total across
+// all three languages), this flags exactly which files are below the bar.
+func RunGoCoverage(ctx *CheckContext) (CheckResult, error) {
+	modules, err := FindAllGoModules(ctx.effectiveFS(), ".", DiscoveryOptions{})
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
+	}
+
+	defaultThreshold := goCoverageDefaultThreshold
+	if ctx.Config != nil && ctx.Config.Coverage.Go > 0 {
+		defaultThreshold = float64(ctx.Config.Coverage.Go)
+	}
+
+	var allLowCoverageFiles []string
+	for _, mod := range modules {
+		modDir := filepath.Join(ctx.RootDir, mod.Dir)
+
+		lowCoverageFiles, err := checkGoModuleCoverage(modDir, defaultThreshold)
+		if err != nil {
+			return CheckResult{}, fmt.Errorf("%s: %w", mod.Dir, err)
+		}
+		for _, f := range lowCoverageFiles {
+			allLowCoverageFiles = append(allLowCoverageFiles, fmt.Sprintf("[%s]%s", mod.Dir, f))
+		}
+	}
+
+	if len(allLowCoverageFiles) > 0 {
+		errorMsg := "Files below coverage threshold:\n"
+		for _, f := range allLowCoverageFiles {
+			errorMsg += "      " + f + "\n"
+		}
+		errorMsg += "\n      To allowlist a file, add it to that module's coverage-allowlist.json with a reason."
+		return CheckResult{}, fmt.Errorf("coverage below threshold for %d files\n%s", len(allLowCoverageFiles), errorMsg)
+	}
+	return Success("All Go files meet their coverage threshold"), nil
+}
+
+// checkGoModuleCoverage runs `go test -coverprofile` for the module at
+// modDir and returns the formatted low-coverage lines for files that don't
+// meet modDir's coverage-allowlist.json thresholds (or defaultThreshold, for
+// files it doesn't mention).
+func checkGoModuleCoverage(modDir string, defaultThreshold float64) ([]string, error) {
+	profilePath := filepath.Join(modDir, ".cmdr-go-coverage.out")
+	cmd := exec.Command("go", "test", "-coverprofile="+profilePath, "./...")
+	cmd.Dir = modDir
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return nil, fmt.Errorf("go test failed: %w\n%s", err, output)
+	}
+	defer os.Remove(profilePath)
+
+	if _, statErr := os.Stat(profilePath); statErr != nil {
+		// No statements to cover in this module; nothing to enforce.
+		return nil, nil
+	}
+
+	report, err := coverage.LoadGoProfile(profilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load coverage profile: %w", err)
+	}
+
+	allowlist, err := coverage.Load(filepath.Join(modDir, "coverage-allowlist.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	return coverage.LowCoverageFiles(report, allowlist, defaultThreshold), nil
+}
@@ -9,6 +9,13 @@ import (
 
 // RunDeadcode runs Go's deadcode tool to find unreachable functions.
 func RunDeadcode(ctx *CheckContext) (CheckResult, error) {
+	return CachedCheck(ctx, "deadcode", goSourceGlobs, goToolchainVersion, 0, func() (CheckResult, error) {
+		return runDeadcode(ctx)
+	})
+}
+
+// runDeadcode is RunDeadcode's uncached implementation.
+func runDeadcode(ctx *CheckContext) (CheckResult, error) {
 	// Ensure deadcode is installed
 	deadcodePath, err := EnsureGoTool("deadcode", "golang.org/x/tools/cmd/deadcode@latest")
 	if err != nil {
@@ -16,7 +23,7 @@ func RunDeadcode(ctx *CheckContext) (CheckResult, error) {
 	}
 
 	// Find all Go modules
-	modules, err := FindAllGoModules(ctx.RootDir)
+	modules, err := FindAllGoModules(ctx.effectiveFS(), ".", DiscoveryOptions{})
 	if err != nil {
 		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
 	}
@@ -24,33 +31,30 @@ func RunDeadcode(ctx *CheckContext) (CheckResult, error) {
 	var allIssues []string
 	modulesChecked := 0
 
-	for baseDir, subModules := range modules {
-		for _, subModule := range subModules {
-			modulePath := filepath.Join(ctx.RootDir, baseDir, subModule)
+	for _, mod := range modules {
+		modulePath := filepath.Join(ctx.RootDir, mod.Dir)
 
-			cmd := exec.Command(deadcodePath, "./...")
-			cmd.Dir = modulePath
-			output, err := RunCommand(cmd, true)
+		cmd := exec.Command(deadcodePath, "./...")
+		cmd.Dir = modulePath
+		output, err := RunCommand(cmd, true)
 
-			// deadcode exits 0 even when it finds issues, output goes to stdout
-			if err != nil {
-				return CheckResult{}, fmt.Errorf("deadcode failed in %s: %w\n%s", modulePath, err, output)
-			}
+		// deadcode exits 0 even when it finds issues, output goes to stdout
+		if err != nil {
+			return CheckResult{}, fmt.Errorf("deadcode failed in %s: %w\n%s", modulePath, err, output)
+		}
 
-			// Parse output - each line is a dead code issue
-			output = strings.TrimSpace(output)
-			if output != "" {
-				for _, line := range strings.Split(output, "\n") {
-					if line != "" {
-						// Prefix with module path for clarity
-						relPath := filepath.Join(baseDir, subModule)
-						allIssues = append(allIssues, fmt.Sprintf("%s: %s", relPath, line))
-					}
+		// Parse output - each line is a dead code issue
+		output = strings.TrimSpace(output)
+		if output != "" {
+			for _, line := range strings.Split(output, "\n") {
+				if line != "" {
+					// Prefix with module path for clarity
+					allIssues = append(allIssues, fmt.Sprintf("%s: %s", mod.Dir, line))
 				}
 			}
-
-			modulesChecked++
 		}
+
+		modulesChecked++
 	}
 
 	if len(allIssues) > 0 {
@@ -0,0 +1,56 @@
+package checks
+
+import (
+	"os/exec"
+
+	"vmail/scripts/check/checks/toolchain"
+)
+
+// RunErrcheck checks for unchecked errors, using the errcheck version
+// tools.lock pins (see the toolchain package) rather than whatever @latest
+// resolved to last time someone ran it.
+func RunErrcheck(ctx *CheckContext) (CheckResult, error) {
+	return CachedCheck(ctx, "errcheck", goSourceGlobs, func() string { return errcheckVersion(ctx) }, 0, func() (CheckResult, error) {
+		return runErrcheck(ctx)
+	})
+}
+
+// errcheckVersion returns the version tools.lock pins errcheck to, so a
+// tools.lock bump still busts CachedCheck's fingerprint even though
+// tools.lock isn't one of goSourceGlobs.
+func errcheckVersion(ctx *CheckContext) string {
+	lock, err := toolchain.LoadLock(ctx.RootDir)
+	if err != nil {
+		return ""
+	}
+	return lock["errcheck"]
+}
+
+// runErrcheck is RunErrcheck's uncached implementation.
+func runErrcheck(ctx *CheckContext) (CheckResult, error) {
+	errcheckBin, err := toolchain.Ensure(ctx.RootDir, "errcheck")
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	runner := GoModuleRunner{
+		Name: "errcheck",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command(errcheckBin, "./...")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			count, _ := countGoPackages(modDir)
+			if cmdErr == nil {
+				return nil, count, nil
+			}
+			issues := rewriteGoToolOutput(output, modLabel, rewriteLeadingGoPath)
+			if len(issues) == 0 {
+				issues = []string{cmdErr.Error()}
+			}
+			return issues, count, nil
+		},
+		CountNoun: "package",
+	}
+
+	return runner.Run(ctx)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -12,6 +13,13 @@ const GocycloThreshold = 15
 
 // RunGocyclo checks cyclomatic complexity of Go functions.
 func RunGocyclo(ctx *CheckContext) (CheckResult, error) {
+	return CachedCheck(ctx, "gocyclo", goSourceGlobs, goToolchainVersion, 0, func() (CheckResult, error) {
+		return runGocyclo(ctx)
+	})
+}
+
+// runGocyclo is RunGocyclo's uncached implementation.
+func runGocyclo(ctx *CheckContext) (CheckResult, error) {
 	scriptsDir := filepath.Join(ctx.RootDir, "scripts")
 
 	// Ensure gocyclo is installed
@@ -22,16 +30,17 @@ func RunGocyclo(ctx *CheckContext) (CheckResult, error) {
 		}
 	}
 
-	modules, err := FindGoModules(scriptsDir)
+	modules, err := FindAllGoModules(ctx.effectiveFS(), "scripts", DiscoveryOptions{})
 	if err != nil {
 		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
 	}
 
 	var allIssues []string
+	var diagnostics []Diagnostic
 	fileCount := 0
 
 	for _, mod := range modules {
-		modDir := filepath.Join(scriptsDir, mod)
+		modDir := filepath.Join(scriptsDir, mod.Dir)
 
 		// Count Go files in this module
 		findCmd := exec.Command("find", ".", "-name", "*.go", "-type", "f")
@@ -56,8 +65,11 @@ func RunGocyclo(ctx *CheckContext) (CheckResult, error) {
 					// Find the last space before the file:line part and prefix the file path
 					parts := strings.Fields(line)
 					if len(parts) >= 4 {
-						parts[3] = fmt.Sprintf("scripts/%s/%s", mod, parts[3])
+						parts[3] = fmt.Sprintf("scripts/%s/%s", mod.Dir, parts[3])
 						lines[i] = strings.Join(parts, " ")
+						if d, ok := parseGocycloLine(parts); ok {
+							diagnostics = append(diagnostics, d)
+						}
 					}
 				}
 				allIssues = append(allIssues, strings.Join(lines, "\n"))
@@ -66,7 +78,7 @@ func RunGocyclo(ctx *CheckContext) (CheckResult, error) {
 	}
 
 	if len(allIssues) > 0 {
-		return CheckResult{}, fmt.Errorf("functions exceed complexity threshold of %d\n%s", GocycloThreshold, indentOutput(strings.Join(allIssues, "\n")))
+		return CheckResult{Diagnostics: diagnostics}, fmt.Errorf("functions exceed complexity threshold of %d\n%s", GocycloThreshold, indentOutput(strings.Join(allIssues, "\n")))
 	}
 
 	if fileCount > 0 {
@@ -74,3 +86,33 @@ func RunGocyclo(ctx *CheckContext) (CheckResult, error) {
 	}
 	return Success("Complexity OK"), nil
 }
+
+// parseGocycloLine turns one already-repo-path-rewritten gocyclo output line,
+// split on whitespace ("<complexity> <package> <function> <file>:<line>"),
+// into a Diagnostic. ok is false if parts doesn't have the shape gocyclo's
+// own format guarantees.
+func parseGocycloLine(parts []string) (Diagnostic, bool) {
+	if len(parts) < 4 {
+		return Diagnostic{}, false
+	}
+	complexity, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Diagnostic{}, false
+	}
+	file, lineStr, found := strings.Cut(parts[3], ":")
+	if !found {
+		return Diagnostic{}, false
+	}
+	line, err := strconv.Atoi(lineStr)
+	if err != nil {
+		return Diagnostic{}, false
+	}
+	return Diagnostic{
+		File:     file,
+		Line:     line,
+		Rule:     "gocyclo",
+		Message:  fmt.Sprintf("%s has cyclomatic complexity %d (threshold %d)", parts[2], complexity, GocycloThreshold),
+		Severity: "warning",
+		Tool:     "gocyclo",
+	}, true
+}
@@ -0,0 +1,56 @@
+package checks
+
+import (
+	"os/exec"
+
+	"vmail/scripts/check/checks/toolchain"
+)
+
+// RunGosec scans Go code for common security issues, using the gosec version
+// tools.lock pins (see the toolchain package) rather than whatever @latest
+// resolved to last time someone ran it.
+func RunGosec(ctx *CheckContext) (CheckResult, error) {
+	return CachedCheck(ctx, "gosec", goSourceGlobs, func() string { return gosecVersion(ctx) }, 0, func() (CheckResult, error) {
+		return runGosec(ctx)
+	})
+}
+
+// gosecVersion returns the version tools.lock pins gosec to, so a
+// tools.lock bump still busts CachedCheck's fingerprint even though
+// tools.lock isn't one of goSourceGlobs.
+func gosecVersion(ctx *CheckContext) string {
+	lock, err := toolchain.LoadLock(ctx.RootDir)
+	if err != nil {
+		return ""
+	}
+	return lock["gosec"]
+}
+
+// runGosec is RunGosec's uncached implementation.
+func runGosec(ctx *CheckContext) (CheckResult, error) {
+	gosecBin, err := toolchain.Ensure(ctx.RootDir, "gosec")
+	if err != nil {
+		return CheckResult{}, err
+	}
+
+	runner := GoModuleRunner{
+		Name: "gosec",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command(gosecBin, "-quiet", "./...")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			count, _ := countGoPackages(modDir)
+			if cmdErr == nil {
+				return nil, count, nil
+			}
+			issues := rewriteGoToolOutput(output, modLabel, rewriteLeadingGoPath)
+			if len(issues) == 0 {
+				issues = []string{cmdErr.Error()}
+			}
+			return issues, count, nil
+		},
+		CountNoun: "package",
+	}
+
+	return runner.Run(ctx)
+}
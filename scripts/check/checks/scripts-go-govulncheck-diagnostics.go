@@ -0,0 +1,108 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// govulncheckMessage mirrors the subset of govulncheck's `-json` output
+// (a stream of JSON objects, one per line) needed to report call-site
+// Diagnostics: a "finding" message for a vulnerable symbol actually reached,
+// with a trace of call frames from main down to that symbol.
+type govulncheckMessage struct {
+	Finding *struct {
+		OSV   string `json:"osv"`
+		Trace []struct {
+			Module   string `json:"module"`
+			Package  string `json:"package"`
+			Function string `json:"function"`
+			Position *struct {
+				Filename string `json:"filename"`
+				Line     int    `json:"line"`
+				Column   int    `json:"column"`
+			} `json:"position"`
+		} `json:"trace"`
+	} `json:"finding"`
+}
+
+// renderGovulncheckOutput reassembles a human-readable vulnerability list
+// from govulncheck's `-json` output, one line per finding, reusing
+// pnpm-audit's severityIcon/truncate so both vulnerability checks read the
+// same way in the terminal.
+//
+// Unlike npm advisories, the Go vulnerability database doesn't grade
+// findings by severity - every finding govulncheck reports here is a
+// symbol first-party code actually reaches (see
+// parseGovulncheckDiagnostics's import-only skip), so each is rendered at
+// "high". [fixable] vs [transitive] mirrors RunPnpmAudit's depth heuristic:
+// trace[0] is the vulnerable symbol itself, so a trace of length 2 or less
+// means first-party code calls it directly (depth 1, fixable by changing
+// that one call site); a longer trace means the call is buried behind
+// other dependencies (transitive).
+func renderGovulncheckOutput(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var msg govulncheckMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil || msg.Finding == nil || len(msg.Finding.Trace) == 0 {
+			continue
+		}
+		frame := msg.Finding.Trace[0]
+
+		fixable := "[fixable]"
+		if len(msg.Finding.Trace) > 2 {
+			fixable = "[transitive]"
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s %s: %s", severityIcon("high"), msg.Finding.OSV, fixable,
+			truncate(fmt.Sprintf("%s calls vulnerable %s (%s)", frame.Module, frame.Function, govulncheckHelpURI(msg.Finding.OSV)), 100)))
+	}
+	return lines
+}
+
+// govulncheckHelpURI returns the pkg.go.dev advisory page for a govulncheck
+// OSV ID (e.g. "GO-2023-1829"), the Go vulnerability database's own
+// canonical doc page for that finding.
+func govulncheckHelpURI(osv string) string {
+	return "https://pkg.go.dev/vuln/" + osv
+}
+
+// parseGovulncheckDiagnostics parses one module's `-json` output into one
+// Diagnostic per finding that has a call-site position — govulncheck also
+// reports vulnerabilities that are merely imported but never called, which
+// have no position to attach a Diagnostic to, so those are left to the
+// plain-text issue list instead.
+func parseGovulncheckDiagnostics(output, modLabel string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var msg govulncheckMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil || msg.Finding == nil {
+			continue
+		}
+		trace := msg.Finding.Trace
+		if len(trace) == 0 || trace[0].Position == nil {
+			continue
+		}
+		frame := trace[0]
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     filepath.Join(modLabel, frame.Position.Filename),
+			Line:     frame.Position.Line,
+			Col:      frame.Position.Column,
+			Rule:     msg.Finding.OSV,
+			Message:  fmt.Sprintf("call to vulnerable function %s (%s)", frame.Function, msg.Finding.OSV),
+			Severity: "error",
+			Tool:     "govulncheck",
+			HelpURI:  govulncheckHelpURI(msg.Finding.OSV),
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,77 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseGovulncheckDiagnostics_ParsesFindingWithPosition(t *testing.T) {
+	output := `{"finding":{"osv":"GO-2023-1234","trace":[{"module":"example.com/vuln","package":"example.com/vuln/pkg","function":"Do","position":{"filename":"main.go","line":12,"column":4}}]}}`
+
+	got := parseGovulncheckDiagnostics(output, "scripts/check")
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	d := got[0]
+	if d.File != "scripts/check/main.go" {
+		t.Errorf("expected File prefixed with modLabel, got %q", d.File)
+	}
+	if d.Line != 12 || d.Col != 4 {
+		t.Errorf("unexpected location: %+v", d)
+	}
+	if d.Rule != "GO-2023-1234" {
+		t.Errorf("expected Rule GO-2023-1234, got %q", d.Rule)
+	}
+	if d.Tool != "govulncheck" {
+		t.Errorf("expected Tool govulncheck, got %q", d.Tool)
+	}
+	if d.HelpURI != "https://pkg.go.dev/vuln/GO-2023-1234" {
+		t.Errorf("unexpected HelpURI: %q", d.HelpURI)
+	}
+}
+
+func TestParseGovulncheckDiagnostics_SkipsFindingWithoutPosition(t *testing.T) {
+	output := `{"finding":{"osv":"GO-2023-1234","trace":[{"module":"example.com/vuln","package":"example.com/vuln/pkg","function":"Do"}]}}`
+
+	got := parseGovulncheckDiagnostics(output, "scripts/check")
+
+	if len(got) != 0 {
+		t.Errorf("expected no diagnostics for a finding with no call-site position (import-only vuln), got %+v", got)
+	}
+}
+
+func TestParseGovulncheckDiagnostics_IgnoresOtherMessageKinds(t *testing.T) {
+	output := `{"progress":{"message":"Scanning your code..."}}
+{"osv":{"id":"GO-2023-1234"}}`
+
+	got := parseGovulncheckDiagnostics(output, "scripts/check")
+
+	if len(got) != 0 {
+		t.Errorf("expected no diagnostics from non-finding messages, got %+v", got)
+	}
+}
+
+func TestRenderGovulncheckOutput_MarksDirectCallFixable(t *testing.T) {
+	output := `{"finding":{"osv":"GO-2023-1234","trace":[{"module":"example.com/vuln","package":"example.com/vuln/pkg","function":"Do"},{"module":"example.com/myapp","package":"example.com/myapp","function":"main"}]}}`
+
+	lines := renderGovulncheckOutput(output)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "[fixable]") {
+		t.Errorf("expected a depth-2 trace to be marked [fixable], got %q", lines[0])
+	}
+}
+
+func TestRenderGovulncheckOutput_MarksIndirectCallTransitive(t *testing.T) {
+	output := `{"finding":{"osv":"GO-2023-1234","trace":[{"module":"example.com/vuln","package":"example.com/vuln/pkg","function":"Do"},{"module":"example.com/middle","package":"example.com/middle","function":"Wrap"},{"module":"example.com/myapp","package":"example.com/myapp","function":"main"}]}}`
+
+	lines := renderGovulncheckOutput(output)
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, got %d: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "[transitive]") {
+		t.Errorf("expected a depth-3 trace to be marked [transitive], got %q", lines[0])
+	}
+}
@@ -1,50 +1,59 @@
 package checks
 
 import (
-	"fmt"
 	"os/exec"
-	"path/filepath"
-	"strings"
+
+	"vmail/scripts/check/checks/toolchain"
 )
 
-// RunGovulncheck checks for known vulnerabilities in Go dependencies.
+// RunGovulncheck checks for known vulnerabilities in Go dependencies, using
+// the govulncheck version tools.lock pins (see the toolchain package).
 func RunGovulncheck(ctx *CheckContext) (CheckResult, error) {
-	govulncheckBin, err := EnsureGoTool("govulncheck", "golang.org/x/vuln/cmd/govulncheck@latest")
-	if err != nil {
-		return CheckResult{}, err
+	if ctx.Offline && !toolchain.Resolved(ctx.RootDir, "govulncheck") {
+		return Skipped("govulncheck not installed and --offline set"), nil
 	}
 
-	allModules, err := FindAllGoModules(ctx.RootDir)
+	govulncheckBin, err := toolchain.Ensure(ctx.RootDir, "govulncheck")
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
+		return CheckResult{}, err
 	}
 
-	var allIssues []string
-	modCount := 0
-
-	for goDir, modules := range allModules {
-		baseDir := filepath.Join(ctx.RootDir, goDir)
-		for _, mod := range modules {
-			modDir := filepath.Join(baseDir, mod)
-			modLabel := filepath.Join(goDir, mod)
-			modCount++
-
-			cmd := exec.Command(govulncheckBin, "./...")
-			cmd.Dir = modDir
-			output, err := RunCommand(cmd, true)
-			if err != nil {
-				issueText := strings.TrimSpace(output)
-				if issueText == "" {
-					issueText = err.Error()
-				}
-				allIssues = append(allIssues, fmt.Sprintf("[%s]\n%s", modLabel, issueText))
+	runner := GoModuleRunner{
+		Name: "govulncheck",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command(govulncheckBin, "-json", "./...")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			if cmdErr == nil {
+				return nil, 1, nil
 			}
-		}
+			issues := renderGovulncheckOutput(output)
+			if len(issues) == 0 {
+				issues = []string{cmdErr.Error()}
+			}
+			return issues, 1, nil
+		},
+		CountNoun: "module",
+		Diagnostics: func(output, modDir, modLabel string) []Diagnostic {
+			return parseGovulncheckDiagnostics(output, modLabel)
+		},
 	}
 
-	if len(allIssues) > 0 {
-		return CheckResult{}, fmt.Errorf("vulnerabilities found\n%s", indentOutput(strings.Join(allIssues, "\n")))
-	}
+	return runner.Run(ctx)
+}
 
-	return Success(fmt.Sprintf("Scanned %d %s, no vulnerabilities", modCount, Pluralize(modCount, "module", "modules"))), nil
+// GetGoModules returns the directories (relative to rootDir, slash-
+// separated) of every Go module under rootDir, mirroring GetPnpmApps'
+// shape for the Go side - a lightweight []string a caller can use without
+// pulling in FindAllGoModules' ModuleInfo/SourceFS machinery directly.
+func GetGoModules(rootDir string) []string {
+	modules, err := FindAllGoModules(NewOSFS(rootDir), ".", DiscoveryOptions{})
+	if err != nil {
+		return nil
+	}
+	dirs := make([]string, len(modules))
+	for i, mod := range modules {
+		dirs[i] = mod.Dir
+	}
+	return dirs
 }
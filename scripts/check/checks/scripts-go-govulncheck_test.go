@@ -0,0 +1,38 @@
+package checks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetGoModules_FindsEveryGoModUnderRootDir(t *testing.T) {
+	root := t.TempDir()
+	writeGoModule(t, root, ".")
+	writeGoModule(t, root, "scripts/check")
+
+	got := GetGoModules(root)
+
+	want := map[string]bool{".": true, "scripts/check": true}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d modules, got %d: %v", len(want), len(got), got)
+	}
+	for _, dir := range got {
+		if !want[dir] {
+			t.Errorf("unexpected module dir %q", dir)
+		}
+	}
+}
+
+func TestGetGoModules_EmptyRootDirReturnsNoModules(t *testing.T) {
+	root := t.TempDir()
+	if got := GetGoModules(root); len(got) != 0 {
+		t.Errorf("expected no modules in an empty tree, got %v", got)
+	}
+}
+
+func TestGetGoModules_NonexistentRootDirReturnsNil(t *testing.T) {
+	if got := GetGoModules(filepath.Join(os.TempDir(), "does-not-exist-cmdr-test")); got != nil {
+		t.Errorf("expected nil for an unreadable rootDir, got %v", got)
+	}
+}
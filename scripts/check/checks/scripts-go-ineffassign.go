@@ -1,60 +1,56 @@
 package checks
 
 import (
-	"fmt"
 	"os/exec"
-	"path/filepath"
-	"strings"
+
+	"vmail/scripts/check/checks/toolchain"
 )
 
-// RunIneffassign detects ineffectual assignments.
+// RunIneffassign detects ineffectual assignments, using the ineffassign
+// version tools.lock pins (see the toolchain package) rather than whatever
+// @latest resolved to last time someone ran it.
 func RunIneffassign(ctx *CheckContext) (CheckResult, error) {
-	ineffassignBin, err := EnsureGoTool("ineffassign", "github.com/gordonklaus/ineffassign@latest")
+	return CachedCheck(ctx, "ineffassign", goSourceGlobs, func() string { return ineffassignVersion(ctx) }, 0, func() (CheckResult, error) {
+		return runIneffassign(ctx)
+	})
+}
+
+// ineffassignVersion returns the version tools.lock pins ineffassign to, so
+// a tools.lock bump still busts CachedCheck's fingerprint even though
+// tools.lock isn't one of goSourceGlobs.
+func ineffassignVersion(ctx *CheckContext) string {
+	lock, err := toolchain.LoadLock(ctx.RootDir)
 	if err != nil {
-		return CheckResult{}, err
+		return ""
 	}
+	return lock["ineffassign"]
+}
 
-	allModules, err := FindAllGoModules(ctx.RootDir)
+// runIneffassign is RunIneffassign's uncached implementation.
+func runIneffassign(ctx *CheckContext) (CheckResult, error) {
+	ineffassignBin, err := toolchain.Ensure(ctx.RootDir, "ineffassign")
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
+		return CheckResult{}, err
 	}
 
-	var allIssues []string
-	fileCount := 0
-
-	for goDir, modules := range allModules {
-		baseDir := filepath.Join(ctx.RootDir, goDir)
-		for _, mod := range modules {
-			modDir := filepath.Join(baseDir, mod)
-			modLabel := filepath.Join(goDir, mod)
-
-			// Count Go files in this module
-			findCmd := exec.Command("find", ".", "-name", "*.go", "-type", "f")
-			findCmd.Dir = modDir
-			findOutput, _ := RunCommand(findCmd, true)
-			if strings.TrimSpace(findOutput) != "" {
-				fileCount += len(strings.Split(strings.TrimSpace(findOutput), "\n"))
+	runner := GoModuleRunner{
+		Name: "ineffassign",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command(ineffassignBin, "./...")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			count, _ := countGoFiles(modDir)
+			if cmdErr == nil {
+				return nil, count, nil
 			}
-
-			cmd := exec.Command(ineffassignBin, "./...")
-			cmd.Dir = modDir
-			output, err := RunCommand(cmd, true)
-			if err != nil {
-				issueText := strings.TrimSpace(output)
-				if issueText == "" {
-					issueText = err.Error()
-				}
-				allIssues = append(allIssues, fmt.Sprintf("[%s]\n%s", modLabel, issueText))
+			issues := rewriteGoToolOutput(output, modLabel, rewriteLeadingGoPath)
+			if len(issues) == 0 {
+				issues = []string{cmdErr.Error()}
 			}
-		}
+			return issues, count, nil
+		},
+		CountNoun: "file",
 	}
 
-	if len(allIssues) > 0 {
-		return CheckResult{}, fmt.Errorf("ineffectual assignments found\n%s", indentOutput(strings.Join(allIssues, "\n")))
-	}
-
-	if fileCount > 0 {
-		return Success(fmt.Sprintf("%d %s checked, no ineffectual assignments", fileCount, Pluralize(fileCount, "file", "files"))), nil
-	}
-	return Success("No ineffectual assignments"), nil
+	return runner.Run(ctx)
 }
@@ -0,0 +1,67 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// misspellFinding mirrors one line of misspell's `-f json` output (one JSON
+// object per line, one per misspelling found).
+type misspellFinding struct {
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	Column    int    `json:"column"`
+	Original  string `json:"original"`
+	Corrected string `json:"corrected"`
+}
+
+// renderMisspellOutput reassembles `-f json` output into the
+// "file:line:col: `original` is a misspelling of `corrected`" lines
+// misspell's default format would have printed, for the issue list in the
+// check's error message.
+func renderMisspellOutput(output, modLabel string) []string {
+	var lines []string
+	for _, f := range parseMisspellFindings(output) {
+		lines = append(lines, fmt.Sprintf("%s:%d:%d: %q is a misspelling of %q",
+			filepath.Join(modLabel, f.File), f.Line, f.Column, f.Original, f.Corrected))
+	}
+	return lines
+}
+
+// parseMisspellDiagnostics parses one module's `-f json` output into
+// Diagnostics, rewriting each finding's module-relative file to be
+// modLabel-prefixed, matching every other aggregated-across-modules check's
+// convention (see rewriteLeadingGoPath).
+func parseMisspellDiagnostics(output, modLabel string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, f := range parseMisspellFindings(output) {
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     filepath.Join(modLabel, f.File),
+			Line:     f.Line,
+			Col:      f.Column,
+			Rule:     "misspell",
+			Message:  fmt.Sprintf("%q is a misspelling of %q", f.Original, f.Corrected),
+			Severity: "warning",
+			Tool:     "misspell",
+		})
+	}
+	return diagnostics
+}
+
+func parseMisspellFindings(output string) []misspellFinding {
+	var findings []misspellFinding
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var f misspellFinding
+		if err := json.Unmarshal([]byte(line), &f); err != nil {
+			continue
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
@@ -0,0 +1,44 @@
+package checks
+
+import "testing"
+
+func TestParseMisspellDiagnostics_ParsesFinding(t *testing.T) {
+	output := `{"file":"README.md","line":5,"column":3,"original":"langauge","corrected":"language"}`
+
+	got := parseMisspellDiagnostics(output, "apps/website")
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	d := got[0]
+	if d.File != "apps/website/README.md" {
+		t.Errorf("expected File prefixed with modLabel, got %q", d.File)
+	}
+	if d.Line != 5 || d.Col != 3 {
+		t.Errorf("unexpected location: %+v", d)
+	}
+	if d.Tool != "misspell" {
+		t.Errorf("expected Tool misspell, got %q", d.Tool)
+	}
+}
+
+func TestParseMisspellDiagnostics_SkipsMalformedLines(t *testing.T) {
+	output := "not json at all\n" + `{"file":"a.go","line":1,"column":1,"original":"teh","corrected":"the"}`
+
+	got := parseMisspellDiagnostics(output, "scripts/check")
+
+	if len(got) != 1 {
+		t.Errorf("expected the malformed line to be skipped and the valid one parsed, got %+v", got)
+	}
+}
+
+func TestRenderMisspellOutput_FormatsAsFileLineColMessage(t *testing.T) {
+	output := `{"file":"README.md","line":5,"column":3,"original":"langauge","corrected":"language"}`
+
+	got := renderMisspellOutput(output, "apps/website")
+
+	want := `apps/website/README.md:5:3: "langauge" is a misspelling of "language"`
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("expected %q, got %+v", want, got)
+	}
+}
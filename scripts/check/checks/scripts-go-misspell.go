@@ -1,52 +1,59 @@
 package checks
 
 import (
-	"fmt"
 	"os/exec"
-	"path/filepath"
-	"strings"
+
+	"vmail/scripts/check/checks/toolchain"
 )
 
-// RunMisspell checks for spelling mistakes.
+// RunMisspell checks for spelling mistakes, using the misspell version
+// tools.lock pins (see the toolchain package) rather than whatever @latest
+// resolved to last time someone ran it.
 func RunMisspell(ctx *CheckContext) (CheckResult, error) {
-	misspellBin, err := EnsureGoTool("misspell", "github.com/client9/misspell/cmd/misspell@latest")
+	return CachedCheck(ctx, "misspell", goSourceGlobs, func() string { return misspellVersion(ctx) }, 0, func() (CheckResult, error) {
+		return runMisspell(ctx)
+	})
+}
+
+// misspellVersion returns the version tools.lock pins misspell to, so a
+// tools.lock bump still busts CachedCheck's fingerprint even though
+// tools.lock isn't one of goSourceGlobs.
+func misspellVersion(ctx *CheckContext) string {
+	lock, err := toolchain.LoadLock(ctx.RootDir)
 	if err != nil {
-		return CheckResult{}, err
+		return ""
 	}
+	return lock["misspell"]
+}
 
-	goDirs := GetGoDirectories(ctx.RootDir)
-	totalFileCount := 0
-	var allIssues []string
-
-	for _, goDir := range goDirs {
-		fullPath := filepath.Join(ctx.RootDir, goDir)
-
-		// Count Go files
-		findCmd := exec.Command("find", ".", "-name", "*.go", "-type", "f")
-		findCmd.Dir = fullPath
-		findOutput, _ := RunCommand(findCmd, true)
-		if strings.TrimSpace(findOutput) != "" {
-			totalFileCount += len(strings.Split(strings.TrimSpace(findOutput), "\n"))
-		}
-
-		cmd := exec.Command(misspellBin, "-error", ".")
-		cmd.Dir = fullPath
-		output, err := RunCommand(cmd, true)
-		if err != nil {
-			issueText := strings.TrimSpace(output)
-			if issueText == "" {
-				issueText = err.Error()
-			}
-			allIssues = append(allIssues, fmt.Sprintf("[%s]\n%s", goDir, issueText))
-		}
+// runMisspell is RunMisspell's uncached implementation.
+func runMisspell(ctx *CheckContext) (CheckResult, error) {
+	misspellBin, err := toolchain.Ensure(ctx.RootDir, "misspell")
+	if err != nil {
+		return CheckResult{}, err
 	}
 
-	if len(allIssues) > 0 {
-		return CheckResult{}, fmt.Errorf("spelling mistakes found\n%s", indentOutput(strings.Join(allIssues, "\n")))
+	runner := GoModuleRunner{
+		Name: "misspell",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command(misspellBin, "-error", "-f", "json", ".")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			count, _ := countGoFiles(modDir)
+			if cmdErr == nil {
+				return nil, count, nil
+			}
+			issues := renderMisspellOutput(output, modLabel)
+			if len(issues) == 0 {
+				issues = []string{cmdErr.Error()}
+			}
+			return issues, count, nil
+		},
+		CountNoun: "file",
+		Diagnostics: func(output, modDir, modLabel string) []Diagnostic {
+			return parseMisspellDiagnostics(output, modLabel)
+		},
 	}
 
-	if totalFileCount > 0 {
-		return Success(fmt.Sprintf("%d %s checked, no misspellings", totalFileCount, Pluralize(totalFileCount, "file", "files"))), nil
-	}
-	return Success("No misspellings"), nil
+	return runner.Run(ctx)
 }
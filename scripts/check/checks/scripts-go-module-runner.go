@@ -0,0 +1,187 @@
+package checks
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// PathRewriter rewrites one line of a tool's module-relative output (e.g.
+// "foo.go:12:3: message") to reference a modLabel-prefixed path instead, so
+// aggregating every module's output into one CheckResult doesn't produce
+// ambiguous "foo.go:12" references that collide across modules.
+type PathRewriter func(line, modLabel string) string
+
+// rewriteLeadingGoPath is the PathRewriter for tools that prefix each
+// finding with a module-relative Go file path ("<file>:<line>[:<col>]:
+// message", the shape go vet, gosec, and errcheck all report in): it
+// rewrites a leading "./foo.go" or "foo.go" to be modLabel-prefixed, leaving
+// lines that don't start with a .go path (continuation lines, blank lines)
+// unchanged.
+func rewriteLeadingGoPath(line, modLabel string) string {
+	trimmed := strings.TrimPrefix(line, "./")
+	idx := strings.Index(trimmed, ":")
+	if idx <= 0 {
+		return line
+	}
+	file := trimmed[:idx]
+	if !strings.HasSuffix(file, ".go") {
+		return line
+	}
+	return filepath.Join(modLabel, file) + trimmed[idx:]
+}
+
+// rewriteGoToolOutput splits output into non-blank lines, rewriting each
+// through rw (a nil rw passes lines through unchanged, for tools like
+// govulncheck whose report isn't one finding per line).
+func rewriteGoToolOutput(output string, modLabel string, rw PathRewriter) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+		if rw != nil {
+			line = rw(line, modLabel)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// GoModuleRunner runs one external command in every Go module under the repo
+// (as found by FindAllGoModules), aggregating each module's parsed output
+// into a single CheckResult. It's the loop RunIneffassign, RunMisspell,
+// RunStaticcheck, and RunGovulncheck used to hand-roll independently (each
+// with its own copy of the find-modules/run/collect-issues/count-and-summarize
+// steps); RunGoVet, RunGosec, and RunErrcheck are built directly on it.
+type GoModuleRunner struct {
+	// Name identifies the tool in the aggregate error message, e.g. "go vet".
+	Name string
+
+	// Command builds the command to run in modDir (Dir is set to modDir by
+	// Run before the command executes).
+	Command func(modDir string) *exec.Cmd
+
+	// Parse turns one module's combined stdout+stderr (and the command's
+	// exit error, nil on a clean exit) into that module's issue lines and
+	// how many CountNoun items it checked. A non-nil err return aborts the
+	// whole run, for when the module's command couldn't be evaluated at all
+	// (as opposed to merely reporting issues). modDir is the module's
+	// absolute directory (for counting files/packages); modLabel is its
+	// rootDir-relative path (for labeling issues and rewriting paths).
+	Parse func(output string, cmdErr error, modDir, modLabel string) (issues []string, count int, err error)
+
+	// CountNoun names what Parse's count measures, for the success summary
+	// ("42 packages checked, no issues"). Empty suppresses the count from
+	// the summary.
+	CountNoun string
+
+	// Diagnostics optionally parses one module's raw output into structured
+	// Diagnostics alongside the human-readable issues Parse returns (e.g.
+	// staticcheck's `-f json` output, which Parse also renders back into
+	// plain text lines for the error message). Left nil by tools with no
+	// structured output to offer, in which case the check simply reports no
+	// Diagnostics, same as before this field existed.
+	Diagnostics func(output, modDir, modLabel string) []Diagnostic
+}
+
+// moduleRunResult is one module's outcome from GoModuleRunner.Run, collected
+// by index so a worker pool can fill them in out of order while the caller
+// still reports them in FindAllGoModules's original order.
+type moduleRunResult struct {
+	modLabel    string
+	issues      []string
+	count       int
+	diagnostics []Diagnostic
+	err         error
+}
+
+// Run runs r.Command in every Go module FindAllGoModules finds under
+// ctx.RootDir, fanned out across a worker pool sized to runtime.NumCPU(),
+// aggregating r.Parse's issues and counts (and r.Diagnostics's findings, if
+// set) into one CheckResult. Results are reassembled in FindAllGoModules's
+// original order, so output stays deterministic regardless of which
+// module's command happens to finish first.
+func (r GoModuleRunner) Run(ctx *CheckContext) (CheckResult, error) {
+	allModules, err := FindAllGoModules(ctx.effectiveFS(), ".", DiscoveryOptions{})
+	if err != nil {
+		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
+	}
+
+	results := make([]moduleRunResult, len(allModules))
+	sem := make(chan struct{}, runtime.NumCPU())
+	var wg sync.WaitGroup
+	for i, mod := range allModules {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, mod ModuleInfo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = r.runModule(ctx.RootDir, mod)
+		}(i, mod)
+	}
+	wg.Wait()
+
+	var allIssues []string
+	var allDiagnostics []Diagnostic
+	total := 0
+	for _, res := range results {
+		if res.err != nil {
+			return CheckResult{}, fmt.Errorf("%s failed in %s: %w", r.Name, res.modLabel, res.err)
+		}
+		total += res.count
+		if len(res.issues) > 0 {
+			allIssues = append(allIssues, fmt.Sprintf("[%s]\n%s", res.modLabel, strings.Join(res.issues, "\n")))
+		}
+		allDiagnostics = append(allDiagnostics, res.diagnostics...)
+	}
+
+	if len(allIssues) > 0 {
+		return CheckResult{Diagnostics: allDiagnostics}, fmt.Errorf("%s found issues\n%s", r.Name, indentOutput(strings.Join(allIssues, "\n")))
+	}
+	if r.CountNoun != "" && total > 0 {
+		return CheckResult{Code: ResultSuccess, Message: fmt.Sprintf("%d %s checked, no issues", total, Pluralize(total, r.CountNoun, r.CountNoun+"s")), Diagnostics: allDiagnostics}, nil
+	}
+	return CheckResult{Code: ResultSuccess, Message: "No issues found", Diagnostics: allDiagnostics}, nil
+}
+
+// runModule runs r.Command in mod and parses its result, for one worker in
+// Run's pool.
+func (r GoModuleRunner) runModule(rootDir string, mod ModuleInfo) moduleRunResult {
+	modDir := filepath.Join(rootDir, mod.Dir)
+	modLabel := mod.Dir
+
+	cmd := r.Command(modDir)
+	cmd.Dir = modDir
+	output, cmdErr := RunCommand(cmd, true)
+
+	issues, count, err := r.Parse(output, cmdErr, modDir, modLabel)
+	if err != nil {
+		return moduleRunResult{modLabel: modLabel, err: err}
+	}
+	res := moduleRunResult{modLabel: modLabel, issues: issues, count: count}
+	if r.Diagnostics != nil {
+		res.diagnostics = r.Diagnostics(output, modDir, modLabel)
+	}
+	return res
+}
+
+// countGoPackages runs `go list ./...` in modDir and counts the lines it
+// prints, the package-counting convention already used by staticcheck and
+// nilaway's success summaries.
+func countGoPackages(modDir string) (int, error) {
+	cmd := exec.Command("go", "list", "./...")
+	cmd.Dir = modDir
+	output, err := RunCommand(cmd, true)
+	if err != nil {
+		return 0, err
+	}
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return 0, nil
+	}
+	return len(strings.Split(output, "\n")), nil
+}
@@ -0,0 +1,94 @@
+package checks
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeGoModule creates dir/go.mod under root, so FindAllGoModules discovers
+// it as a module.
+func writeGoModule(t *testing.T, root, dir string) {
+	t.Helper()
+	modDir := filepath.Join(root, dir)
+	if err := os.MkdirAll(modDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modDir, "go.mod"), []byte("module example\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGoModuleRunner_RunsEveryModuleAndReportsInDiscoveryOrder(t *testing.T) {
+	root := t.TempDir()
+	writeGoModule(t, root, "a")
+	writeGoModule(t, root, "b")
+	writeGoModule(t, root, "c")
+
+	ctx := &CheckContext{RootDir: root}
+	runner := GoModuleRunner{
+		Name: "echo",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command("echo", "ran")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			return nil, 1, nil
+		},
+		CountNoun: "module",
+	}
+
+	result, err := runner.Run(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Message != "3 modules checked, no issues" {
+		t.Errorf("unexpected message: %q", result.Message)
+	}
+}
+
+func TestGoModuleRunner_AggregatesIssuesLabeledByModule(t *testing.T) {
+	root := t.TempDir()
+	writeGoModule(t, root, "a")
+	writeGoModule(t, root, "b")
+
+	ctx := &CheckContext{RootDir: root}
+	runner := GoModuleRunner{
+		Name: "echo",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command("echo", "problem found")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			return []string{"problem found"}, 1, nil
+		},
+	}
+
+	_, err := runner.Run(ctx)
+	if err == nil {
+		t.Fatal("expected an error summarizing both modules' issues")
+	}
+	if !strings.Contains(err.Error(), "[a]") || !strings.Contains(err.Error(), "[b]") {
+		t.Errorf("expected both module labels in the error, got: %v", err)
+	}
+}
+
+func TestGoModuleRunner_ParseErrorAbortsTheRun(t *testing.T) {
+	root := t.TempDir()
+	writeGoModule(t, root, "a")
+
+	ctx := &CheckContext{RootDir: root}
+	runner := GoModuleRunner{
+		Name: "echo",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command("echo", "ran")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			return nil, 0, os.ErrInvalid
+		},
+	}
+
+	if _, err := runner.Run(ctx); err == nil {
+		t.Fatal("expected Parse's error to abort the run")
+	}
+}
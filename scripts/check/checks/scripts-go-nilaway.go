@@ -9,12 +9,19 @@ import (
 
 // RunNilaway detects potential nil pointer dereferences.
 func RunNilaway(ctx *CheckContext) (CheckResult, error) {
+	return CachedCheck(ctx, "nilaway", goSourceGlobs, goToolchainVersion, 0, func() (CheckResult, error) {
+		return runNilaway(ctx)
+	})
+}
+
+// runNilaway is RunNilaway's uncached implementation.
+func runNilaway(ctx *CheckContext) (CheckResult, error) {
 	nilawayBin, err := EnsureGoTool("nilaway", "go.uber.org/nilaway/cmd/nilaway@latest")
 	if err != nil {
 		return CheckResult{}, err
 	}
 
-	allModules, err := FindAllGoModules(ctx.RootDir)
+	allModules, err := FindAllGoModules(ctx.effectiveFS(), ".", DiscoveryOptions{})
 	if err != nil {
 		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
 	}
@@ -22,30 +29,26 @@ func RunNilaway(ctx *CheckContext) (CheckResult, error) {
 	var allIssues []string
 	pkgCount := 0
 
-	for goDir, modules := range allModules {
-		baseDir := filepath.Join(ctx.RootDir, goDir)
-		for _, mod := range modules {
-			modDir := filepath.Join(baseDir, mod)
-			modLabel := filepath.Join(goDir, mod)
-
-			// Count packages
-			listCmd := exec.Command("go", "list", "./...")
-			listCmd.Dir = modDir
-			listOutput, _ := RunCommand(listCmd, true)
-			if strings.TrimSpace(listOutput) != "" {
-				pkgCount += len(strings.Split(strings.TrimSpace(listOutput), "\n"))
-			}
+	for _, mod := range allModules {
+		modDir := filepath.Join(ctx.RootDir, mod.Dir)
+
+		// Count packages
+		listCmd := exec.Command("go", "list", "./...")
+		listCmd.Dir = modDir
+		listOutput, _ := RunCommand(listCmd, true)
+		if strings.TrimSpace(listOutput) != "" {
+			pkgCount += len(strings.Split(strings.TrimSpace(listOutput), "\n"))
+		}
 
-			cmd := exec.Command(nilawayBin, "./...")
-			cmd.Dir = modDir
-			output, err := RunCommand(cmd, true)
-			if err != nil {
-				issueText := strings.TrimSpace(output)
-				if issueText == "" {
-					issueText = err.Error()
-				}
-				allIssues = append(allIssues, fmt.Sprintf("[%s]\n%s", modLabel, issueText))
+		cmd := exec.Command(nilawayBin, "./...")
+		cmd.Dir = modDir
+		output, err := RunCommand(cmd, true)
+		if err != nil {
+			issueText := strings.TrimSpace(output)
+			if issueText == "" {
+				issueText = err.Error()
 			}
+			allIssues = append(allIssues, fmt.Sprintf("[%s]\n%s", mod.Dir, issueText))
 		}
 	}
 
@@ -0,0 +1,89 @@
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// staticcheckFinding mirrors one line of staticcheck's `-f json` output (one
+// JSON object per finding; undocumented but stable shape used by staticcheck
+// itself and by golangci-lint's staticcheck wrapper).
+type staticcheckFinding struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Location struct {
+		File   string `json:"file"`
+		Line   int    `json:"line"`
+		Column int    `json:"column"`
+	} `json:"location"`
+	End struct {
+		Line   int `json:"line"`
+		Column int `json:"column"`
+	} `json:"end"`
+	Message string `json:"message"`
+}
+
+// renderStaticcheckOutput reassembles `-f json` output into the
+// "file:line:col: message (CODE)" lines staticcheck's plain-text mode would
+// have printed, for the issue list in the check's error message — otherwise
+// indentOutput would just indent raw JSON, same problem renderClippyOutput
+// solves for clippy.
+func renderStaticcheckOutput(output, modLabel string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var f staticcheckFinding
+		if err := json.Unmarshal([]byte(line), &f); err != nil {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s:%d:%d: %s (%s)",
+			filepath.Join(modLabel, f.Location.File), f.Location.Line, f.Location.Column, f.Message, f.Code))
+	}
+	return lines
+}
+
+// staticcheckHelpURI returns staticcheck's own docs page for a check code
+// (e.g. "SA4006"), or "" for a bare "ST"-less/unrecognized code with no
+// page of its own.
+func staticcheckHelpURI(code string) string {
+	if code == "" {
+		return ""
+	}
+	return "https://staticcheck.dev/docs/checks/#" + code
+}
+
+// parseStaticcheckDiagnostics parses one module's `-f json` output into
+// Diagnostics, rewriting each finding's module-relative file to be
+// modLabel-prefixed, matching every other aggregated-across-modules check's
+// convention (see rewriteLeadingGoPath).
+func parseStaticcheckDiagnostics(output, modLabel string) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] != '{' {
+			continue
+		}
+		var f staticcheckFinding
+		if err := json.Unmarshal([]byte(line), &f); err != nil {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     filepath.Join(modLabel, f.Location.File),
+			Line:     f.Location.Line,
+			Col:      f.Location.Column,
+			EndLine:  f.End.Line,
+			EndCol:   f.End.Column,
+			Rule:     f.Code,
+			Message:  f.Message,
+			Severity: f.Severity,
+			Tool:     "staticcheck",
+			HelpURI:  staticcheckHelpURI(f.Code),
+		})
+	}
+	return diagnostics
+}
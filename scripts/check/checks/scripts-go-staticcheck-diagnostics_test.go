@@ -0,0 +1,50 @@
+package checks
+
+import "testing"
+
+func TestParseStaticcheckDiagnostics_ParsesFinding(t *testing.T) {
+	output := `{"code":"SA4006","severity":"error","location":{"file":"foo.go","line":10,"column":2},"end":{"line":10,"column":9},"message":"this value of x is never used"}`
+
+	got := parseStaticcheckDiagnostics(output, "scripts/check")
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %+v", len(got), got)
+	}
+	d := got[0]
+	if d.File != "scripts/check/foo.go" {
+		t.Errorf("expected File prefixed with modLabel, got %q", d.File)
+	}
+	if d.Line != 10 || d.Col != 2 || d.EndLine != 10 || d.EndCol != 9 {
+		t.Errorf("unexpected location: %+v", d)
+	}
+	if d.Rule != "SA4006" {
+		t.Errorf("expected Rule SA4006, got %q", d.Rule)
+	}
+	if d.Tool != "staticcheck" {
+		t.Errorf("expected Tool staticcheck, got %q", d.Tool)
+	}
+	if d.HelpURI != "https://staticcheck.dev/docs/checks/#SA4006" {
+		t.Errorf("unexpected HelpURI: %q", d.HelpURI)
+	}
+}
+
+func TestParseStaticcheckDiagnostics_SkipsMalformedLines(t *testing.T) {
+	output := "not json at all\n" + `{"code":"SA1000","severity":"error","location":{"file":"a.go","line":1,"column":1},"end":{"line":1,"column":1},"message":"x"}`
+
+	got := parseStaticcheckDiagnostics(output, "scripts/check")
+
+	if len(got) != 1 {
+		t.Errorf("expected the malformed line to be skipped and the valid one parsed, got %+v", got)
+	}
+}
+
+func TestRenderStaticcheckOutput_FormatsAsFileLineColMessage(t *testing.T) {
+	output := `{"code":"SA4006","severity":"error","location":{"file":"foo.go","line":10,"column":2},"end":{"line":10,"column":9},"message":"this value of x is never used"}`
+
+	got := renderStaticcheckOutput(output, "scripts/check")
+
+	want := "scripts/check/foo.go:10:2: this value of x is never used (SA4006)"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("expected %q, got %+v", want, got)
+	}
+}
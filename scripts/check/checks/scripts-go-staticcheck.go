@@ -1,57 +1,59 @@
 package checks
 
 import (
-	"fmt"
 	"os/exec"
-	"path/filepath"
-	"strings"
+
+	"vmail/scripts/check/checks/toolchain"
 )
 
-// RunStaticcheck runs staticcheck for static analysis.
+// RunStaticcheck runs staticcheck for static analysis, pinned to the
+// version tools.lock records (see the toolchain package) rather than
+// whatever @latest resolved to last time someone ran it.
 func RunStaticcheck(ctx *CheckContext) (CheckResult, error) {
-	scriptsDir := filepath.Join(ctx.RootDir, "scripts")
-
-	// Ensure staticcheck is installed
-	if !CommandExists("staticcheck") {
-		installCmd := exec.Command("go", "install", "honnef.co/go/tools/cmd/staticcheck@latest")
-		if _, err := RunCommand(installCmd, true); err != nil {
-			return CheckResult{}, fmt.Errorf("failed to install staticcheck: %w", err)
-		}
-	}
+	return CachedCheck(ctx, "staticcheck", goSourceGlobs, func() string { return staticcheckVersion(ctx) }, 0, func() (CheckResult, error) {
+		return runStaticcheck(ctx)
+	})
+}
 
-	modules, err := FindGoModules(scriptsDir)
+// staticcheckVersion returns the version tools.lock pins staticcheck to, so
+// a tools.lock bump (which FindAllGoModules' goSourceGlobs fingerprint never
+// sees, since tools.lock isn't a .go file) still busts the cache.
+func staticcheckVersion(ctx *CheckContext) string {
+	lock, err := toolchain.LoadLock(ctx.RootDir)
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
+		return ""
 	}
+	return lock["staticcheck"]
+}
 
-	var allIssues []string
-	pkgCount := 0
-
-	for _, mod := range modules {
-		modDir := filepath.Join(scriptsDir, mod)
-
-		// Count packages
-		listCmd := exec.Command("go", "list", "./...")
-		listCmd.Dir = modDir
-		listOutput, _ := RunCommand(listCmd, true)
-		if strings.TrimSpace(listOutput) != "" {
-			pkgCount += len(strings.Split(strings.TrimSpace(listOutput), "\n"))
-		}
-
-		cmd := exec.Command("staticcheck", "./...")
-		cmd.Dir = modDir
-		output, err := RunCommand(cmd, true)
-		if err != nil {
-			allIssues = append(allIssues, fmt.Sprintf("[%s]\n%s", mod, output))
-		}
+// runStaticcheck is RunStaticcheck's uncached implementation.
+func runStaticcheck(ctx *CheckContext) (CheckResult, error) {
+	staticcheckBin, err := toolchain.Ensure(ctx.RootDir, "staticcheck")
+	if err != nil {
+		return CheckResult{}, err
 	}
 
-	if len(allIssues) > 0 {
-		return CheckResult{}, fmt.Errorf("staticcheck found issues\n%s", indentOutput(strings.Join(allIssues, "\n")))
+	runner := GoModuleRunner{
+		Name: "staticcheck",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command(staticcheckBin, "-f", "json", "./...")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			count, _ := countGoPackages(modDir)
+			if cmdErr == nil {
+				return nil, count, nil
+			}
+			issues := renderStaticcheckOutput(output, modLabel)
+			if len(issues) == 0 {
+				issues = []string{cmdErr.Error()}
+			}
+			return issues, count, nil
+		},
+		CountNoun: "package",
+		Diagnostics: func(output, modDir, modLabel string) []Diagnostic {
+			return parseStaticcheckDiagnostics(output, modLabel)
+		},
 	}
 
-	if pkgCount > 0 {
-		return Success(fmt.Sprintf("%d %s checked, no issues", pkgCount, Pluralize(pkgCount, "package", "packages"))), nil
-	}
-	return Success("No issues found"), nil
+	return runner.Run(ctx)
 }
@@ -10,9 +10,16 @@ import (
 
 // RunGoTests runs Go tests.
 func RunGoTests(ctx *CheckContext) (CheckResult, error) {
+	return CachedCheck(ctx, "go-tests", goTestInputGlobs, goToolchainVersion, 0, func() (CheckResult, error) {
+		return runGoTests(ctx)
+	})
+}
+
+// runGoTests is RunGoTests' uncached implementation.
+func runGoTests(ctx *CheckContext) (CheckResult, error) {
 	scriptsDir := filepath.Join(ctx.RootDir, "scripts")
 
-	modules, err := FindGoModules(scriptsDir)
+	modules, err := FindAllGoModules(ctx.effectiveFS(), "scripts", DiscoveryOptions{})
 	if err != nil {
 		return CheckResult{}, fmt.Errorf("failed to find Go modules: %w", err)
 	}
@@ -21,13 +28,13 @@ func RunGoTests(ctx *CheckContext) (CheckResult, error) {
 	pkgCount := 0
 
 	for _, mod := range modules {
-		modDir := filepath.Join(scriptsDir, mod)
+		modDir := filepath.Join(scriptsDir, mod.Dir)
 
 		cmd := exec.Command("go", "test", "./...")
 		cmd.Dir = modDir
 		output, err := RunCommand(cmd, true)
 		if err != nil {
-			allFailures = append(allFailures, fmt.Sprintf("[%s]\n%s", mod, output))
+			allFailures = append(allFailures, fmt.Sprintf("[%s]\n%s", mod.Dir, output))
 			continue
 		}
 
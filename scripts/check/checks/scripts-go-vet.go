@@ -0,0 +1,27 @@
+package checks
+
+import "os/exec"
+
+// RunGoVet runs `go vet` across every Go module in the repo.
+func RunGoVet(ctx *CheckContext) (CheckResult, error) {
+	runner := GoModuleRunner{
+		Name: "go vet",
+		Command: func(modDir string) *exec.Cmd {
+			return exec.Command("go", "vet", "./...")
+		},
+		Parse: func(output string, cmdErr error, modDir, modLabel string) ([]string, int, error) {
+			count, _ := countGoPackages(modDir)
+			if cmdErr == nil {
+				return nil, count, nil
+			}
+			issues := rewriteGoToolOutput(output, modLabel, rewriteLeadingGoPath)
+			if len(issues) == 0 {
+				issues = []string{cmdErr.Error()}
+			}
+			return issues, count, nil
+		},
+		CountNoun: "package",
+	}
+
+	return runner.Run(ctx)
+}
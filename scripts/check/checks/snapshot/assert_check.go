@@ -0,0 +1,27 @@
+package snapshot
+
+import "testing"
+
+// AssertCheck golden-tests the (message, err) pair a checks.Run* function's
+// result boils down to, the way a test would otherwise assert on
+// result.Message or err.Error() with brittle strings.Contains calls. It
+// renders the pair the same way the CLI does — err's text when non-nil,
+// message otherwise — normalizes it against rootDir (pass the same RootDir
+// the CheckContext was given), and compares it to goldenPath via
+// AssertMatches.
+//
+// AssertCheck takes message rather than a checks.CheckResult directly:
+// snapshot can't import the checks package, since checks' own _test.go
+// files import snapshot — that round trip is the import cycle Go's test
+// tooling rejects. Callers pass result.Message straight through, e.g.
+// snapshot.AssertCheck(t, root, result.Message, err, "testdata/...").
+func AssertCheck(t *testing.T, rootDir, message string, err error, goldenPath string) {
+	t.Helper()
+
+	got := message
+	if err != nil {
+		got = err.Error()
+	}
+
+	AssertMatches(t, Normalize(got, rootDir), goldenPath)
+}
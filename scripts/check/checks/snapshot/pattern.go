@@ -0,0 +1,49 @@
+package snapshot
+
+import (
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// placeholderToken is one [TOKEN] a golden file's text may contain, along
+// with the regex fragment it expands to once the golden's literal text has
+// been escaped. [ROOT] and [CWD] aren't listed here — Normalize already
+// rewrites a check's output to contain those literal strings, so once
+// escaped they're matched as plain text like everything else in the golden
+// file.
+var placeholderTokens = []struct {
+	token   string
+	pattern string
+}{
+	{"[..]", `[^\n]*?`},
+	{"[N]", `[1-9][0-9]*`},
+	{"[EXE]", exeSuffixPattern()},
+}
+
+// exeSuffixPattern returns the regex fragment [EXE] expands to for the
+// platform running the test, rather than a wildcard — a check's own output
+// is expected to contain exactly ".exe" on Windows and nothing elsewhere,
+// not "maybe either".
+func exeSuffixPattern() string {
+	if runtime.GOOS == "windows" {
+		return regexp.QuoteMeta(".exe")
+	}
+	return ""
+}
+
+// compilePattern turns golden (a golden file's text, placeholders and all)
+// into a regexp matching the exact text it describes, anchored to the
+// whole string so a golden file can't accidentally match a prefix/suffix of
+// a much longer, wrong output.
+func compilePattern(golden string) (*regexp.Regexp, error) {
+	escaped := regexp.QuoteMeta(golden)
+	for _, p := range placeholderTokens {
+		// p.token's escaped form (e.g. "[..]" -> `\[\.\.\]`) is what actually
+		// appears as a substring of escaped, since golden as a whole was
+		// just run through QuoteMeta above — a plain, literal substring
+		// replacement, not another regex search, is what's needed to find it.
+		escaped = strings.ReplaceAll(escaped, regexp.QuoteMeta(p.token), p.pattern)
+	}
+	return regexp.Compile("^" + escaped + "$")
+}
@@ -0,0 +1,102 @@
+// Package snapshot golden-file tests a check's output without the test
+// having to sprintf-assert every path, count, and pluralized noun a check
+// like RunCfgGate embeds in its Message/error text. A golden file is a
+// plain text fixture under testdata/ compared against the check's
+// (normalized) output, with a handful of placeholder tokens standing in for
+// the parts that legitimately vary from run to run or machine to machine:
+//
+//	[..]   0+ characters, non-greedy, matched within a single line
+//	[ROOT] the repo root path (see Normalize)
+//	[CWD]  the working directory (see Normalize)
+//	[N]    any positive integer, e.g. a violation count
+//	[EXE]  ".exe" on Windows, empty everywhere else
+//
+// Run tests with UPDATE=1 to (re)write every golden file a failing
+// AssertMatches/AssertCheck call compares against, the same update flow
+// locreport.Save's callers use for loc-count snapshots.
+package snapshot
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// updateEnvVar is the env var that switches AssertMatches/AssertCheck from
+// comparing to regenerating goldens.
+const updateEnvVar = "UPDATE"
+
+// Normalize rewrites s so it's stable across machines and runs: backslashes
+// collapse to forward slashes (so a golden file written on Windows reads
+// identically on Linux/macOS), then any absolute path under rootDir is
+// replaced with "[ROOT]", and any absolute path under the process's current
+// working directory is replaced with "[CWD]". rootDir may be "" for a check
+// that doesn't take one (e.g. one CheckContext.RootDir wasn't set for).
+func Normalize(s, rootDir string) string {
+	s = strings.ReplaceAll(s, "\\", "/")
+
+	if rootDir != "" {
+		root := strings.TrimSuffix(strings.ReplaceAll(rootDir, "\\", "/"), "/")
+		s = strings.ReplaceAll(s, root, "[ROOT]")
+	}
+
+	if cwd, err := os.Getwd(); err == nil {
+		cwd = strings.TrimSuffix(strings.ReplaceAll(cwd, "\\", "/"), "/")
+		s = strings.ReplaceAll(s, cwd, "[CWD]")
+	}
+
+	return s
+}
+
+// AssertMatches compares got against the golden file at goldenPath, after
+// resolving goldenPath's placeholder tokens (see the package doc) into a
+// pattern. got is compared as-is — callers normally pass it through
+// Normalize first (see AssertCheck, which does this for a checks.Run*
+// result/err pair automatically).
+//
+// With UPDATE=1 set, goldenPath is (over)written with got instead of being
+// compared against, mirroring locreport.Save's update flow; goldenPath's
+// parent directory is created if missing.
+func AssertMatches(t *testing.T, got, goldenPath string) {
+	t.Helper()
+
+	if os.Getenv(updateEnvVar) != "" {
+		if err := writeGolden(goldenPath, got); err != nil {
+			t.Fatalf("failed to update golden file %s: %v", goldenPath, err)
+		}
+		return
+	}
+
+	golden, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("failed to read golden file %s (run with UPDATE=1 to create it): %v", goldenPath, err)
+	}
+
+	want := strings.TrimSuffix(string(golden), "\n")
+	got = strings.TrimSuffix(got, "\n")
+
+	re, err := compilePattern(want)
+	if err != nil {
+		t.Fatalf("golden file %s has an invalid placeholder pattern: %v", goldenPath, err)
+	}
+	if !re.MatchString(got) {
+		t.Errorf("output doesn't match golden file %s (run with UPDATE=1 to accept this change if it's expected)\n--- want (pattern) ---\n%s\n--- got ---\n%s", goldenPath, want, got)
+	}
+}
+
+func writeGolden(goldenPath, content string) error {
+	if dir := goldenDir(goldenPath); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(goldenPath, []byte(strings.TrimSuffix(content, "\n")+"\n"), 0644)
+}
+
+func goldenDir(goldenPath string) string {
+	idx := strings.LastIndexByte(goldenPath, '/')
+	if idx < 0 {
+		return ""
+	}
+	return goldenPath[:idx]
+}
@@ -0,0 +1,93 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalize_RewritesRootDirAndBackslashes(t *testing.T) {
+	got := Normalize(`C:\repo\apps\desktop\src-tauri\src\lib.rs`, `C:\repo`)
+	want := "[ROOT]/apps/desktop/src-tauri/src/lib.rs"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestNormalize_EmptyRootDirLeavesPathsAlone(t *testing.T) {
+	got := Normalize("/tmp/TestFoo123/lib.rs", "")
+	if got != "/tmp/TestFoo123/lib.rs" {
+		t.Errorf("expected no rewrite without a rootDir, got %q", got)
+	}
+}
+
+func TestCompilePattern_DotDotMatchesWithinOneLineOnly(t *testing.T) {
+	re, err := compilePattern("found [..] issues")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString("found 3 ungated macOS issues") {
+		t.Error("expected [..] to match text on the same line")
+	}
+	if re.MatchString("found 3 ungated\nmacOS issues") {
+		t.Error("expected [..] to not match across a newline")
+	}
+}
+
+func TestCompilePattern_NMatchesOnlyPositiveIntegers(t *testing.T) {
+	re, err := compilePattern("[N] gated uses")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"1 gated uses", "42 gated uses"} {
+		if !re.MatchString(s) {
+			t.Errorf("expected %q to match", s)
+		}
+	}
+	for _, s := range []string{"0 gated uses", "-1 gated uses", "gated uses"} {
+		if re.MatchString(s) {
+			t.Errorf("expected %q not to match (not a positive integer)", s)
+		}
+	}
+}
+
+func TestCompilePattern_LiteralTextIsEscaped(t *testing.T) {
+	re, err := compilePattern(`found 1 use of foo.rs (cfg-gated)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !re.MatchString(`found 1 use of foo.rs (cfg-gated)`) {
+		t.Error("expected the literal golden text to match itself")
+	}
+	if re.MatchString(`found 1 use of fooXrs (cfg-gated)`) {
+		t.Error("expected the literal '.' in foo.rs to not act as a regex wildcard")
+	}
+}
+
+func TestAssertMatches_UpdateWritesGolden(t *testing.T) {
+	dir := t.TempDir()
+	goldenPath := filepath.Join(dir, "nested", "example.txt")
+
+	t.Setenv("UPDATE", "1")
+	// Under UPDATE, AssertMatches writes got and returns without comparing,
+	// so this must pass even though no golden file exists yet at goldenPath.
+	AssertMatches(t, "hello world\n", goldenPath)
+
+	data, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("expected UPDATE=1 to create %s: %v", goldenPath, err)
+	}
+	if string(data) != "hello world\n" {
+		t.Errorf("got %q, want %q", string(data), "hello world\n")
+	}
+}
+
+func TestAssertMatches_ComparesAgainstExistingGolden(t *testing.T) {
+	dir := t.TempDir()
+	goldenPath := filepath.Join(dir, "example.txt")
+	if err := os.WriteFile(goldenPath, []byte("found [N] issues in [..]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	AssertMatches(t, "found 3 issues in apps/desktop/src/lib.rs", goldenPath)
+}
@@ -0,0 +1,298 @@
+package checks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// suppressionsFileName is where RunPnpmAudit looks for accepted-risk
+// entries, alongside .cmdr/tools/ (see checks/toolchain) rather than at the
+// repo root like cmdr.toml — it's workspace-local bookkeeping, not a
+// project-wide behavior override.
+const suppressionsFileName = ".cmdr/audit-suppressions.toml"
+
+// suppressionDateFormat is the expected shape of a Suppression's Expires
+// field: a plain date, since a suppression is reviewed by calendar day, not
+// wall-clock time.
+const suppressionDateFormat = "2006-01-02"
+
+// Suppression is one accepted-risk entry: "we know about this advisory,
+// here's why we're not fixing it yet, and here's when that stops being
+// true." Module+VulnerableVersions (rather than just Module) scopes a
+// suppression to the specific advisory it was written for, so it doesn't
+// silently swallow an unrelated future advisory against the same package
+// once the original one is patched. AdvisoryURL, if set, is matched
+// instead and takes priority, since it names one advisory unambiguously.
+type Suppression struct {
+	Module             string `toml:"module"`
+	AdvisoryURL        string `toml:"advisory_url"`
+	VulnerableVersions string `toml:"vulnerable_versions"`
+	Reason             string `toml:"reason"`
+	Expires            string `toml:"expires"`
+}
+
+// suppressionsFile is the on-disk shape of .cmdr/audit-suppressions.toml:
+// an array of tables under [[suppressions]], the same layout `cmdr.toml`
+// uses for [[filelength.overrides]]-style lists. Unlike config.Config,
+// decoding straight into a typed struct is the right call here — this
+// file's shape is a fixed list of records, not the arbitrary per-extension
+// tables that forced config.go to navigate an untyped map.
+type suppressionsFile struct {
+	Suppressions []Suppression `toml:"suppressions"`
+}
+
+// LoadSuppressions reads .cmdr/audit-suppressions.toml from rootDir. A
+// missing file is not an error — it's treated as no suppressions, which is
+// what a repo that's never needed to accept a risk looks like.
+func LoadSuppressions(rootDir string) ([]Suppression, error) {
+	path := filepath.Join(rootDir, suppressionsFileName)
+	var f suppressionsFile
+	if _, err := toml.DecodeFile(path, &f); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to parse %s: %w", suppressionsFileName, err)
+	}
+	return f.Suppressions, nil
+}
+
+// applySuppressions filters suppressed advisories out of advisories,
+// returning the ones that still apply plus how many were suppressed. It
+// errors out — rather than silently accepting the risk — for any
+// suppression that's expired as of now, or that matches none of the current
+// advisories (a stale entry: the advisory it was written for has since been
+// patched, so it's dead weight that should be deleted, not left to quietly
+// cover whatever replaces it).
+func applySuppressions(advisories map[string]Advisory, suppressions []Suppression, now time.Time) (map[string]Advisory, int, error) {
+	kept := make(map[string]Advisory, len(advisories))
+	for id, adv := range advisories {
+		kept[id] = adv
+	}
+
+	var problems []string
+	suppressedCount := 0
+
+	for _, s := range suppressions {
+		expires, err := time.Parse(suppressionDateFormat, s.Expires)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid expires date %q (want YYYY-MM-DD)", suppressionLabel(s), s.Expires))
+			continue
+		}
+		if !now.Before(expires) {
+			problems = append(problems, fmt.Sprintf("%s: expired on %s, renew or remove it", suppressionLabel(s), s.Expires))
+			continue
+		}
+
+		matched := false
+		for id, adv := range kept {
+			if !suppressionMatches(s, adv) {
+				continue
+			}
+			matched = true
+			delete(kept, id)
+			suppressedCount++
+		}
+		if !matched {
+			problems = append(problems, fmt.Sprintf("%s: no longer matches an active advisory, remove it", suppressionLabel(s)))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, 0, fmt.Errorf("%s in %s:\n%s", Pluralize(len(problems), "stale suppression", "stale suppressions"), suppressionsFileName, strings.Join(problems, "\n"))
+	}
+	return kept, suppressedCount, nil
+}
+
+// suppressionLabel identifies a Suppression in an error message.
+func suppressionLabel(s Suppression) string {
+	if s.AdvisoryURL != "" {
+		return s.AdvisoryURL
+	}
+	return s.Module
+}
+
+// suppressionMatches reports whether s covers adv: an AdvisoryURL names one
+// advisory directly, while Module+VulnerableVersions matches by package plus
+// an overlapping semver range, so a suppression written against
+// ">=4.0.0 <4.17.12" still applies if pnpm later reports the same advisory
+// with an equivalent but differently-formatted range.
+func suppressionMatches(s Suppression, adv Advisory) bool {
+	if s.AdvisoryURL != "" {
+		return s.AdvisoryURL == adv.URL
+	}
+	if s.Module != adv.ModuleName {
+		return false
+	}
+	if s.VulnerableVersions == "" {
+		return true
+	}
+	return semverRangesOverlap(s.VulnerableVersions, adv.VulnerableVersions)
+}
+
+// semverVersion is a parsed Major.Minor.Patch version; pnpm audit's
+// VulnerableVersions ranges never carry prerelease or build metadata, so
+// that's all a comparator needs.
+type semverVersion struct {
+	major, minor, patch int
+}
+
+// parseSemverVersion parses a bare version like "4.17.21", tolerating a
+// leading "v" and ignoring anything from a "-" prerelease suffix onward.
+// Missing trailing components (e.g. "4.17") default to 0.
+func parseSemverVersion(s string) (semverVersion, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" {
+		return semverVersion{}, false
+	}
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	component := func(i int) int {
+		if i >= len(parts) {
+			return 0
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return semverVersion{major: component(0), minor: component(1), patch: component(2)}, true
+}
+
+// compare returns -1, 0, or 1 as v is less than, equal to, or greater than o.
+func (v semverVersion) compare(o semverVersion) int {
+	switch {
+	case v.major != o.major:
+		return signOf(v.major - o.major)
+	case v.minor != o.minor:
+		return signOf(v.minor - o.minor)
+	default:
+		return signOf(v.patch - o.patch)
+	}
+}
+
+func signOf(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// semverBound is one side of an AND-combined range, e.g. the ">=4.0.0" half
+// of ">=4.0.0 <4.17.12". unbounded means no constraint was given on this
+// side (e.g. a bare "<4.17.12" range has no lower bound).
+type semverBound struct {
+	version   semverVersion
+	inclusive bool
+	unbounded bool
+}
+
+// parseSemverRange parses an npm-style comparator range (space-separated
+// comparators are AND'd together; pnpm audit's VulnerableVersions doesn't
+// use npm's "||" OR syntax, so that's intentionally not supported here) into
+// a lower and upper bound.
+func parseSemverRange(rangeStr string) (lower, upper semverBound, err error) {
+	lower = semverBound{unbounded: true}
+	upper = semverBound{unbounded: true}
+
+	fields := strings.Fields(rangeStr)
+	if len(fields) == 0 {
+		return lower, upper, fmt.Errorf("empty version range")
+	}
+
+	for _, field := range fields {
+		op, rest, ok := cutSemverOperator(field)
+		if !ok {
+			return lower, upper, fmt.Errorf("unrecognized version comparator %q", field)
+		}
+		v, ok := parseSemverVersion(rest)
+		if !ok {
+			return lower, upper, fmt.Errorf("invalid version %q", rest)
+		}
+		switch op {
+		case ">=", ">":
+			lower = semverBound{version: v, inclusive: op == ">="}
+		case "<=", "<":
+			upper = semverBound{version: v, inclusive: op == "<="}
+		case "=":
+			lower = semverBound{version: v, inclusive: true}
+			upper = semverBound{version: v, inclusive: true}
+		}
+	}
+	return lower, upper, nil
+}
+
+// cutSemverOperator splits a comparator token like ">=4.0.0" into its
+// operator and version, defaulting to "=" for a bare version with no
+// operator prefix.
+func cutSemverOperator(field string) (op, rest string, ok bool) {
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if rest, found := strings.CutPrefix(field, candidate); found {
+			return candidate, rest, true
+		}
+	}
+	if _, valid := parseSemverVersion(field); valid {
+		return "=", field, true
+	}
+	return "", "", false
+}
+
+// semverRangesOverlap reports whether any version could satisfy both a and
+// b, by intersecting their lower/upper bounds. A range that fails to parse
+// (unexpected syntax neither range format has been seen using) is treated
+// as not overlapping, so a malformed suppression range is reported as stale
+// rather than silently matching everything.
+func semverRangesOverlap(a, b string) bool {
+	aLower, aUpper, err := parseSemverRange(a)
+	if err != nil {
+		return false
+	}
+	bLower, bUpper, err := parseSemverRange(b)
+	if err != nil {
+		return false
+	}
+
+	lower := aLower
+	lowerInclusive := aLower.inclusive
+	if !bLower.unbounded && (aLower.unbounded || bLower.version.compare(aLower.version) > 0) {
+		lower = bLower
+		lowerInclusive = bLower.inclusive
+	} else if !aLower.unbounded && !bLower.unbounded && bLower.version.compare(aLower.version) == 0 {
+		lowerInclusive = aLower.inclusive && bLower.inclusive
+	}
+
+	upper := aUpper
+	upperInclusive := aUpper.inclusive
+	if !bUpper.unbounded && (aUpper.unbounded || bUpper.version.compare(aUpper.version) < 0) {
+		upper = bUpper
+		upperInclusive = bUpper.inclusive
+	} else if !aUpper.unbounded && !bUpper.unbounded && bUpper.version.compare(aUpper.version) == 0 {
+		upperInclusive = aUpper.inclusive && bUpper.inclusive
+	}
+
+	if lower.unbounded || upper.unbounded {
+		return true
+	}
+	switch lower.version.compare(upper.version) {
+	case -1:
+		return true
+	case 0:
+		return lowerInclusive && upperInclusive
+	default:
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+package checks
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParseDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	tm, err := time.Parse(suppressionDateFormat, s)
+	if err != nil {
+		t.Fatalf("invalid test date %q: %v", s, err)
+	}
+	return tm
+}
+
+func TestApplySuppressions_FiltersMatchingNonExpiredEntry(t *testing.T) {
+	advisories := map[string]Advisory{
+		"1": {ModuleName: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", URL: "https://example.com/adv/1"},
+	}
+	suppressions := []Suppression{
+		{Module: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", Reason: "not exploitable", Expires: "2099-01-01"},
+	}
+
+	kept, suppressedCount, err := applySuppressions(advisories, suppressions, mustParseDate(t, "2026-01-01"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if suppressedCount != 1 {
+		t.Errorf("expected 1 suppressed, got %d", suppressedCount)
+	}
+	if len(kept) != 0 {
+		t.Errorf("expected the advisory to be filtered out, got %+v", kept)
+	}
+}
+
+func TestApplySuppressions_MatchesByAdvisoryURL(t *testing.T) {
+	advisories := map[string]Advisory{
+		"1": {ModuleName: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", URL: "https://example.com/adv/1"},
+	}
+	suppressions := []Suppression{
+		{AdvisoryURL: "https://example.com/adv/1", Reason: "accepted", Expires: "2099-01-01"},
+	}
+
+	kept, suppressedCount, err := applySuppressions(advisories, suppressions, mustParseDate(t, "2026-01-01"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if suppressedCount != 1 || len(kept) != 0 {
+		t.Errorf("expected the advisory suppressed by URL, got kept=%+v suppressedCount=%d", kept, suppressedCount)
+	}
+}
+
+func TestApplySuppressions_ExpiredEntryFailsTheCheck(t *testing.T) {
+	advisories := map[string]Advisory{
+		"1": {ModuleName: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", URL: "https://example.com/adv/1"},
+	}
+	suppressions := []Suppression{
+		{Module: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", Reason: "not exploitable", Expires: "2025-01-01"},
+	}
+
+	_, _, err := applySuppressions(advisories, suppressions, mustParseDate(t, "2026-01-01"))
+	if err == nil {
+		t.Fatal("expected an error for an expired suppression")
+	}
+	if !strings.Contains(err.Error(), "expired") {
+		t.Errorf("expected error to mention expiry, got %q", err.Error())
+	}
+}
+
+func TestApplySuppressions_StaleEntryFailsTheCheck(t *testing.T) {
+	advisories := map[string]Advisory{
+		"1": {ModuleName: "lodash", VulnerableVersions: ">=5.0.0 <5.1.0", URL: "https://example.com/adv/1"},
+	}
+	suppressions := []Suppression{
+		// Written for a since-patched advisory; the current advisory's
+		// VulnerableVersions no longer overlaps this one.
+		{Module: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", Reason: "not exploitable", Expires: "2099-01-01"},
+	}
+
+	_, _, err := applySuppressions(advisories, suppressions, mustParseDate(t, "2026-01-01"))
+	if err == nil {
+		t.Fatal("expected an error for a stale suppression")
+	}
+	if !strings.Contains(err.Error(), "no longer matches") {
+		t.Errorf("expected error to mention the stale match, got %q", err.Error())
+	}
+}
+
+func TestApplySuppressions_InvalidExpiresDateFailsTheCheck(t *testing.T) {
+	advisories := map[string]Advisory{
+		"1": {ModuleName: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", URL: "https://example.com/adv/1"},
+	}
+	suppressions := []Suppression{
+		{Module: "lodash", VulnerableVersions: ">=4.0.0 <4.17.12", Expires: "not-a-date"},
+	}
+
+	_, _, err := applySuppressions(advisories, suppressions, mustParseDate(t, "2026-01-01"))
+	if err == nil {
+		t.Fatal("expected an error for an unparseable expires date")
+	}
+}
+
+func TestSemverRangesOverlap_OverlappingRangesMatch(t *testing.T) {
+	if !semverRangesOverlap(">=4.0.0 <4.17.12", ">=4.10.0 <5.0.0") {
+		t.Error("expected overlapping ranges to match")
+	}
+}
+
+func TestSemverRangesOverlap_DisjointRangesDoNotMatch(t *testing.T) {
+	if semverRangesOverlap(">=4.0.0 <4.17.12", ">=5.0.0 <5.1.0") {
+		t.Error("expected disjoint ranges not to match")
+	}
+}
+
+func TestSemverRangesOverlap_ExclusiveBoundariesDoNotMatch(t *testing.T) {
+	if semverRangesOverlap(">=1.0.0 <2.0.0", ">=2.0.0 <3.0.0") {
+		t.Error("expected ranges touching only at an exclusive boundary not to match")
+	}
+}
+
+func TestSemverRangesOverlap_BareVersionMatchesContainingRange(t *testing.T) {
+	if !semverRangesOverlap("4.17.10", ">=4.0.0 <4.17.12") {
+		t.Error("expected a bare version within the range to match")
+	}
+}
+
+func TestSemverRangesOverlap_MalformedRangeDoesNotMatch(t *testing.T) {
+	if semverRangesOverlap("not a range", ">=4.0.0 <5.0.0") {
+		t.Error("expected a malformed range to be treated as non-overlapping, not as matching everything")
+	}
+}
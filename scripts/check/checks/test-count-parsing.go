@@ -0,0 +1,63 @@
+package checks
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// playwrightPassedCountPattern matches the "N passed" Playwright's default
+// reporter prints in its summary line.
+var playwrightPassedCountPattern = regexp.MustCompile(`(\d+) passed`)
+
+// ParsePlaywrightPassedCount extracts the passed-test count from Playwright
+// output, shared by every Playwright-driven check (RunDesktopE2E,
+// RunWebsiteE2E) and by plugin checks declaring parse = "playwright-count"
+// (see checks/plugin).
+func ParsePlaywrightPassedCount(output string) (int, bool) {
+	matches := playwrightPassedCountPattern.FindStringSubmatch(output)
+	if len(matches) < 2 {
+		return 0, false
+	}
+	count, err := strconv.Atoi(matches[1])
+	return count, err == nil
+}
+
+// vitestPassedCountPattern matches the "Tests  N passed" line vitest's
+// default reporter prints in its summary.
+var vitestPassedCountPattern = regexp.MustCompile(`Tests\s+(\d+) passed`)
+
+// ParseVitestPassedCount extracts the passed-test count from vitest output,
+// shared by RunLicenseServerTests and by plugin checks declaring parse =
+// "vitest-count" (see checks/plugin).
+func ParseVitestPassedCount(output string) (int, bool) {
+	matches := vitestPassedCountPattern.FindStringSubmatch(output)
+	if len(matches) < 2 {
+		return 0, false
+	}
+	count, err := strconv.Atoi(matches[1])
+	return count, err == nil
+}
+
+// eslintProblemSummaryPattern matches the summary line ESLint's default
+// "stylish" formatter prints, e.g. "✖ 12 problems (8 errors, 4 warnings)".
+var eslintProblemSummaryPattern = regexp.MustCompile(`(\d+) problems? \((\d+) errors?, (\d+) warnings?\)`)
+
+// ParseESLintProblemCounts extracts the error and warning counts from
+// ESLint's stylish-formatter summary line, for plugin checks declaring
+// parse = "eslint" (see checks/plugin). None of cmdr's own ESLint checks
+// (RunDesktopESLint and friends) need this today — they just treat any
+// nonzero exit as failure — but a plugin check has no Go-side error
+// classification to fall back on, so it needs the counts to build a
+// message.
+func ParseESLintProblemCounts(output string) (errors, warnings int, ok bool) {
+	matches := eslintProblemSummaryPattern.FindStringSubmatch(output)
+	if len(matches) < 4 {
+		return 0, 0, false
+	}
+	errorCount, errErr := strconv.Atoi(matches[2])
+	warningCount, warnErr := strconv.Atoi(matches[3])
+	if errErr != nil || warnErr != nil {
+		return 0, 0, false
+	}
+	return errorCount, warningCount, true
+}
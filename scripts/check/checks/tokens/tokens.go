@@ -0,0 +1,110 @@
+// Package tokens estimates token counts the way a real BPE tokenizer would,
+// instead of the sizeBytes/4 heuristic checks.formatTokenCount falls back to.
+// It pre-tokenizes text with a per-extension regex, then applies byte-pair
+// merges from a small embedded rank table (see merges.go) until no further
+// merge applies — the same two-stage shape as tiktoken's cl100k_base,
+// scaled down to a hand-curated subset of merges rather than the full
+// ~100k-entry vocabulary.
+package tokens
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// mergeRank maps a "left\x00right" pair to its priority (lower merges
+// first), built once from mergeOrder.
+var mergeRank = buildMergeRank()
+
+func buildMergeRank() map[string]int {
+	rank := make(map[string]int, len(mergeOrder))
+	for i, pair := range mergeOrder {
+		key := pairKey(pair[0], pair[1])
+		if _, exists := rank[key]; !exists {
+			rank[key] = i
+		}
+	}
+	return rank
+}
+
+func pairKey(a, b string) string {
+	return a + "\x00" + b
+}
+
+// genericPretokenPattern approximates tiktoken's cl100k_base splitter: runs
+// of letters/digits stay together, punctuation/operators split off one rune
+// at a time, and whitespace runs are grouped. Go's regexp (RE2) doesn't
+// support lookahead, so unlike cl100k_base a trailing whitespace run isn't
+// folded into the word that follows it.
+var genericPretokenPattern = regexp.MustCompile(`[\p{L}\p{N}_]+|\s+|[^\s\p{L}\p{N}_]`)
+
+// goPretokenPattern splits Go identifiers (including leading underscores)
+// from numbers, whitespace, and punctuation/operators, closer to how a Go
+// tokenizer sees a .go file than the generic pattern above.
+var goPretokenPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*|[0-9]+|\s+|[^\s]`)
+
+// pretokenPatternFor returns the pre-tokenizer regex for a file extension
+// (including the leading dot, e.g. ".go"), falling back to the generic
+// pattern for extensions with no dedicated tokenizer.
+func pretokenPatternFor(ext string) *regexp.Regexp {
+	switch ext {
+	case ".go":
+		return goPretokenPattern
+	default:
+		return genericPretokenPattern
+	}
+}
+
+// CountTokens counts r's contents the way a BPE tokenizer would: each line
+// is pre-tokenized into words/operators/whitespace runs, then every
+// pre-token is BPE-merged via mergeRank. Reading line-by-line, rather than
+// slurping the whole file, keeps memory bounded on very large files.
+func CountTokens(r io.Reader, ext string) (int64, error) {
+	pattern := pretokenPatternFor(ext)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var total int64
+	for scanner.Scan() {
+		for _, pretoken := range pattern.FindAllString(scanner.Text(), -1) {
+			total += int64(len(bpeMerge(pretoken)))
+		}
+		total++ // the newline bufio.Scanner strips, itself one token
+	}
+	return total, scanner.Err()
+}
+
+// bpeMerge repeatedly merges the lowest-rank adjacent pair of symbols in s
+// until no known merge applies, returning the final symbols (BPE tokens).
+func bpeMerge(s string) []string {
+	symbols := strings.Split(s, "")
+	if len(symbols) <= 1 {
+		return symbols
+	}
+
+	for {
+		bestRank := -1
+		bestIdx := -1
+		for i := 0; i < len(symbols)-1; i++ {
+			if rank, ok := mergeRank[pairKey(symbols[i], symbols[i+1])]; ok {
+				if bestRank == -1 || rank < bestRank {
+					bestRank = rank
+					bestIdx = i
+				}
+			}
+		}
+		if bestIdx == -1 {
+			return symbols
+		}
+
+		merged := symbols[bestIdx] + symbols[bestIdx+1]
+		next := make([]string, 0, len(symbols)-1)
+		next = append(next, symbols[:bestIdx]...)
+		next = append(next, merged)
+		next = append(next, symbols[bestIdx+2:]...)
+		symbols = next
+	}
+}
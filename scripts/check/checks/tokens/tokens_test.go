@@ -0,0 +1,56 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountTokens_EmptyInput(t *testing.T) {
+	n, err := CountTokens(strings.NewReader(""), ".go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Errorf("expected 0 tokens, got %d", n)
+	}
+}
+
+func TestCountTokens_FewerTokensThanBytes(t *testing.T) {
+	// A real tokenizer should merge runs of letters into far fewer tokens
+	// than one-token-per-byte, unlike the sizeBytes/4 heuristic it replaces.
+	line := strings.Repeat("the quick brown fox jumps over the lazy dog ", 20)
+	n, err := CountTokens(strings.NewReader(line), ".go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n >= int64(len(line)) {
+		t.Errorf("expected fewer tokens than bytes, got %d tokens for %d bytes", n, len(line))
+	}
+}
+
+func TestCountTokens_GoKeywordsMergeTighter(t *testing.T) {
+	withKeywords, err := CountTokens(strings.NewReader("func return struct import package"), ".go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// One token per rune would be len(s) tokens; keywords in the merge table
+	// should collapse to meaningfully fewer than that.
+	raw := len([]rune("func return struct import package"))
+	if withKeywords >= int64(raw) {
+		t.Errorf("expected keyword merges to beat one-token-per-rune (%d), got %d", raw, withKeywords)
+	}
+}
+
+func TestBpeMerge_SingleRuneUnchanged(t *testing.T) {
+	got := bpeMerge("x")
+	if len(got) != 1 || got[0] != "x" {
+		t.Errorf("expected [\"x\"], got %v", got)
+	}
+}
+
+func TestBpeMerge_KnownDigraphMerges(t *testing.T) {
+	got := bpeMerge("the")
+	if len(got) != 1 {
+		t.Errorf("expected \"the\" to merge into a single token, got %v", got)
+	}
+}
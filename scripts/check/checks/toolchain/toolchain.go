@@ -0,0 +1,274 @@
+// Package toolchain pins auxiliary tools (staticcheck, jscpd, govulncheck,
+// …) to exact versions recorded in tools.lock, replacing the
+// go install …@latest / npm install -g pattern checks used before: each
+// tool is installed once into a workspace-local .cmdr/tools/<name>-<version>/
+// directory and reused by its pinned version, not by whatever happens to
+// already be on PATH.
+package toolchain
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Installer names how a Tool gets installed.
+type Installer string
+
+const (
+	InstallerGo  Installer = "go"  // go install <Module>@<version>, GOBIN pointed at the cache dir
+	InstallerNpm Installer = "npm" // npm install --prefix <cache dir> <Module>@<version>
+)
+
+// Tool describes how to install one pinned tool; its version comes from
+// tools.lock, not from here.
+type Tool struct {
+	Name      string
+	Installer Installer
+	Module    string // go module path, or npm package name
+}
+
+// knownTools is every tool tools.lock is allowed to pin. Adding a new
+// pinned tool means adding an entry here and a line in tools.lock.
+var knownTools = map[string]Tool{
+	"staticcheck": {Name: "staticcheck", Installer: InstallerGo, Module: "honnef.co/go/tools/cmd/staticcheck"},
+	"govulncheck": {Name: "govulncheck", Installer: InstallerGo, Module: "golang.org/x/vuln/cmd/govulncheck"},
+	"jscpd":       {Name: "jscpd", Installer: InstallerNpm, Module: "jscpd"},
+	"errcheck":    {Name: "errcheck", Installer: InstallerGo, Module: "github.com/kisielk/errcheck"},
+	"misspell":    {Name: "misspell", Installer: InstallerGo, Module: "github.com/client9/misspell/cmd/misspell"},
+	"ineffassign": {Name: "ineffassign", Installer: InstallerGo, Module: "github.com/gordonklaus/ineffassign"},
+	"gosec":       {Name: "gosec", Installer: InstallerGo, Module: "github.com/securego/gosec/v2/cmd/gosec"},
+}
+
+// LoadLock reads tools.lock (one "name@version" entry per line; blank
+// lines and "#" comments ignored) from rootDir.
+func LoadLock(rootDir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(rootDir, "tools.lock"))
+	if err != nil {
+		return nil, fmt.Errorf("reading tools.lock: %w", err)
+	}
+
+	locked := map[string]string{}
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, version, ok := strings.Cut(line, "@")
+		if !ok || name == "" || version == "" {
+			return nil, fmt.Errorf("tools.lock: malformed entry %q (want name@version)", line)
+		}
+		locked[name] = version
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading tools.lock: %w", err)
+	}
+	return locked, nil
+}
+
+// UpdateLock rewrites rootDir's tools.lock, setting name's pin to version -
+// "cmdr tools update" bumps a single tool's pin this way rather than hand-
+// editing the file. name must already be a known tool; it's an error to
+// introduce a pin for a tool tools.lock (and knownTools) doesn't know about,
+// since that pin would just be dead weight no check ever consults.
+func UpdateLock(rootDir, name, version string) error {
+	if _, ok := knownTools[name]; !ok {
+		return fmt.Errorf("toolchain: %q is not a known tool", name)
+	}
+
+	path := filepath.Join(rootDir, "tools.lock")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading tools.lock: %w", err)
+	}
+
+	lines := strings.Split(string(data), "\n")
+	found := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		existingName, _, ok := strings.Cut(trimmed, "@")
+		if ok && existingName == name {
+			lines[i] = fmt.Sprintf("%s@%s", name, version)
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("toolchain: tools.lock has no existing entry for %q to update", name)
+	}
+
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return fmt.Errorf("writing tools.lock: %w", err)
+	}
+	return nil
+}
+
+// cacheDir returns the directory a pinned tool's binary lives in once
+// installed: $XDG_CACHE_HOME/cmdr/tools/<name>-<version> if XDG_CACHE_HOME
+// is set, otherwise os.UserCacheDir()/cmdr/tools/<name>-<version> — the
+// same cache root checks.CacheDir resolves for the check-result and
+// analysis caches, so all of cmdr's on-disk state lives under one
+// ~/.cache/cmdr, content-keyed by name and version rather than by rootDir.
+func cacheDir(name, version string) (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		userCacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		base = userCacheDir
+	}
+	return filepath.Join(base, "cmdr", "tools", name+"-"+version), nil
+}
+
+// binPath returns where a tool's binary lives inside its cache directory.
+func binPath(dir, name string) string {
+	return filepath.Join(dir, name)
+}
+
+// Ensure installs (if not already cached) and returns the path to name's
+// pinned binary, per tools.lock at rootDir. A binary already on disk is
+// reused only if running it with --version reports the locked version, so
+// a stale cache directory left over from an older tools.lock can't
+// silently satisfy a newer one.
+func Ensure(rootDir, name string) (string, error) {
+	tool, ok := knownTools[name]
+	if !ok {
+		return "", fmt.Errorf("toolchain: %q is not a known tool", name)
+	}
+
+	locked, err := LoadLock(rootDir)
+	if err != nil {
+		return "", err
+	}
+	version, ok := locked[name]
+	if !ok {
+		return "", fmt.Errorf("toolchain: tools.lock has no entry for %q", name)
+	}
+
+	dir, err := cacheDir(name, version)
+	if err != nil {
+		return "", fmt.Errorf("toolchain: resolving cache dir for %s: %w", name, err)
+	}
+	bin := binPath(dir, name)
+
+	if installedVersion, err := binVersion(bin); err == nil {
+		if !strings.Contains(installedVersion, version) {
+			return "", fmt.Errorf("toolchain: cached %s at %s reports version %q, but tools.lock wants %q (delete %s to reinstall)", name, bin, installedVersion, version, dir)
+		}
+		return bin, nil
+	}
+
+	if err := install(tool, version, dir); err != nil {
+		return "", fmt.Errorf("toolchain: installing %s@%s: %w", name, version, err)
+	}
+
+	installedVersion, err := binVersion(bin)
+	if err != nil {
+		return "", fmt.Errorf("toolchain: %s installed to %s but --version failed: %w", name, bin, err)
+	}
+	if !strings.Contains(installedVersion, version) {
+		return "", fmt.Errorf("toolchain: tools.lock wants %s@%s but the installed binary reports %q", name, version, installedVersion)
+	}
+	return bin, nil
+}
+
+// Resolved reports whether name's pinned binary is already cached at the
+// version tools.lock wants, without installing it — for an --offline check
+// that should skip rather than attempt a network install.
+func Resolved(rootDir, name string) bool {
+	locked, err := LoadLock(rootDir)
+	if err != nil {
+		return false
+	}
+	version, ok := locked[name]
+	if !ok {
+		return false
+	}
+	dir, err := cacheDir(name, version)
+	if err != nil {
+		return false
+	}
+	installedVersion, err := binVersion(binPath(dir, name))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(installedVersion, version)
+}
+
+// Sync installs every tool named in tools.lock, so CI (or a contributor
+// setting up a fresh checkout) can pre-warm the cache with one command
+// ("cmdr tools sync") instead of hitting an install on whichever check
+// happens to run first. Returns the installed binaries' paths in
+// tools.lock order.
+func Sync(rootDir string) ([]string, error) {
+	locked, err := LoadLock(rootDir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(locked))
+	for name := range locked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	installed := make([]string, 0, len(names))
+	for _, name := range names {
+		bin, err := Ensure(rootDir, name)
+		if err != nil {
+			return installed, err
+		}
+		installed = append(installed, bin)
+	}
+	return installed, nil
+}
+
+// binVersion runs bin --version and returns its trimmed combined output, or
+// an error if bin doesn't exist or can't be run.
+func binVersion(bin string) (string, error) {
+	if _, err := os.Stat(bin); err != nil {
+		return "", err
+	}
+	out, err := exec.Command(bin, "--version").CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// install runs tool's installer with its cache directory as the install
+// target, creating dir first if needed.
+func install(tool Tool, version, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	switch tool.Installer {
+	case InstallerGo:
+		cmd := exec.Command("go", "install", fmt.Sprintf("%s@%s", tool.Module, version))
+		cmd.Env = append(os.Environ(), "GOBIN="+dir)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("go install: %w\n%s", err, out)
+		}
+		return nil
+	case InstallerNpm:
+		cmd := exec.Command("npm", "install", "--prefix", dir, fmt.Sprintf("%s@%s", tool.Module, version))
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("npm install: %w\n%s", err, out)
+		}
+		// npm puts the binary at dir/node_modules/.bin/<name>; symlink it to
+		// dir/<name> so binPath's flat layout is the same for every installer.
+		return os.Symlink(filepath.Join(dir, "node_modules", ".bin", tool.Name), binPath(dir, tool.Name))
+	default:
+		return fmt.Errorf("unknown installer %q", tool.Installer)
+	}
+}
@@ -0,0 +1,165 @@
+package toolchain
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func writeLock(t *testing.T, dir, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "tools.lock"), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadLock_ParsesNameAtVersionLines(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "\n# comment\nstaticcheck@2024.1.1\njscpd@4.0.5\n")
+
+	locked, err := LoadLock(tmp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if locked["staticcheck"] != "2024.1.1" || locked["jscpd"] != "4.0.5" {
+		t.Errorf("expected both entries parsed, got %+v", locked)
+	}
+}
+
+func TestLoadLock_MalformedEntryErrors(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "staticcheck\n")
+
+	if _, err := LoadLock(tmp); err == nil {
+		t.Fatal("expected an error for an entry with no @version")
+	}
+}
+
+func TestLoadLock_MissingFileErrors(t *testing.T) {
+	if _, err := LoadLock(t.TempDir()); err == nil {
+		t.Fatal("expected an error for a missing tools.lock")
+	}
+}
+
+// fakeBin writes a shell script at <cache dir>/<name> that just echoes
+// version when run with --version, standing in for an already-installed
+// pinned tool without actually installing anything. Points XDG_CACHE_HOME
+// at a temp dir first so it doesn't touch the real ~/.cache/cmdr.
+func fakeBin(t *testing.T, name, version string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake shell-script binaries don't run on windows")
+	}
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	dir, err := cacheDir(name, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\necho " + version + "\n"
+	if err := os.WriteFile(binPath(dir, name), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnsure_ReusesCachedBinaryMatchingLock(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "staticcheck@2024.1.1\n")
+	fakeBin(t, "staticcheck", "2024.1.1")
+
+	bin, err := Ensure(tmp, "staticcheck")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dir, err := cacheDir("staticcheck", "2024.1.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bin != binPath(dir, "staticcheck") {
+		t.Errorf("expected the cached binary's path, got %s", bin)
+	}
+}
+
+func TestEnsure_CachedBinaryDisagreeingWithLockErrors(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "staticcheck@2024.1.1\n")
+	fakeBin(t, "staticcheck", "2023.1.1")
+
+	if _, err := Ensure(tmp, "staticcheck"); err == nil {
+		t.Fatal("expected an error when the cached binary's version disagrees with tools.lock")
+	}
+}
+
+func TestEnsure_UnknownToolErrors(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "made-up-tool@1.0.0\n")
+
+	if _, err := Ensure(tmp, "made-up-tool"); err == nil {
+		t.Fatal("expected an error for a tool toolchain doesn't know how to install")
+	}
+}
+
+func TestEnsure_NoLockEntryErrors(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "jscpd@4.0.5\n")
+
+	if _, err := Ensure(tmp, "staticcheck"); err == nil {
+		t.Fatal("expected an error for a tool with no tools.lock entry")
+	}
+}
+
+func TestResolved_TrueOnlyWhenCachedVersionMatchesLock(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "staticcheck@2024.1.1\n")
+
+	if Resolved(tmp, "staticcheck") {
+		t.Error("expected Resolved to be false before anything is cached")
+	}
+
+	fakeBin(t, "staticcheck", "2024.1.1")
+	if !Resolved(tmp, "staticcheck") {
+		t.Error("expected Resolved to be true once the cached binary matches tools.lock")
+	}
+}
+
+func TestUpdateLock_BumpsExistingEntryInPlace(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "# a comment\nstaticcheck@2024.1.1\njscpd@4.0.5\n")
+
+	if err := UpdateLock(tmp, "staticcheck", "2024.1.2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	locked, err := LoadLock(tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locked["staticcheck"] != "2024.1.2" {
+		t.Errorf("expected staticcheck bumped to 2024.1.2, got %q", locked["staticcheck"])
+	}
+	if locked["jscpd"] != "4.0.5" {
+		t.Errorf("expected jscpd left untouched, got %q", locked["jscpd"])
+	}
+}
+
+func TestUpdateLock_UnknownToolErrors(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "made-up-tool@1.0.0\n")
+
+	if err := UpdateLock(tmp, "made-up-tool", "2.0.0"); err == nil {
+		t.Fatal("expected an error for a tool toolchain doesn't know how to install")
+	}
+}
+
+func TestUpdateLock_NoExistingEntryErrors(t *testing.T) {
+	tmp := t.TempDir()
+	writeLock(t, tmp, "jscpd@4.0.5\n")
+
+	if err := UpdateLock(tmp, "staticcheck", "2024.1.2"); err == nil {
+		t.Fatal("expected an error when there's no existing entry to bump")
+	}
+}
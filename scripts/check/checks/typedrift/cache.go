@@ -0,0 +1,114 @@
+package typedrift
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// cacheVersion is bumped whenever cacheFile's shape, or what ParseRust/
+// ParseTypeScript produce, changes in a way that would make an old cache
+// entry read back wrong.
+const cacheVersion = 1
+
+// cacheFile is the on-disk shape of .cache/typedrift.json: the Schema each
+// side parsed to, plus the mtime of every source file that went into it. A
+// later run that finds every file's mtime unchanged skips re-parsing (and,
+// for Rust, re-deriving serde attributes) entirely.
+type cacheFile struct {
+	Version int              `json:"version"`
+	Files   map[string]int64 `json:"files"` // path -> ModTime().UnixNano()
+	Rust    Schema           `json:"rust"`
+	TS      Schema           `json:"ts"`
+}
+
+// Load parses rustDir and tsDir into their Schemas, reusing cachePath's
+// cached result if every source file's mtime still matches what's recorded
+// there. A missing, corrupt, or stale-versioned cache is treated as a full
+// miss rather than an error.
+func Load(cachePath, rustDir, tsDir string) (rust, ts Schema, err error) {
+	currentFiles, err := collectMTimes(rustDir, tsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cached, ok := readCache(cachePath); ok && mtimesEqual(cached.Files, currentFiles) {
+		return cached.Rust, cached.TS, nil
+	}
+
+	rust, err = ParseRust(rustDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	ts, err = ParseTypeScript(tsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_ = writeCache(cachePath, cacheFile{Version: cacheVersion, Files: currentFiles, Rust: rust, TS: ts})
+	return rust, ts, nil
+}
+
+func readCache(path string) (cacheFile, bool) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return cacheFile{}, false
+	}
+	var cached cacheFile
+	if err := json.Unmarshal(raw, &cached); err != nil || cached.Version != cacheVersion {
+		return cacheFile{}, false
+	}
+	return cached, true
+}
+
+func writeCache(path string, cached cacheFile) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	raw, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0644)
+}
+
+// collectMTimes walks rustDir and tsDir, recording each source file's mtime
+// keyed by its absolute path.
+func collectMTimes(dirs ...string) (map[string]int64, error) {
+	files := make(map[string]int64)
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			files[path] = info.ModTime().UnixNano()
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func mtimesEqual(a, b map[string]int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, mtime := range a {
+		if b[path] != mtime {
+			return false
+		}
+	}
+	return true
+}
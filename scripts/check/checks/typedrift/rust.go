@@ -0,0 +1,335 @@
+package typedrift
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// tsExportAttr and cmdrExportLine each mark a Rust struct/enum as part of
+// the IPC surface this package tracks: `#[ts(export)]` is ts-rs's own
+// convention, `//cmdr:export` opts in types that don't depend on ts-rs at
+// all. Either one, alongside deriving Serialize and Deserialize, is enough
+// for ParseRust to include a type.
+var (
+	derivePattern   = regexp.MustCompile(`#\[derive\(([^)]*)\)\]`)
+	tsExportAttr    = regexp.MustCompile(`#\[ts\(\s*export`)
+	cmdrExportLine  = regexp.MustCompile(`^\s*//\s*cmdr:export\s*$`)
+	serdeTagAttr    = regexp.MustCompile(`#\[serde\(\s*tag\s*=\s*"([^"]+)"`)
+	serdeRenameAll  = regexp.MustCompile(`#\[serde\(\s*rename_all\s*=\s*"([^"]+)"`)
+	serdeRename     = regexp.MustCompile(`rename\s*=\s*"([^"]+)"`)
+	structDecl      = regexp.MustCompile(`^\s*pub\s+struct\s+(\w+)`)
+	enumDecl        = regexp.MustCompile(`^\s*pub\s+enum\s+(\w+)`)
+	tupleStructDecl = regexp.MustCompile(`^\s*pub\s+struct\s+(\w+)\s*\(([^)]*)\)\s*;`)
+	fieldLine       = regexp.MustCompile(`^\s*(?:#\[[^\]]*\]\s*)*pub\s+(\w+)\s*:\s*(.+?),?\s*$`)
+	variantLine     = regexp.MustCompile(`^\s*(\w+)\s*[,({]?`)
+)
+
+// ParseRust walks every *.rs file under dir and returns the canonical Schema
+// of every struct/enum opted in via #[ts(export)] or //cmdr:export that also
+// derives Serialize and Deserialize (the combination Tauri's IPC layer
+// requires for a type to cross the JS boundary at all).
+//
+// This is a line-oriented parser rather than a real syn-equivalent AST: it
+// recognizes the attribute/field shapes serde and ts-rs actually emit in
+// this codebase, not arbitrary Rust syntax (generics, cfg-gated fields, and
+// multi-line field types aren't handled). That's a deliberate trade — a
+// dependency-free parser that runs anywhere `go build` does, matching how
+// FindGoModules avoids shelling out to `find` — rather than reaching for
+// `cargo expand`, which requires a working Rust toolchain.
+func ParseRust(dir string) (Schema, error) {
+	schema := make(Schema)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".rs") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for name, def := range parseRustFile(string(data)) {
+			schema[name] = def
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// parseRustFile extracts every exported Def from one Rust source file's
+// contents.
+func parseRustFile(src string) Schema {
+	schema := make(Schema)
+	lines := strings.Split(src, "\n")
+
+	for i := 0; i < len(lines); i++ {
+		attrs := collectPrecedingAttrs(lines, i)
+		if !isExported(attrs) || !derivesSerde(attrs) {
+			continue
+		}
+
+		if m := structDecl.FindStringSubmatch(lines[i]); m != nil {
+			if tm := tupleStructDecl.FindStringSubmatch(lines[i]); tm != nil {
+				schema[tm[1]] = parseTupleStruct(tm[1], tm[2])
+				continue
+			}
+			structName, bodyEnd := m[1], findBlockEnd(lines, i)
+			def := Def{Name: structName, Kind: KindStruct, Fields: parseStructFields(lines[i+1 : bodyEnd])}
+			if len(def.Fields) == 1 && def.Fields[0].Name == "" {
+				def.Kind = KindNewtype
+			}
+			schema[structName] = def
+			i = bodyEnd
+			continue
+		}
+
+		if m := enumDecl.FindStringSubmatch(lines[i]); m != nil {
+			bodyEnd := findBlockEnd(lines, i)
+			tag := ""
+			for _, a := range attrs {
+				if tm := serdeTagAttr.FindStringSubmatch(a); tm != nil {
+					tag = tm[1]
+				}
+			}
+			schema[m[1]] = Def{Name: m[1], Kind: KindEnum, Variants: parseEnumVariants(lines[i+1 : bodyEnd]), Tag: tag}
+			i = bodyEnd
+			continue
+		}
+	}
+	return schema
+}
+
+// collectPrecedingAttrs walks upward from line i collecting the contiguous
+// run of attribute (#[...]) and comment lines directly above it, in source
+// order.
+func collectPrecedingAttrs(lines []string, i int) []string {
+	var attrs []string
+	j := i - 1
+	for j >= 0 {
+		line := strings.TrimSpace(lines[j])
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "#[") || strings.HasPrefix(line, "//") {
+			attrs = append([]string{line}, attrs...)
+			j--
+			continue
+		}
+		break
+	}
+	return attrs
+}
+
+func isExported(attrs []string) bool {
+	for _, a := range attrs {
+		if tsExportAttr.MatchString(a) || cmdrExportLine.MatchString(a) {
+			return true
+		}
+	}
+	return false
+}
+
+func derivesSerde(attrs []string) bool {
+	hasSerialize, hasDeserialize := false, false
+	for _, a := range attrs {
+		m := derivePattern.FindStringSubmatch(a)
+		if m == nil {
+			continue
+		}
+		if strings.Contains(m[1], "Serialize") {
+			hasSerialize = true
+		}
+		if strings.Contains(m[1], "Deserialize") {
+			hasDeserialize = true
+		}
+	}
+	return hasSerialize && hasDeserialize
+}
+
+// findBlockEnd returns the index of the line closing the `{ ... }` block
+// that opens on (or after) lines[i], by brace-depth counting.
+func findBlockEnd(lines []string, i int) int {
+	depth := 0
+	started := false
+	for j := i; j < len(lines); j++ {
+		for _, r := range lines[j] {
+			switch r {
+			case '{':
+				depth++
+				started = true
+			case '}':
+				depth--
+			}
+		}
+		if started && depth == 0 {
+			return j
+		}
+	}
+	return len(lines) - 1
+}
+
+func parseStructFields(lines []string) []Field {
+	var fields []Field
+	renameAll := ""
+	for _, l := range lines {
+		if m := serdeRenameAll.FindStringSubmatch(l); m != nil {
+			renameAll = m[1]
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		attrs := collectPrecedingAttrs(lines, i+1)
+		m := fieldLine.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		fieldName, rustType := m[1], strings.TrimSpace(m[2])
+		for _, a := range attrs {
+			if rm := serdeRename.FindStringSubmatch(a); rm != nil {
+				fieldName = rm[1]
+			}
+		}
+		if renameAll != "" {
+			fieldName = applyRenameAll(fieldName, renameAll)
+		}
+		canonical, optional := normalizeRustType(rustType)
+		fields = append(fields, Field{Name: fieldName, Type: canonical, Optional: optional})
+	}
+	return fields
+}
+
+func parseTupleStruct(name, inner string) Def {
+	parts := strings.Split(inner, ",")
+	fields := make([]Field, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(p), "pub"))
+		if p == "" {
+			continue
+		}
+		canonical, optional := normalizeRustType(p)
+		fields = append(fields, Field{Type: canonical, Optional: optional})
+	}
+	kind := KindTuple
+	if len(fields) == 1 {
+		kind = KindNewtype
+	}
+	return Def{Name: name, Kind: kind, Fields: fields}
+}
+
+func parseEnumVariants(lines []string) []string {
+	var variants []string
+	for _, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#[") || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		if m := variantLine.FindStringSubmatch(trimmed); m != nil {
+			variants = append(variants, m[1])
+		}
+	}
+	return variants
+}
+
+// applyRenameAll renders fieldName the way serde's rename_all would, for the
+// two conventions this repo's IPC types actually use.
+func applyRenameAll(fieldName, convention string) string {
+	switch convention {
+	case "camelCase":
+		return toCamelCase(fieldName)
+	default:
+		return fieldName
+	}
+}
+
+func toCamelCase(s string) string {
+	parts := strings.Split(s, "_")
+	var b strings.Builder
+	for i, p := range parts {
+		if p == "" {
+			continue
+		}
+		if i == 0 {
+			b.WriteString(p)
+			continue
+		}
+		b.WriteString(strings.ToUpper(p[:1]))
+		b.WriteString(p[1:])
+	}
+	return b.String()
+}
+
+// normalizeRustType converts one Rust field type into its canonical,
+// TS-comparable token (see normalizeTSType for the other side), peeling off
+// Option<...> into the Optional flag rather than encoding it in the string.
+func normalizeRustType(rustType string) (canonical string, optional bool) {
+	rustType = strings.TrimSpace(rustType)
+	if inner, ok := unwrap(rustType, "Option"); ok {
+		canonical, _ = normalizeRustType(inner)
+		return canonical, true
+	}
+	if inner, ok := unwrap(rustType, "Vec"); ok {
+		elem, _ := normalizeRustType(inner)
+		return elem + "[]", false
+	}
+	if inner, ok := unwrap(rustType, "Box"); ok {
+		return normalizeRustType(inner)
+	}
+	if k, v, ok := unwrap2(rustType); ok {
+		keyType, _ := normalizeRustType(k)
+		valType, _ := normalizeRustType(v)
+		return "Record<" + keyType + ", " + valType + ">", false
+	}
+	return rustPrimitive(rustType), false
+}
+
+// unwrap strips a single-argument `wrapper<inner>` shell, e.g.
+// unwrap("Option<u32>", "Option") -> ("u32", true).
+func unwrap(rustType, wrapper string) (inner string, ok bool) {
+	prefix := wrapper + "<"
+	if !strings.HasPrefix(rustType, prefix) || !strings.HasSuffix(rustType, ">") {
+		return "", false
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(rustType, prefix), ">"), true
+}
+
+// unwrap2 strips HashMap<K, V>/BTreeMap<K, V> into its two type arguments.
+func unwrap2(rustType string) (key, value string, ok bool) {
+	for _, wrapper := range []string{"HashMap", "BTreeMap"} {
+		if inner, ok := unwrap(rustType, wrapper); ok {
+			parts := strings.SplitN(inner, ",", 2)
+			if len(parts) == 2 {
+				return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+var rustNumericTypes = map[string]bool{
+	"u8": true, "u16": true, "u32": true, "u64": true, "u128": true, "usize": true,
+	"i8": true, "i16": true, "i32": true, "i64": true, "i128": true, "isize": true,
+	"f32": true, "f64": true,
+}
+
+// rustPrimitive maps a bare (non-generic) Rust type to its canonical TS-side
+// token; anything not recognized is assumed to be a reference to another
+// exported Def and passed through unchanged.
+func rustPrimitive(rustType string) string {
+	switch {
+	case rustNumericTypes[rustType]:
+		return "number"
+	case rustType == "bool":
+		return "boolean"
+	case rustType == "String" || rustType == "&str" || strings.HasPrefix(rustType, "&'"):
+		return "string"
+	case rustType == "()":
+		return "null"
+	default:
+		return rustType
+	}
+}
@@ -0,0 +1,206 @@
+// Package typedrift compares the Rust types Tauri serializes over IPC
+// against their hand-written TypeScript counterparts, replacing the old
+// RunTypeDrift's shell-out to `pnpm check:type-drift` (which only grepped a
+// type count out of that script's stdout). Both sides are parsed into a
+// shared canonical Schema (see Def) so ParseRust and ParseTypeScript never
+// need to agree on anything beyond that shape, and Diff reports concrete
+// field-level mismatches instead of a pass/fail integer.
+package typedrift
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Kind is the shape a Def was declared as, on whichever side parsed it.
+type Kind string
+
+const (
+	KindStruct  Kind = "struct"  // named fields
+	KindEnum    Kind = "enum"    // a fixed set of variants, optionally serde-tagged
+	KindTuple   Kind = "tuple"   // positional fields, no names
+	KindNewtype Kind = "newtype" // a single wrapped type, e.g. struct Id(u32)
+)
+
+// Field is one named (struct) or positional (tuple) member of a Def.
+// Name is empty for KindTuple fields.
+type Field struct {
+	Name     string
+	Type     string // canonical type token; see normalizeRustType/normalizeTSType
+	Optional bool   // Rust Option<T> / TS "T | null" or "?:"
+}
+
+// Def is the canonical, side-agnostic shape of one Rust struct/enum or one
+// TypeScript interface/type alias, after normalizing away each language's
+// own spelling of optionality, collections, and renamed fields.
+type Def struct {
+	Name     string
+	Kind     Kind
+	Fields   []Field  // KindStruct, KindTuple, KindNewtype
+	Variants []string // KindEnum, in declaration order
+	Tag      string   // serde #[serde(tag = "...")] discriminant field, empty if untagged/externally-tagged
+}
+
+// Schema maps a type name to its Def, as produced by ParseRust or
+// ParseTypeScript.
+type Schema map[string]Def
+
+// Drift is one concrete, actionable mismatch Diff found between a Rust Def
+// and its TypeScript counterpart.
+type Drift struct {
+	Type    string // the type name both sides declare
+	Message string
+}
+
+// Diff compares rust and ts and returns one Drift per mismatch: a type
+// missing on one side, a kind mismatch (struct vs enum), or a field whose
+// name, optionality, or normalized type disagrees. Results are ordered by
+// type name, then by field, so two runs over unchanged input produce an
+// identical diff.
+func Diff(rust, ts Schema) []Drift {
+	var drifts []Drift
+	for _, name := range sortedKeys(unionKeys(rust, ts)) {
+		rd, inRust := rust[name]
+		td, inTS := ts[name]
+		switch {
+		case inRust && !inTS:
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf("type %q is exported from Rust but has no TypeScript declaration", name)})
+		case inTS && !inRust:
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf("type %q is declared in TypeScript but not exported from Rust", name)})
+		default:
+			drifts = append(drifts, diffDef(name, rd, td)...)
+		}
+	}
+	return drifts
+}
+
+// diffDef compares one type present on both sides.
+func diffDef(name string, rd, td Def) []Drift {
+	if rd.Kind != td.Kind {
+		return []Drift{{Type: name, Message: fmt.Sprintf("type %q is a %s in Rust but a %s in TS", name, rd.Kind, td.Kind)}}
+	}
+
+	var drifts []Drift
+	switch rd.Kind {
+	case KindEnum:
+		drifts = append(drifts, diffVariants(name, rd.Variants, td.Variants)...)
+		if rd.Tag != td.Tag {
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf("type %q is tagged %q in Rust but %q in TS", name, rd.Tag, td.Tag)})
+		}
+	default:
+		drifts = append(drifts, diffFields(name, rd.Kind, rd.Fields, td.Fields)...)
+	}
+	return drifts
+}
+
+func diffVariants(name string, rust, ts []string) []Drift {
+	rustSet := toSet(rust)
+	tsSet := toSet(ts)
+	var drifts []Drift
+	for _, v := range rust {
+		if !tsSet[v] {
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf("variant %q.%q exists in Rust but not in TS", name, v)})
+		}
+	}
+	for _, v := range ts {
+		if !rustSet[v] {
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf("variant %q.%q exists in TS but not in Rust", name, v)})
+		}
+	}
+	return drifts
+}
+
+func diffFields(name string, kind Kind, rust, ts []Field) []Drift {
+	rustByName := fieldsByName(kind, rust)
+	tsByName := fieldsByName(kind, ts)
+
+	var drifts []Drift
+	for _, fieldName := range sortedKeys(unionFieldKeys(rustByName, tsByName)) {
+		rf, inRust := rustByName[fieldName]
+		tf, inTS := tsByName[fieldName]
+		switch {
+		case inRust && !inTS:
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf("field %q.%q exists in Rust but not in TS", name, fieldName)})
+		case inTS && !inRust:
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf("field %q.%q exists in TS but not in Rust", name, fieldName)})
+		case rf.Optional != tf.Optional || rf.Type != tf.Type:
+			drifts = append(drifts, Drift{Type: name, Message: fmt.Sprintf(
+				"field %q.%q is %s in Rust but %s in TS — expected %s",
+				name, fieldName, rustSideType(rf), tf.Type, expectedTSType(rf))})
+		}
+	}
+	return drifts
+}
+
+// rustSideType renders f the way it'd read in the original Rust source, for
+// Drift messages ("Option<u32>" rather than the normalized "u32"+Optional).
+func rustSideType(f Field) string {
+	if f.Optional {
+		return fmt.Sprintf("Option<%s>", f.Type)
+	}
+	return f.Type
+}
+
+// expectedTSType renders what TS should declare for f, given its Rust side.
+func expectedTSType(f Field) string {
+	if f.Optional {
+		return f.Type + " | null"
+	}
+	return f.Type
+}
+
+// fieldsByName keys fields by Field.Name, except for KindTuple/KindNewtype
+// defs, whose fields are all Name: "" (positional, no names to key by) — for
+// those, fields are keyed by their index instead, so distinct tuple
+// positions don't collapse onto the same "" key and get compared as one.
+func fieldsByName(kind Kind, fields []Field) map[string]Field {
+	m := make(map[string]Field, len(fields))
+	for i, f := range fields {
+		key := f.Name
+		if kind == KindTuple || kind == KindNewtype {
+			key = strconv.Itoa(i)
+		}
+		m[key] = f
+	}
+	return m
+}
+
+func toSet(values []string) map[string]bool {
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[v] = true
+	}
+	return m
+}
+
+func unionKeys(rust, ts Schema) map[string]bool {
+	keys := make(map[string]bool, len(rust)+len(ts))
+	for k := range rust {
+		keys[k] = true
+	}
+	for k := range ts {
+		keys[k] = true
+	}
+	return keys
+}
+
+func unionFieldKeys(rust, ts map[string]Field) map[string]bool {
+	keys := make(map[string]bool, len(rust)+len(ts))
+	for k := range rust {
+		keys[k] = true
+	}
+	for k := range ts {
+		keys[k] = true
+	}
+	return keys
+}
+
+func sortedKeys(keys map[string]bool) []string {
+	result := make([]string, 0, len(keys))
+	for k := range keys {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
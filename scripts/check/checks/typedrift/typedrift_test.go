@@ -0,0 +1,213 @@
+package typedrift
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseRust_StructFields(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "types.rs", `
+#[derive(Serialize, Deserialize)]
+#[ts(export)]
+pub struct User {
+    pub id: u32,
+    pub nickname: Option<String>,
+}
+`)
+
+	schema, err := ParseRust(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, ok := schema["User"]
+	if !ok {
+		t.Fatalf("expected User in schema, got %v", schema)
+	}
+	if def.Kind != KindStruct {
+		t.Errorf("expected KindStruct, got %s", def.Kind)
+	}
+	fields := fieldsByName(def.Kind, def.Fields)
+	if fields["id"].Type != "number" || fields["id"].Optional {
+		t.Errorf("expected id: number (required), got %+v", fields["id"])
+	}
+	if fields["nickname"].Type != "string" || !fields["nickname"].Optional {
+		t.Errorf("expected nickname: string (optional), got %+v", fields["nickname"])
+	}
+}
+
+func TestParseRust_SkipsUnexportedTypes(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "types.rs", `
+#[derive(Serialize, Deserialize)]
+pub struct Internal {
+    pub secret: String,
+}
+`)
+
+	schema, err := ParseRust(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := schema["Internal"]; ok {
+		t.Error("expected Internal to be skipped without #[ts(export)] or //cmdr:export")
+	}
+}
+
+func TestParseRust_CmdrExportMarker(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "types.rs", `
+//cmdr:export
+#[derive(Serialize, Deserialize)]
+pub enum Status {
+    Active,
+    Inactive,
+}
+`)
+
+	schema, err := ParseRust(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, ok := schema["Status"]
+	if !ok || def.Kind != KindEnum {
+		t.Fatalf("expected Status enum, got %+v, ok=%v", def, ok)
+	}
+	if len(def.Variants) != 2 || def.Variants[0] != "Active" || def.Variants[1] != "Inactive" {
+		t.Errorf("expected [Active Inactive], got %v", def.Variants)
+	}
+}
+
+func TestParseTypeScript_Interface(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "user.ts", `
+export interface User {
+  id: number;
+  nickname: string | null;
+}
+`)
+
+	schema, err := ParseTypeScript(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, ok := schema["User"]
+	if !ok {
+		t.Fatalf("expected User in schema, got %v", schema)
+	}
+	fields := fieldsByName(def.Kind, def.Fields)
+	if fields["nickname"].Type != "string" || !fields["nickname"].Optional {
+		t.Errorf("expected nickname: string (optional), got %+v", fields["nickname"])
+	}
+}
+
+func TestDiff_FlagsOptionalityMismatch(t *testing.T) {
+	rust := Schema{"User": {Name: "User", Kind: KindStruct, Fields: []Field{
+		{Name: "id", Type: "number"},
+		{Name: "nickname", Type: "string", Optional: true},
+	}}}
+	ts := Schema{"User": {Name: "User", Kind: KindStruct, Fields: []Field{
+		{Name: "id", Type: "number"},
+		{Name: "nickname", Type: "string"},
+	}}}
+
+	drifts := Diff(rust, ts)
+	if len(drifts) != 1 {
+		t.Fatalf("expected 1 drift, got %d: %v", len(drifts), drifts)
+	}
+	want := `field "User"."nickname" is Option<string> in Rust but string in TS — expected string | null`
+	if drifts[0].Message != want {
+		t.Errorf("got %q, want %q", drifts[0].Message, want)
+	}
+}
+
+func TestDiff_FlagsMissingType(t *testing.T) {
+	rust := Schema{"User": {Name: "User", Kind: KindStruct}}
+	ts := Schema{}
+
+	drifts := Diff(rust, ts)
+	if len(drifts) != 1 {
+		t.Fatalf("expected 1 drift, got %d: %v", len(drifts), drifts)
+	}
+	if drifts[0].Type != "User" {
+		t.Errorf("expected drift for User, got %+v", drifts[0])
+	}
+}
+
+// TestDiff_TupleStructComparesAllPositions guards against fieldsByName
+// collapsing every KindTuple field onto its shared Name: "" key: rust and ts
+// here only disagree at position 0, with position 1 matching on both sides,
+// so a map keyed by Name would have both positions overwrite the same ""
+// entry down to the last one (position 1, which matches) and report no
+// drift at all despite the real mismatch at position 0.
+func TestDiff_TupleStructComparesAllPositions(t *testing.T) {
+	rust := Schema{"Point": {Name: "Point", Kind: KindTuple, Fields: []Field{
+		{Type: "number"},
+		{Type: "string"},
+	}}}
+	ts := Schema{"Point": {Name: "Point", Kind: KindTuple, Fields: []Field{
+		{Type: "boolean"},
+		{Type: "string"},
+	}}}
+
+	drifts := Diff(rust, ts)
+	if len(drifts) != 1 {
+		t.Fatalf("expected 1 drift, got %d: %v", len(drifts), drifts)
+	}
+	want := `field "Point"."0" is number in Rust but boolean in TS — expected number`
+	if drifts[0].Message != want {
+		t.Errorf("got %q, want %q", drifts[0].Message, want)
+	}
+}
+
+func TestDiff_NoDriftWhenInSync(t *testing.T) {
+	rust := Schema{"User": {Name: "User", Kind: KindStruct, Fields: []Field{{Name: "id", Type: "number"}}}}
+	ts := Schema{"User": {Name: "User", Kind: KindStruct, Fields: []Field{{Name: "id", Type: "number"}}}}
+
+	if drifts := Diff(rust, ts); len(drifts) != 0 {
+		t.Errorf("expected no drift, got %v", drifts)
+	}
+}
+
+func TestLoad_CachesUntilFileChanges(t *testing.T) {
+	rustDir := t.TempDir()
+	tsDir := t.TempDir()
+	cachePath := filepath.Join(t.TempDir(), "typedrift.json")
+
+	writeFile(t, rustDir, "types.rs", `
+#[derive(Serialize, Deserialize)]
+#[ts(export)]
+pub struct User {
+    pub id: u32,
+}
+`)
+
+	rust, _, err := Load(cachePath, rustDir, tsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rust["User"]; !ok {
+		t.Fatalf("expected User in first parse, got %v", rust)
+	}
+	if _, err := os.Stat(cachePath); err != nil {
+		t.Fatalf("expected cache file to be written: %v", err)
+	}
+
+	// A second load with nothing changed should return the cached schema
+	// (and not error re-reading a now-stale cache file).
+	rust2, _, err := Load(cachePath, rustDir, tsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rust2["User"]; !ok {
+		t.Fatalf("expected User in cached parse, got %v", rust2)
+	}
+}
@@ -0,0 +1,165 @@
+package typedrift
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	interfaceDecl = regexp.MustCompile(`^\s*export\s+interface\s+(\w+)\s*\{`)
+	typeAliasDecl = regexp.MustCompile(`^\s*export\s+type\s+(\w+)\s*=\s*(.+?);?\s*$`)
+	tsFieldLine   = regexp.MustCompile(`^\s*(\w+)(\??)\s*:\s*(.+?);?\s*$`)
+	unionLiteral  = regexp.MustCompile(`^\s*(?:'[^']*'|"[^"]*")(?:\s*\|\s*(?:'[^']*'|"[^"]*"))*\s*$`)
+	quotedLiteral = regexp.MustCompile(`'([^']*)'|"([^"]*)"`)
+)
+
+// ParseTypeScript walks every *.ts/*.d.ts file under dir and returns the
+// canonical Schema of every exported interface and type alias it declares.
+//
+// Like ParseRust, this is a small hand-rolled walker over the textual
+// declarations rather than a binding to the real TypeScript compiler API:
+// it understands the `export interface { field: Type }` and
+// `export type X = 'a' | 'b'` shapes this codebase's hand-written IPC
+// types actually use, not arbitrary TS (mapped types, generics, and
+// multi-line unions aren't handled). Running `tsc --emitDeclarationOnly`
+// first and walking its .d.ts output would add a Node toolchain dependency
+// to a check that otherwise only needs `go build`; this walker reads the
+// source .ts directly instead.
+func ParseTypeScript(dir string) (Schema, error) {
+	schema := make(Schema)
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".ts") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		for name, def := range parseTSFile(string(data)) {
+			schema[name] = def
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func parseTSFile(src string) Schema {
+	schema := make(Schema)
+	lines := strings.Split(src, "\n")
+
+	for i := 0; i < len(lines); i++ {
+		if m := interfaceDecl.FindStringSubmatch(lines[i]); m != nil {
+			bodyEnd := findBlockEnd(lines, i)
+			schema[m[1]] = Def{Name: m[1], Kind: KindStruct, Fields: parseTSFields(lines[i+1 : bodyEnd])}
+			i = bodyEnd
+			continue
+		}
+		if m := typeAliasDecl.FindStringSubmatch(lines[i]); m != nil {
+			schema[m[1]] = parseTSTypeAlias(m[1], m[2])
+			continue
+		}
+	}
+	return schema
+}
+
+func parseTSFields(lines []string) []Field {
+	var fields []Field
+	for _, l := range lines {
+		trimmed := strings.TrimSpace(l)
+		if trimmed == "" || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		m := tsFieldLine.FindStringSubmatch(l)
+		if m == nil {
+			continue
+		}
+		fieldName, optionalMark, tsType := m[1], m[2], strings.TrimSpace(m[3])
+		canonical, nullable := normalizeTSType(tsType)
+		fields = append(fields, Field{Name: fieldName, Type: canonical, Optional: optionalMark == "?" || nullable})
+	}
+	return fields
+}
+
+// parseTSTypeAlias turns a `type X = ...;` alias into a Def: a union of
+// string literals becomes KindEnum (ts-rs's rendering of a serde
+// externally-tagged or unit-only Rust enum), anything else becomes a
+// KindNewtype wrapping the aliased type.
+func parseTSTypeAlias(name, rhs string) Def {
+	if unionLiteral.MatchString(rhs) {
+		var variants []string
+		for _, m := range quotedLiteral.FindAllStringSubmatch(rhs, -1) {
+			if m[1] != "" {
+				variants = append(variants, m[1])
+			} else {
+				variants = append(variants, m[2])
+			}
+		}
+		return Def{Name: name, Kind: KindEnum, Variants: variants}
+	}
+	canonical, optional := normalizeTSType(rhs)
+	return Def{Name: name, Kind: KindNewtype, Fields: []Field{{Type: canonical, Optional: optional}}}
+}
+
+// normalizeTSType converts one TS field/alias type into its canonical,
+// Rust-comparable token (see normalizeRustType), peeling a trailing
+// "| null" or "| undefined" union member into the Optional flag.
+func normalizeTSType(tsType string) (canonical string, optional bool) {
+	tsType = strings.TrimSpace(tsType)
+	if parts := splitTopLevelUnion(tsType); len(parts) > 1 {
+		var rest []string
+		for _, p := range parts {
+			p = strings.TrimSpace(p)
+			if p == "null" || p == "undefined" {
+				optional = true
+				continue
+			}
+			rest = append(rest, p)
+		}
+		if len(rest) == 1 {
+			canonical, _ = normalizeTSType(rest[0])
+			return canonical, optional
+		}
+		return strings.Join(rest, " | "), optional
+	}
+	if strings.HasSuffix(tsType, "[]") {
+		elem, _ := normalizeTSType(strings.TrimSuffix(tsType, "[]"))
+		return elem + "[]", false
+	}
+	if inner, ok := unwrap(tsType, "Array"); ok {
+		elem, _ := normalizeTSType(inner)
+		return elem + "[]", false
+	}
+	return tsType, false
+}
+
+// splitTopLevelUnion splits s on " | " at bracket depth 0, so
+// "Record<string, number> | null" splits into two parts rather than being
+// cut inside the Record's angle brackets.
+func splitTopLevelUnion(s string) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '<', '[', '(':
+			depth++
+		case '>', ']', ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+	return parts
+}
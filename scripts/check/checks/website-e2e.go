@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
-	"regexp"
-	"strconv"
 )
 
 // RunWebsiteE2E runs Playwright E2E tests on the website.
@@ -19,11 +17,7 @@ func RunWebsiteE2E(ctx *CheckContext) (CheckResult, error) {
 		return CheckResult{}, fmt.Errorf("e2e tests failed\n%s", indentOutput(output))
 	}
 
-	// Extract test count
-	re := regexp.MustCompile(`(\d+) passed`)
-	matches := re.FindStringSubmatch(output)
-	if len(matches) > 1 {
-		count, _ := strconv.Atoi(matches[1])
+	if count, ok := ParsePlaywrightPassedCount(output); ok {
 		return Success(fmt.Sprintf("%d %s passed", count, Pluralize(count, "test", "tests"))), nil
 	}
 	return Success("All E2E tests passed"), nil
@@ -35,7 +35,7 @@ func RunWebsitePrettier(ctx *CheckContext) (CheckResult, error) {
 
 	if ctx.CI {
 		if len(needsFormat) > 0 {
-			return CheckResult{}, fmt.Errorf("code is not formatted, run pnpm format locally\n%s", indentOutput(checkOutput))
+			return CheckResult{Diagnostics: prettierDiagnostics(needsFormat, "apps/website")}, fmt.Errorf("code is not formatted, run pnpm format locally\n%s", indentOutput(checkOutput))
 		}
 		return Success(fmt.Sprintf("%d %s already formatted", fileCount, Pluralize(fileCount, "file", "files"))), nil
 	}
@@ -53,3 +53,23 @@ func RunWebsitePrettier(ctx *CheckContext) (CheckResult, error) {
 
 	return Success(fmt.Sprintf("%d %s already formatted", fileCount, Pluralize(fileCount, "file", "files"))), nil
 }
+
+// prettierDiagnostics turns the file paths prettier --list-different printed
+// (one per line, no line/column - prettier's own output doesn't say which
+// lines differ) into whole-file Diagnostics. The paths prettier prints are
+// relative to appDir (where checkCmd.Dir pointed), so appDir is prefixed back
+// on to make File repo-root-relative, matching what --format=sarif/github
+// consumers expect.
+func prettierDiagnostics(needsFormat []string, appDir string) []Diagnostic {
+	diagnostics := make([]Diagnostic, len(needsFormat))
+	for i, file := range needsFormat {
+		diagnostics[i] = Diagnostic{
+			File:     filepath.Join(appDir, file),
+			Rule:     "prettier",
+			Message:  "not formatted with prettier",
+			Severity: "warning",
+			Tool:     "prettier",
+		}
+	}
+	return diagnostics
+}
@@ -10,6 +10,10 @@ import (
 func RunWebsiteTypecheck(ctx *CheckContext) (CheckResult, error) {
 	websiteDir := filepath.Join(ctx.RootDir, "apps", "website")
 
+	if ctx.Offline && !CommandExists("pnpm") {
+		return Skipped("pnpm not found and --offline set"), nil
+	}
+
 	cmd := exec.Command("pnpm", "typecheck")
 	cmd.Dir = websiteDir
 	output, err := RunCommand(cmd, true)
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"vmail/scripts/check/checks"
+)
+
+// writeDiagnostics renders every outcome's CheckResult.Diagnostics (checks
+// that don't populate it, like most of them today, simply contribute none)
+// in the requested format. format is validated in parseFlags, so anything
+// other than "json", "sarif", or "github" reaching here is a programming
+// error.
+func writeDiagnostics(w io.Writer, outcomes []checkOutcome, format string) error {
+	var diagnostics []checks.Diagnostic
+	for _, o := range outcomes {
+		diagnostics = append(diagnostics, o.result.Diagnostics...)
+	}
+
+	switch format {
+	case "json":
+		return writeJSONDiagnostics(w, diagnostics)
+	case "sarif":
+		return writeSARIFDiagnostics(w, diagnostics)
+	case "github":
+		return writeGitHubDiagnostics(w, diagnostics)
+	default:
+		return fmt.Errorf("unknown diagnostics format %q", format)
+	}
+}
+
+// writeJSONDiagnostics writes one checks.Diagnostic JSON object per line
+// (ndjson), so a consumer can stream-parse results without buffering a
+// top-level array.
+func writeJSONDiagnostics(w io.Writer, diagnostics []checks.Diagnostic) error {
+	enc := json.NewEncoder(w)
+	for _, d := range diagnostics {
+		if err := enc.Encode(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sarifLog is the minimal subset of the SARIF 2.1.0 schema needed to report
+// cmdr's diagnostics to a CI system: one run, one tool driver, one result
+// per Diagnostic.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID      string `json:"id"`
+	HelpURI string `json:"helpUri,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level,omitempty"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+const sarifSchemaURI = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// writeSARIFDiagnostics writes one runs[] entry per distinct Diagnostic.Tool
+// (so GitHub Code Scanning and IDE Problems panels group findings by the
+// linter that produced them, each under its own driver name), plus a
+// trailing "cmdr" run for any Diagnostics that don't name a Tool (e.g.
+// RunCfgGate's, which are cmdr's own analysis rather than a relayed linter).
+func writeSARIFDiagnostics(w io.Writer, diagnostics []checks.Diagnostic) error {
+	log := sarifLog{
+		Schema:  sarifSchemaURI,
+		Version: "2.1.0",
+		Runs:    sarifRunsFor(diagnostics),
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// sarifRunsFor groups diagnostics into one sarifRun per distinct Tool, in
+// first-seen order, with untagged diagnostics (Tool == "") grouped under
+// "cmdr" last.
+func sarifRunsFor(diagnostics []checks.Diagnostic) []sarifRun {
+	var toolOrder []string
+	byTool := make(map[string][]checks.Diagnostic)
+	for _, d := range diagnostics {
+		tool := d.Tool
+		if tool == "" {
+			tool = "cmdr"
+		}
+		if _, seen := byTool[tool]; !seen {
+			toolOrder = append(toolOrder, tool)
+		}
+		byTool[tool] = append(byTool[tool], d)
+	}
+
+	runs := make([]sarifRun, len(toolOrder))
+	for i, tool := range toolOrder {
+		toolDiagnostics := byTool[tool]
+		runs[i] = sarifRun{
+			Tool:    sarifTool{Driver: sarifDriver{Name: tool, Rules: sarifRulesFor(toolDiagnostics)}},
+			Results: sarifResultsFor(toolDiagnostics),
+		}
+	}
+	return runs
+}
+
+// sarifRulesFor returns one sarifRule per distinct Diagnostic.Rule, in first-
+// seen order, for the tool driver's rules array. helpUri is taken from the
+// first diagnostic for that rule that has one set, since HelpURI is
+// populated per-Diagnostic (see Diagnostic.HelpURI) but SARIF only has room
+// for one per reportingDescriptor.
+func sarifRulesFor(diagnostics []checks.Diagnostic) []sarifRule {
+	seen := make(map[string]int) // rule -> index into rules
+	var rules []sarifRule
+	for _, d := range diagnostics {
+		if i, ok := seen[d.Rule]; ok {
+			if rules[i].HelpURI == "" && d.HelpURI != "" {
+				rules[i].HelpURI = d.HelpURI
+			}
+			continue
+		}
+		seen[d.Rule] = len(rules)
+		rules = append(rules, sarifRule{ID: d.Rule, HelpURI: d.HelpURI})
+	}
+	return rules
+}
+
+func sarifResultsFor(diagnostics []checks.Diagnostic) []sarifResult {
+	results := make([]sarifResult, len(diagnostics))
+	for i, d := range diagnostics {
+		results[i] = sarifResult{
+			RuleID:  d.Rule,
+			Level:   sarifLevelFor(d.Severity),
+			Message: sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: d.File},
+					Region: sarifRegion{
+						StartLine:   d.Line,
+						StartColumn: d.Col,
+						EndLine:     d.EndLine,
+						EndColumn:   d.EndCol,
+					},
+				},
+			}},
+		}
+	}
+	return results
+}
+
+// sarifLevelFor maps a Diagnostic.Severity (whatever vocabulary the
+// underlying tool uses, e.g. clippy's "warning"/"error", or pnpm audit's
+// "critical"/"high"/"moderate"/"low"/"info") onto SARIF's level enum
+// ("note", "warning", "error"), defaulting to "warning" for an empty or
+// unrecognized severity so a result still renders in GitHub code scanning
+// instead of being dropped for lacking a level.
+func sarifLevelFor(severity string) string {
+	switch severity {
+	case "error", "critical", "high":
+		return "error"
+	case "note", "help", "low", "info":
+		return "note"
+	case "warning", "moderate", "":
+		return "warning"
+	default:
+		return "warning"
+	}
+}
+
+// writeGitHubDiagnostics renders each Diagnostic as a GitHub Actions
+// workflow command (https://docs.github.com/actions/using-workflows/workflow-commands-for-github-actions#setting-an-error-message),
+// so it shows up as an inline annotation on the offending line of the pull
+// request diff instead of only in the raw job log.
+func writeGitHubDiagnostics(w io.Writer, diagnostics []checks.Diagnostic) error {
+	for _, d := range diagnostics {
+		level := githubAnnotationLevelFor(d.Severity)
+		fmt.Fprintf(w, "::%s %s::%s\n", level, githubAnnotationProperties(d), githubAnnotationEscape(d.Message))
+	}
+	return nil
+}
+
+// githubAnnotationLevelFor maps a Diagnostic.Severity onto one of the three
+// GitHub Actions annotation commands, defaulting to "warning" for the same
+// reason sarifLevelFor does.
+func githubAnnotationLevelFor(severity string) string {
+	switch severity {
+	case "error", "critical", "high":
+		return "error"
+	case "note", "help", "low", "info":
+		return "notice"
+	case "warning", "moderate", "":
+		return "warning"
+	default:
+		return "warning"
+	}
+}
+
+// githubAnnotationProperties builds the "file=...,line=...,col=..." property
+// list a workflow command takes before its "::message". title is set to the
+// rule name, if any, so the annotation's heading names what flagged it.
+func githubAnnotationProperties(d checks.Diagnostic) string {
+	var props []string
+	if d.File != "" {
+		props = append(props, "file="+d.File)
+	}
+	if d.Line > 0 {
+		props = append(props, fmt.Sprintf("line=%d", d.Line))
+	}
+	if d.EndLine > 0 {
+		props = append(props, fmt.Sprintf("endLine=%d", d.EndLine))
+	}
+	if d.Col > 0 {
+		props = append(props, fmt.Sprintf("col=%d", d.Col))
+	}
+	if d.EndCol > 0 {
+		props = append(props, fmt.Sprintf("endColumn=%d", d.EndCol))
+	}
+	if d.Rule != "" {
+		props = append(props, "title="+d.Rule)
+	}
+	return strings.Join(props, ",")
+}
+
+// githubAnnotationEscape escapes the characters workflow commands treat
+// specially within a message (%, \r, \n), per GitHub's documented escaping
+// rules.
+func githubAnnotationEscape(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
@@ -4,11 +4,17 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"vmail/scripts/check/checks"
+	"vmail/scripts/check/checks/config"
+	"vmail/scripts/check/checks/plugin"
 )
 
 // stringSlice implements flag.Value for accumulating multiple flag values
@@ -39,30 +45,127 @@ type cliFlags struct {
 	verbose     bool
 	includeSlow bool
 	failFast    bool
+	workers     int
+	timeout     time.Duration
+	summary     bool
+	noBaseline  bool
+	fastTokens  bool
+	fix         bool
+	dryRun      bool
+	cache       bool
+	noCache     bool
+	changedOnly bool
+	baseRef     string
+	format      string
+	only        string
+	skip        string
+	traceOut    string
+	shard       int
+	shards      int
+	offline     bool
+	showSkips   bool
+	update      bool
+	keep        bool
+	output      string
 }
 
 func main() {
-	// Validate check configuration at startup to catch nickname collisions early
+	if len(os.Args) > 1 && os.Args[1] == "baseline" {
+		runBaselineCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServeCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		runWatchCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		runCacheCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "history" {
+		runHistoryCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "tools" {
+		runToolsCommand(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "plan" {
+		runPlanCommand(os.Args[2:])
+		return
+	}
+
+	rootDir, err := findRootDir()
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	if _, err := checks.LoadRegistry(filepath.Join(rootDir, "cmdr-checks.toml"), checks.AllChecks); err != nil {
+		printError("Bad check registry: %v", err)
+		os.Exit(1)
+	}
+
+	pluginChecks, err := plugin.Discover(rootDir)
+	if err != nil {
+		printError("Bad plugin: %v", err)
+		os.Exit(1)
+	}
+	if len(pluginChecks) > 0 {
+		checks.AppendActiveChecks(pluginChecks)
+	}
+
+	// Validate check configuration at startup (after LoadRegistry, so a
+	// registry-added or -overridden check is covered too) to catch
+	// nickname collisions, dependency misconfiguration (unknown or cyclic
+	// DependsOn), and a DependsOn that names a nickname instead of an ID
+	// early, rather than have the runner deadlock waiting on a "done"
+	// channel that never closes.
 	if err := checks.ValidateCheckNames(); err != nil {
 		printError("Bad check configuration: %v", err)
 		os.Exit(1)
 	}
+	if err := checks.ValidateCheckDependencies(checks.ActiveChecks()); err != nil {
+		printError("Bad check configuration: %v", err)
+		os.Exit(1)
+	}
+	if err := checks.ValidateNoOrphanedNicknames(checks.ActiveChecks()); err != nil {
+		printError("Bad check configuration: %v", err)
+		os.Exit(1)
+	}
 
 	flags := parseFlags()
 	if flags == nil {
 		return // Help was shown
 	}
 
-	rootDir, err := findRootDir()
+	cfg, err := config.Load(rootDir)
 	if err != nil {
 		printError("Error: %v", err)
 		os.Exit(1)
 	}
 
 	ctx := &checks.CheckContext{
-		CI:      flags.ciMode,
-		Verbose: flags.verbose,
-		RootDir: rootDir,
+		CI:         flags.ciMode,
+		Verbose:    flags.verbose,
+		RootDir:    rootDir,
+		NoBaseline: flags.noBaseline,
+		Config:     cfg,
+		FastTokens: flags.fastTokens,
+		Fix:        flags.fix,
+		DryRun:     flags.dryRun,
+		Cache:      flags.cache,
+		NoCache:    flags.noCache,
+		// CI runs judge a PR against the diff it introduces, not the whole
+		// file it touches — see ChangedOnly's doc comment.
+		ChangedOnly: flags.changedOnly || flags.ciMode,
+		BaseRef:     flags.baseRef,
+		Offline:     flags.offline,
+		Update:      flags.update,
 	}
 
 	checksToRun, err := selectChecks(flags)
@@ -72,13 +175,25 @@ func main() {
 	}
 
 	checksToRun = checks.FilterSlowChecks(checksToRun, flags.includeSlow)
+	checksToRun = filterDisabledChecks(checksToRun, cfg)
+	checksToRun, err = filterByGlob(checksToRun, flags.only, flags.skip)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	if flags.shards > 1 {
+		total := len(checksToRun)
+		checksToRun = filterByShard(checksToRun, flags.shard, flags.shards)
+		fmt.Printf("Shard %d/%d: running %d of %d checks\n", flags.shard+1, flags.shards, len(checksToRun), total)
+	}
 
 	if len(checksToRun) == 0 {
 		fmt.Println("No checks to run.")
 		os.Exit(0)
 	}
 
-	runChecks(ctx, checksToRun, flags.failFast)
+	runChecks(ctx, checksToRun, flags)
 }
 
 // parseFlags parses command-line flags and returns nil if help was shown.
@@ -96,6 +211,32 @@ func parseFlags() *cliFlags {
 		verbose     = flag.Bool("verbose", false, "Show detailed output")
 		includeSlow = flag.Bool("include-slow", false, "Include slow checks (excluded by default)")
 		failFast    = flag.Bool("fail-fast", false, "Stop on first failure")
+		workers     = flag.Int("n", runtime.NumCPU(), "Number of checks to run concurrently (forced to 1 with --verbose)")
+		jobs        = flag.Int("jobs", 0, "Alias for -n")
+		parallel    = flag.Int("parallel", 0, "Alias for -n/--jobs")
+		serial      = flag.Bool("serial", false, "Force -n/--jobs/--parallel to 1, for debugging")
+		timeout     = flag.Duration("timeout", 0, "Per-check timeout, e.g. 2m (0 = no timeout)")
+		summary     = flag.Bool("summary", false, "Print a final PASS/WARN/FAIL count table")
+		noBaseline  = flag.Bool("no-baseline", false, "Ignore .cmdr-baseline.json and warn on every long file")
+		fastTokens  = flag.Bool("fast-tokens", false, "Estimate long-file token counts as sizeBytes/4 instead of running the BPE tokenizer")
+		fix         = flag.Bool("fix", false, "Insert missing #[cfg(...)] gate attributes instead of just reporting them")
+		dryRun      = flag.Bool("dry-run", false, "With --fix, print the diff that would be applied without writing any files")
+		cache       = flag.Bool("cache", false, "Cache RunCfgGate's per-file analysis on disk, keyed by content hash, to skip re-parsing unchanged files")
+		noCache     = flag.Bool("no-cache", false, "Disable CachedCheck's on-disk result cache for deadcode, nilaway, and CI-mode rustfmt")
+		changedOnly = flag.Bool("changed-only", false, "RunSvelteTests fails only on coverage regressions in lines changed since --base-ref (always on in --ci)")
+		baseRef     = flag.String("base-ref", "", "Git ref --changed-only diffs HEAD against (default: origin/main)")
+		format      = flag.String("format", "text", "Output format: text, json (ndjson of CheckResult.Diagnostics), ndjson (one checkEvent per finished check plus a summary event), sarif (SARIF 2.1.0, for CI), or github (GitHub Actions ::error/::warning annotations)")
+		only        = flag.String("only", "", "Only run checks whose ID or nickname matches this glob pattern (e.g. --only='desktop-*')")
+		skip        = flag.String("skip", "", "Skip checks whose ID or nickname matches this glob pattern")
+		traceOut    = flag.String("trace-out", "", "Write a Chrome/Perfetto trace-event JSON file of this run's check timings to this path")
+		shard       = flag.Int("shard", 0, "This CI worker's shard index, 0-based (use with --shards to fan checks out across N jobs)")
+		shards      = flag.Int("shards", 1, "Total number of shards; each check runs in exactly one, picked by hashing its ID")
+		offline     = flag.Bool("offline", false, "Skip checks that would otherwise install a missing toolchain or need network access, instead of attempting the install")
+		showSkips   = flag.Bool("show-skips", false, "Print skipped checks and their reasons in the final summary")
+		update      = flag.Bool("update", false, "Regenerate a check's golden file (e.g. loc-report.json) instead of comparing against it, for checks that define an Update hook")
+		keep        = flag.Bool("keep", false, "Retain every check's work directory instead of only a failed one's (mirrors Go test/run.go's -k)")
+		output      = flag.String("output", "", "Live per-check reporter: tty (colored progress lines, the default on a terminal) or json (one JSON object per check lifecycle event, like `go test -json`; the default when stdout isn't a terminal and --ci is set)")
+		jsonOutput  = flag.Bool("json", false, "Shorthand for --output=json")
 		help        = flag.Bool("help", false, "Show help message")
 		h           = flag.Bool("h", false, "Show help message")
 	)
@@ -107,6 +248,36 @@ func parseFlags() *cliFlags {
 		return nil
 	}
 
+	if *format != "text" && *format != "json" && *format != "ndjson" && *format != "sarif" && *format != "github" {
+		printError("Error: unknown --format %q (want text, json, ndjson, sarif, or github)", *format)
+		os.Exit(1)
+	}
+
+	resolvedOutput := *output
+	if *jsonOutput {
+		resolvedOutput = "json"
+	}
+	if resolvedOutput != "" && resolvedOutput != "tty" && resolvedOutput != "json" {
+		printError("Error: unknown --output %q (want tty or json)", resolvedOutput)
+		os.Exit(1)
+	}
+
+	if err := validateShardFlags(*shard, *shards); err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	resolvedWorkers := *workers
+	if *jobs > 0 {
+		resolvedWorkers = *jobs
+	}
+	if *parallel > 0 {
+		resolvedWorkers = *parallel
+	}
+	if *serial {
+		resolvedWorkers = 1
+	}
+
 	return &cliFlags{
 		rustOnly:    *rustOnly || *rustOnly2,
 		svelteOnly:  *svelteOnly || *svelteOnly2,
@@ -117,9 +288,129 @@ func parseFlags() *cliFlags {
 		verbose:     *verbose,
 		includeSlow: *includeSlow || len(checkNames) > 0,
 		failFast:    *failFast,
+		workers:     resolvedWorkers,
+		timeout:     *timeout,
+		summary:     *summary,
+		noBaseline:  *noBaseline,
+		fastTokens:  *fastTokens,
+		fix:         *fix,
+		dryRun:      *dryRun,
+		cache:       *cache,
+		noCache:     *noCache,
+		changedOnly: *changedOnly,
+		baseRef:     *baseRef,
+		format:      *format,
+		only:        *only,
+		skip:        *skip,
+		traceOut:    *traceOut,
+		shard:       *shard,
+		shards:      *shards,
+		offline:     *offline,
+		showSkips:   *showSkips,
+		update:      *update,
+		keep:        *keep,
+		output:      resolvedOutput,
 	}
 }
 
+// runBaselineCommand implements the `cmdr baseline` subcommand family.
+func runBaselineCommand(args []string) {
+	if len(args) != 1 || args[0] != "update" {
+		fmt.Println("Usage: go run ./scripts/check baseline update")
+		os.Exit(1)
+	}
+
+	rootDir, err := findRootDir()
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	count, err := checks.WriteFileLengthBaseline(rootDir)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Wrote .cmdr-baseline.json with %d %s\n", count, checks.Pluralize(count, "file", "files"))
+}
+
+// runCacheCommand implements the `cmdr cache` subcommand family.
+func runCacheCommand(args []string) {
+	if len(args) == 0 {
+		printCacheUsage()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "clean":
+		runCacheClean(args[1:])
+	case "clear":
+		runCacheClear(args[1:])
+	default:
+		printCacheUsage()
+		os.Exit(1)
+	}
+}
+
+func printCacheUsage() {
+	fmt.Println("Usage: go run ./scripts/check cache clean [--older-than=7d]")
+	fmt.Println("       go run ./scripts/check cache clear <check>")
+}
+
+// runCacheClean implements `cmdr cache clean`: an age-based sweep of the
+// entire ~/.cache/cmdr tree.
+func runCacheClean(args []string) {
+	fs := flag.NewFlagSet("cache clean", flag.ExitOnError)
+	olderThan := fs.String("older-than", "7d", "Remove cache entries not read/written in longer than this, e.g. 7d, 12h")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	duration, err := parseOlderThan(*olderThan)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	removed, err := checks.CleanCache(duration)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Removed %d cached %s older than %s\n", removed, checks.Pluralize(removed, "file", "files"), *olderThan)
+}
+
+// runCacheClear implements `cmdr cache clear <check>`: a full wipe of one
+// check's cache, regardless of entry age, for when a known-bad result got
+// cached before a fix landed.
+func runCacheClear(args []string) {
+	if len(args) != 1 {
+		printCacheUsage()
+		os.Exit(1)
+	}
+
+	if err := checks.ClearCache(args[0]); err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Cleared cache for %s\n", args[0])
+}
+
+// parseOlderThan parses a duration like time.ParseDuration, plus a "d" (days)
+// suffix that ParseDuration doesn't support — e.g. "7d" for a week.
+func parseOlderThan(s string) (time.Duration, error) {
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid --older-than %q: %w", s, err)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(s)
+}
+
 // selectChecks determines which checks to run based on flags.
 func selectChecks(flags *cliFlags) ([]checks.CheckDefinition, error) {
 	if len(flags.checkNames) > 0 {
@@ -137,7 +428,59 @@ func selectChecks(flags *cliFlags) ([]checks.CheckDefinition, error) {
 	if flags.goOnly {
 		return checks.GetChecksByTech(checks.AppScripts, "🐹 Go"), nil
 	}
-	return checks.AllChecks, nil
+	return checks.ActiveChecks(), nil
+}
+
+// filterDisabledChecks drops any check whose ID is listed under
+// [checks] disabled in cmdr.toml, or whose CheckDefinition.Disabled was set
+// by a "disabled" entry in an external registry file (see LoadRegistry).
+func filterDisabledChecks(checksToRun []checks.CheckDefinition, cfg *config.Config) []checks.CheckDefinition {
+	var result []checks.CheckDefinition
+	for _, check := range checksToRun {
+		if check.Disabled || cfg.DisabledChecks[check.ID] {
+			continue
+		}
+		result = append(result, check)
+	}
+	return result
+}
+
+// filterByGlob applies --only and --skip, matching each check's CLIName()
+// (nickname if set, else ID) against the given glob patterns. An empty
+// pattern is a no-op. --only keeps just the matches; --skip drops them;
+// both may be given together.
+func filterByGlob(checksToRun []checks.CheckDefinition, only, skip string) ([]checks.CheckDefinition, error) {
+	if only == "" && skip == "" {
+		return checksToRun, nil
+	}
+
+	var result []checks.CheckDefinition
+	for _, check := range checksToRun {
+		name := check.CLIName()
+
+		if only != "" {
+			matched, err := path.Match(only, name)
+			if err != nil {
+				return nil, fmt.Errorf("bad --only pattern %q: %w", only, err)
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		if skip != "" {
+			matched, err := path.Match(skip, name)
+			if err != nil {
+				return nil, fmt.Errorf("bad --skip pattern %q: %w", skip, err)
+			}
+			if matched {
+				continue
+			}
+		}
+
+		result = append(result, check)
+	}
+	return result, nil
 }
 
 // selectChecksByID returns checks matching the given IDs.
@@ -169,31 +512,126 @@ func selectChecksByApp(appName string) ([]checks.CheckDefinition, error) {
 	}
 }
 
-// runChecks executes the checks and prints results.
-func runChecks(ctx *checks.CheckContext, checksToRun []checks.CheckDefinition, failFast bool) {
-	fmt.Printf("🔍 Running %d checks...\n\n", len(checksToRun))
+// runChecks executes the checks and prints results. With flags.format set to
+// "json", "ndjson", "sarif", or "github", the usual colored per-check output
+// is suppressed in favor of a single structured dump — either every check's
+// CheckResult.Diagnostics (see writeDiagnostics in diagnostics_output.go) or,
+// for "ndjson", one checkEvent per check plus a summary event (see
+// writeNDJSON in ndjson_output.go) — meant for editors and CI, which
+// shouldn't have to regex-scrape the text report. Independently of --format,
+// flags.output picks the checks.Reporter that streams each check's
+// start/pass/fail/skip events live as the run progresses (see
+// reporterFor): "tty" for the usual colored progress lines, or "json" for
+// one JSON object per line, CI-log- and editor-friendly.
+func runChecks(ctx *checks.CheckContext, checksToRun []checks.CheckDefinition, flags *cliFlags) {
+	structured := flags.format != "text"
+	reporter, jsonReporter := reporterFor(ctx, flags, len(checksToRun))
+	quiet := structured || jsonReporter
+
+	if !quiet {
+		fmt.Printf("🔍 Running %d checks...\n\n", len(checksToRun))
+	}
 
 	startTime := time.Now()
-	runner := NewRunner(ctx, checksToRun, failFast)
+	runner := NewRunner(ctx, checksToRun, flags.failFast)
+	runner.Workers = flags.workers
+	runner.Timeout = flags.timeout
+	runner.Summary = flags.summary
+	runner.ShowSkips = flags.showSkips
+	runner.Keep = flags.keep
+	runner.Quiet = quiet
+	runner.OnStart = reporter.Start
+	runner.OnResult = reporter.Result
 	failed, failedChecks := runner.Run()
 
+	if _, err := writeRunRecord(buildRunRecord(startTime, runner.Outcomes)); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to write run history: %v\n", err)
+	}
+	if flags.traceOut != "" {
+		if err := writeChromeTrace(flags.traceOut, buildChromeTrace(startTime, runner.Outcomes)); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to write trace to %s: %v\n", flags.traceOut, err)
+		}
+	}
+
+	if flags.format == "ndjson" {
+		if err := writeNDJSON(os.Stdout, runner.Outcomes, time.Since(startTime)); err != nil {
+			printError("Error: %v", err)
+			os.Exit(1)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if structured {
+		if err := writeDiagnostics(os.Stdout, runner.Outcomes, flags.format); err != nil {
+			printError("Error: %v", err)
+			os.Exit(1)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if jsonReporter {
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
 	totalDuration := time.Since(startTime)
 	fmt.Println()
 	fmt.Printf("%s⏱️  Total runtime: %s%s\n", colorYellow, formatDuration(totalDuration), colorReset)
 
 	if failed {
-		printFailure(failedChecks)
+		printFailure(failedChecks, workDirsByCheck(runner.Outcomes))
 		os.Exit(1)
 	}
 
 	fmt.Printf("%s✅ All checks passed!%s\n", colorGreen, colorReset)
 }
 
+// reporterFor picks the checks.Reporter that streams live check events as
+// the run progresses: flags.output, if set, wins outright; otherwise
+// ctx.CI with stdout not a terminal defaults to JSON (a CI log viewer wants
+// the structured stream, not colored escape codes it can't render), and
+// everything else gets the usual TTYReporter. The second return value is
+// true for the JSON reporter, so runChecks can suppress the plain-text
+// banner/summary that would otherwise interleave with its event stream.
+func reporterFor(ctx *checks.CheckContext, flags *cliFlags, total int) (checks.Reporter, bool) {
+	useJSON := flags.output == "json" || (flags.output == "" && ctx.CI && !stdoutIsTTY())
+	if useJSON {
+		return checks.NewJSONReporter(os.Stdout), true
+	}
+	return checks.NewTTYReporter(os.Stderr, total), false
+}
+
+// stdoutIsTTY reports whether os.Stdout is an interactive terminal rather
+// than a pipe or redirected file.
+func stdoutIsTTY() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
 // printFailure prints the failure message with rerun instructions.
-func printFailure(failedChecks []string) {
+// printFailure prints the IDs to rerun with --check, plus (next to each ID
+// that left one) the path of its retained checks.CheckContext.WorkDir — see
+// workDirsByCheck and Runner.Keep.
+func printFailure(failedChecks []string, workDirs map[string]string) {
 	fmt.Printf("%s❌ Some checks failed.%s\n", colorRed, colorReset)
 	if len(failedChecks) > 0 {
 		fmt.Println()
+		for _, id := range failedChecks {
+			if dir, ok := workDirs[id]; ok {
+				fmt.Printf("  %s (artifacts: %s)\n", id, dir)
+			}
+		}
 		checkWord := "check"
 		if len(failedChecks) > 1 {
 			checkWord = "checks"
@@ -202,6 +640,18 @@ func printFailure(failedChecks []string) {
 	}
 }
 
+// workDirsByCheck maps each outcome's CLIName to its retained work
+// directory, for outcomes that left one behind.
+func workDirsByCheck(outcomes []checkOutcome) map[string]string {
+	dirs := make(map[string]string)
+	for _, o := range outcomes {
+		if o.workDir != "" {
+			dirs[o.def.CLIName()] = o.workDir
+		}
+	}
+	return dirs
+}
+
 // showUsage displays the help message with dynamically generated check list.
 func showUsage() {
 	fmt.Println("Usage: go run ./scripts/check [OPTIONS]")
@@ -218,16 +668,52 @@ func showUsage() {
 	fmt.Println("    --verbose                Show detailed output")
 	fmt.Println("    --include-slow           Include slow checks (excluded by default)")
 	fmt.Println("    --fail-fast              Stop on first failure")
+	fmt.Println("    -n NUM, --jobs NUM       Run up to NUM checks concurrently (default: number of CPUs)")
+	fmt.Println("    --parallel NUM           Alias for -n/--jobs")
+	fmt.Println("    --serial                 Force -n/--jobs/--parallel to 1, for debugging")
+	fmt.Println("    --only PATTERN           Only run checks whose ID or nickname matches this glob pattern")
+	fmt.Println("    --skip PATTERN           Skip checks whose ID or nickname matches this glob pattern")
+	fmt.Println("    --timeout DURATION       Kill a check that runs longer than this, e.g. 2m (default: no timeout)")
+	fmt.Println("    --summary                Print a final PASS/WARN/FAIL count table")
+	fmt.Println("    --no-baseline            Ignore .cmdr-baseline.json and warn on every long file")
+	fmt.Println("    --fast-tokens            Estimate long-file token counts as sizeBytes/4 instead of running the BPE tokenizer")
+	fmt.Println("    --fix                    Insert missing #[cfg(...)] gate attributes instead of just reporting them")
+	fmt.Println("    --dry-run                With --fix, print the diff that would be applied without writing any files")
+	fmt.Println("    --cache                  Cache RunCfgGate's per-file analysis on disk, keyed by content hash, to skip re-parsing unchanged files")
+	fmt.Println("    --no-cache               Disable CachedCheck's on-disk result cache for deadcode, nilaway, and CI-mode rustfmt")
+	fmt.Println("    --changed-only           RunSvelteTests fails only on coverage regressions in lines changed since --base-ref (always on in --ci)")
+	fmt.Println("    --base-ref REF           Git ref --changed-only diffs HEAD against (default: origin/main)")
+	fmt.Println("    --format FORMAT          Output format: text (default), json (ndjson of diagnostics), ndjson (ndjson of per-check status events), sarif (SARIF 2.1.0, for CI), or github (GitHub Actions annotations)")
+	fmt.Println("    --trace-out PATH         Write a Chrome/Perfetto trace-event JSON file of this run's check timings")
+	fmt.Println("    --shard N --shards M     Run only this 0-based shard's share of the checks, split M ways by hashing check ID")
+	fmt.Println("    --offline                Skip checks that would otherwise install a toolchain or need network access, instead of attempting it")
+	fmt.Println("    --show-skips             Print skipped checks and their reasons in the final summary")
+	fmt.Println("    --update                 Regenerate a check's golden file (e.g. loc-report.json) instead of comparing against it")
+	fmt.Println("    --keep                   Retain every check's work directory instead of only a failed one's")
+	fmt.Println("    --output MODE            Live per-check reporter: tty (colored progress, default) or json (one JSON event per line, like `go test -json`; also the default with --ci when stdout isn't a terminal)")
+	fmt.Println("    -json                    Shorthand for --output=json")
 	fmt.Println("    -h, --help               Show this help message")
 	fmt.Println()
+	fmt.Println("SUBCOMMANDS:")
+	fmt.Println("    baseline update          Record today's over-threshold files in .cmdr-baseline.json")
+	fmt.Println("    serve [--addr ADDR]      Host a live HTML dashboard of check results (default localhost:1313)")
+	fmt.Println("    watch [OPTIONS]          Rerun only the checks affected by each file change (--clear, --postpone, --include-slow)")
+	fmt.Println("    cache clean [--older-than=7d]  Remove cached check/analysis results older than the given duration")
+	fmt.Println("    cache clear <check>      Remove every cached result for one check, regardless of age")
+	fmt.Println("    history [-n NUM]        Print median/p95 durations per check across the last NUM runs (default 20)")
+	fmt.Println()
 	fmt.Println("If no options are provided, runs all non-slow checks for all apps.")
 	fmt.Println()
+	fmt.Println("cmdr.toml at the repo root can override file-length thresholds, source")
+	fmt.Println("extensions/skip dirs, and disable checks outright. See cmdr.toml.example.")
+	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Println("    go run ./scripts/check                              # Run all checks")
 	fmt.Println("    go run ./scripts/check --app desktop                # Run only desktop app checks")
 	fmt.Println("    go run ./scripts/check --check desktop-rust-clippy  # Run specific check")
 	fmt.Println("    go run ./scripts/check --include-slow               # Include slow checks")
 	fmt.Println("    go run ./scripts/check --ci --fail-fast             # CI mode, stop on first failure")
+	fmt.Println("    go run ./scripts/check -n 1 --timeout 2m            # Run serially, kill checks over 2 minutes")
 	fmt.Println()
 	fmt.Println("Available checks:")
 	fmt.Println()
@@ -242,7 +728,7 @@ func showUsage() {
 	groupMap := make(map[string]*checkGroup)
 	var groupOrder []string
 
-	for _, check := range checks.AllChecks {
+	for _, check := range checks.ActiveChecks() {
 		key := string(check.App) + "|" + check.Tech
 		if _, ok := groupMap[key]; !ok {
 			groupMap[key] = &checkGroup{
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"vmail/scripts/check/checks"
+)
+
+// checkEvent is one line of --format=ndjson output: a finished check's
+// structured status, independent of the colored text report() prints, so a
+// consumer (a GitHub Actions wrapper, a dashboard, jq) doesn't have to scrape
+// it back out of Message.
+type checkEvent struct {
+	ID         string `json:"id"`
+	App        string `json:"app"`
+	Tech       string `json:"tech"`
+	Status     string `json:"status"` // "pass", "fail", "skip", or "changed"
+	DurationMS int64  `json:"duration_ms"`
+	Message    string `json:"message"`
+	Output     string `json:"output"`
+	Slow       bool   `json:"slow"`
+}
+
+// summaryEvent is the final ndjson line, once every checkEvent has been
+// written, giving a consumer the run's totals without having to tally the
+// per-check events itself.
+type summaryEvent struct {
+	Event      string   `json:"event"`
+	Total      int      `json:"total"`
+	Passed     int      `json:"passed"`
+	Failed     int      `json:"failed"`
+	Skipped    int      `json:"skipped"`
+	DurationMS int64    `json:"duration_ms"`
+	FailedIDs  []string `json:"failed_ids"`
+}
+
+// writeNDJSON renders one checkEvent per outcome, in outcome order, followed
+// by a single summaryEvent — one JSON object per line, so a consumer can
+// stream-parse results without buffering a top-level array (the same
+// rationale as writeJSONDiagnostics).
+func writeNDJSON(w io.Writer, outcomes []checkOutcome, totalDuration time.Duration) error {
+	enc := json.NewEncoder(w)
+
+	var passed, failed, skipped int
+	var failedIDs []string
+	for _, o := range outcomes {
+		status := statusFor(o)
+		switch status {
+		case "fail":
+			failed++
+			failedIDs = append(failedIDs, o.def.CLIName())
+		case "skip":
+			skipped++
+		default:
+			passed++
+		}
+
+		message := o.result.Message
+		if o.err != nil {
+			message = o.err.Error()
+		}
+		output := o.result.Output
+		if output == "" {
+			output = message
+		}
+
+		event := checkEvent{
+			ID:         o.def.ID,
+			App:        string(o.def.App),
+			Tech:       o.def.Tech,
+			Status:     status,
+			DurationMS: o.duration.Milliseconds(),
+			Message:    message,
+			Output:     output,
+			Slow:       o.def.IsSlow,
+		}
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+	}
+
+	return enc.Encode(summaryEvent{
+		Event:      "summary",
+		Total:      len(outcomes),
+		Passed:     passed,
+		Failed:     failed,
+		Skipped:    skipped,
+		DurationMS: totalDuration.Milliseconds(),
+		FailedIDs:  failedIDs,
+	})
+}
+
+// statusFor maps an outcome onto the four ndjson statuses. Warnings (the one
+// checks.ResultCode that doesn't fail a run, see Runner.report) are reported
+// as "pass" since there's no dedicated status for them in this format — the
+// full warning text is still available in the event's Message/Output.
+func statusFor(o checkOutcome) string {
+	switch {
+	case o.err != nil:
+		return "fail"
+	case o.result.Code == checks.ResultSkipped:
+		return "skip"
+	case o.result.MadeChanges:
+		return "changed"
+	default:
+		return "pass"
+	}
+}
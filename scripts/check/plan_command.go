@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"vmail/scripts/check/checks"
+)
+
+// runPlanCommand implements `cmdr plan [checks...]`: a dry run that prints
+// the DependsOn-resolved execution waves for the named checks (or every
+// active check, if none are named) without running anything.
+func runPlanCommand(args []string) {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	includeSlow := fs.Bool("include-slow", false, "Include slow/Docker-gated checks in the plan")
+	format := fs.String("format", "text", "Output format: text, json, mermaid, dot")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	rootDir, err := findRootDir()
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	if _, err := checks.LoadRegistry(filepath.Join(rootDir, "cmdr-checks.toml"), checks.AllChecks); err != nil {
+		printError("Bad check registry: %v", err)
+		os.Exit(1)
+	}
+
+	plan, err := checks.BuildPlan(checks.ActiveChecks(), fs.Args(), *includeSlow)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	switch *format {
+	case "text":
+		printPlanText(plan)
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(plan.JSON()); err != nil {
+			printError("Error: %v", err)
+			os.Exit(1)
+		}
+	case "mermaid":
+		fmt.Print(plan.Mermaid())
+	case "dot":
+		fmt.Print(plan.Graphviz())
+	default:
+		printError("Error: unknown --format %q (want text, json, mermaid, or dot)", *format)
+		os.Exit(1)
+	}
+}
+
+// printPlanText prints one line per wave, e.g. "wave 1: rustfmt,
+// prettier(desktop), gofmt, misspell", naming each check's app in
+// parentheses unless it's AppScripts (the common case for a scripts-only
+// run, where repeating "(scripts)" on every line would just be noise).
+func printPlanText(plan *checks.Plan) {
+	for i, wave := range plan.Waves {
+		names := make([]string, len(wave))
+		for j, def := range wave {
+			name := def.CLIName()
+			if def.App != checks.AppScripts {
+				name = fmt.Sprintf("%s(%s)", name, def.App)
+			}
+			names[j] = name
+		}
+		fmt.Printf("wave %d: %s\n", i+1, strings.Join(names, ", "))
+	}
+}
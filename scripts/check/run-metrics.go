@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"vmail/scripts/check/checks"
+)
+
+// runRecord is the on-disk shape of one `cmdr check` run's timing data,
+// written as cmdr-run-<timestamp>.json under checks.CacheDir("runs") so
+// `cmdr history` can read back the last N runs and surface which check
+// dominates wall time — the same role rustc's bootstrap/metrics.rs plays for
+// a Rust build.
+type runRecord struct {
+	Timestamp string        `json:"timestamp"`
+	Checks    []checkMetric `json:"checks"`
+}
+
+// checkMetric is one check's timing and outcome within a runRecord.
+type checkMetric struct {
+	ID              string    `json:"id"`
+	DisplayName     string    `json:"displayName"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationMS      int64     `json:"durationMs"`
+	Passed          bool      `json:"passed"`
+	CachedHit       bool      `json:"cachedHit"`
+	DiagnosticCount int       `json:"diagnosticCount"`
+}
+
+// buildRunRecord converts a finished run's outcomes into a runRecord.
+// CachedHit is derived from CachedCheck's "cached: " message prefix (see
+// check-cache.go) rather than a dedicated field, since that's the only
+// signal a Run function has available for whether it served a cache hit.
+func buildRunRecord(timestamp time.Time, outcomes []checkOutcome) runRecord {
+	record := runRecord{Timestamp: timestamp.UTC().Format(time.RFC3339Nano)}
+	for _, o := range outcomes {
+		if o.start.IsZero() {
+			continue // skipped (failed dependency or --fail-fast abort): never ran, no duration to record
+		}
+		record.Checks = append(record.Checks, checkMetric{
+			ID:              o.def.ID,
+			DisplayName:     o.def.DisplayName,
+			Start:           o.start,
+			End:             o.start.Add(o.duration),
+			DurationMS:      o.duration.Milliseconds(),
+			Passed:          o.err == nil,
+			CachedHit:       strings.HasPrefix(o.result.Message, "cached: "),
+			DiagnosticCount: len(o.result.Diagnostics),
+		})
+	}
+	return record
+}
+
+// writeRunRecord writes record as JSON under checks.CacheDir("runs"), named
+// so runs sort chronologically by filename, and returns the path written to.
+func writeRunRecord(record runRecord) (string, error) {
+	dir, err := checks.CacheDir("runs")
+	if err != nil {
+		return "", err
+	}
+
+	name := "cmdr-run-" + strings.ReplaceAll(record.Timestamp, ":", "-") + ".json"
+	path := filepath.Join(dir, name)
+
+	raw, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// --- Chrome trace export (--trace-out) ---
+
+// traceDocument is the top-level shape Chrome's about:tracing and Perfetto
+// both accept: a flat list of complete ("X") events.
+type traceDocument struct {
+	TraceEvents []traceEvent `json:"traceEvents"`
+}
+
+// traceEvent is one check's span, in the Chrome Trace Event Format: ts/dur in
+// microseconds, pid/tid grouping events onto tracks. Every check shares one
+// pid (this cmdr invocation); tid is the concurrency slot it ran in (see
+// checkOutcome.workerSlot), so checks that actually overlapped land on
+// different tracks instead of stacking unreadably onto one.
+type traceEvent struct {
+	Name string `json:"name"`
+	Ph   string `json:"ph"`
+	Ts   int64  `json:"ts"`
+	Dur  int64  `json:"dur"`
+	Pid  int    `json:"pid"`
+	Tid  int    `json:"tid"`
+}
+
+// buildChromeTrace converts a run's outcomes into a traceDocument, with
+// every event's ts measured relative to runStart.
+func buildChromeTrace(runStart time.Time, outcomes []checkOutcome) traceDocument {
+	doc := traceDocument{}
+	for _, o := range outcomes {
+		if o.start.IsZero() {
+			continue // skipped checks never ran, so have no span to trace
+		}
+		doc.TraceEvents = append(doc.TraceEvents, traceEvent{
+			Name: o.def.DisplayName,
+			Ph:   "X",
+			Ts:   o.start.Sub(runStart).Microseconds(),
+			Dur:  o.duration.Microseconds(),
+			Pid:  1,
+			Tid:  o.workerSlot,
+		})
+	}
+	return doc
+}
+
+// writeChromeTrace writes doc as JSON to path.
+func writeChromeTrace(path string, doc traceDocument) error {
+	raw, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, raw, 0644)
+}
+
+// --- `cmdr history` ---
+
+// runHistoryCommand implements the `cmdr history` subcommand: it reads the
+// last n run records checks.CacheDir("runs") holds and prints a table of
+// each check's median and p95 duration, sorted slowest-p95-first so the
+// check dominating wall time (or one that's regressed) is easy to spot.
+func runHistoryCommand(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	n := fs.Int("n", 20, "Number of recent runs to summarize")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if *n <= 0 {
+		printError("Error: -n must be positive, got %d", *n)
+		os.Exit(1)
+	}
+
+	dir, err := checks.CacheDir("runs")
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	records, err := readRunRecords(dir, *n)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+	if len(records) == 0 {
+		fmt.Println("No run history yet — run `cmdr check` first.")
+		return
+	}
+
+	stats := summarizeHistory(records)
+	printHistoryTable(stats)
+}
+
+// historyStat is one check's aggregated duration stats across the runs
+// considered.
+type historyStat struct {
+	ID       string
+	MedianMS int64
+	P95MS    int64
+	RunCount int
+}
+
+// readRunRecords reads every cmdr-run-*.json file under dir, sorted by
+// filename (and so chronologically, since writeRunRecord names files after
+// their RFC3339 timestamp), and returns the last n.
+func readRunRecords(dir string, n int) ([]runRecord, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), "cmdr-run-") && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	if len(names) > n {
+		names = names[len(names)-n:]
+	}
+
+	var records []runRecord
+	for _, name := range names {
+		raw, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		var record runRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// summarizeHistory groups every checkMetric across records by check ID and
+// computes each one's median/p95 duration, sorted by descending p95. Cache
+// hits (see checkMetric.CachedHit) are excluded from the duration samples:
+// their near-zero duration reflects a cache lookup, not the check's real
+// cost, and mixing them in would drag the reported median/p95 for a
+// CachedCheck-backed check (deadcode, nilaway, CI-mode rustfmt) well below
+// what a cache miss — and so a real regression — actually costs.
+func summarizeHistory(records []runRecord) []historyStat {
+	durationsByID := make(map[string][]int64)
+	for _, record := range records {
+		for _, m := range record.Checks {
+			if m.CachedHit {
+				continue
+			}
+			durationsByID[m.ID] = append(durationsByID[m.ID], m.DurationMS)
+		}
+	}
+
+	var stats []historyStat
+	for id, durations := range durationsByID {
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		stats = append(stats, historyStat{
+			ID:       id,
+			MedianMS: percentile(durations, 0.5),
+			P95MS:    percentile(durations, 0.95),
+			RunCount: len(durations),
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].P95MS != stats[j].P95MS {
+			return stats[i].P95MS > stats[j].P95MS
+		}
+		return stats[i].ID < stats[j].ID
+	})
+	return stats
+}
+
+// percentile returns the p-th percentile (0 < p <= 1) of sorted, a slice
+// already sorted ascending, using the nearest-rank method.
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+func printHistoryTable(stats []historyStat) {
+	fmt.Printf("%-40s %10s %10s %6s\n", "CHECK", "MEDIAN", "P95", "RUNS")
+	for _, s := range stats {
+		fmt.Printf("%-40s %10s %10s %6d\n", s.ID, formatDuration(time.Duration(s.MedianMS)*time.Millisecond), formatDuration(time.Duration(s.P95MS)*time.Millisecond), s.RunCount)
+	}
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vmail/scripts/check/checks"
+)
+
+// defaultCheckTimeout bounds how long a single check may run before it's
+// killed and reported as a failure. 0 (the default) means no timeout.
+const defaultCheckTimeout = 0 * time.Second
+
+// checkOutcome is the result of running a single check, kept alongside its
+// definition and wall-clock duration so results can be printed in a stable
+// order once every check has finished.
+type checkOutcome struct {
+	def      checks.CheckDefinition
+	result   checks.CheckResult
+	err      error
+	start    time.Time
+	duration time.Duration
+
+	// workerSlot is the [0, workers) concurrency slot this check ran in —
+	// recorded so the Chrome trace export (see run-metrics.go) can put
+	// checks that actually overlapped in time on distinct trace tracks
+	// instead of stacking every event onto one.
+	workerSlot int
+
+	// workDir is the check's retained temp directory (see
+	// checks.CheckContext.WorkDir), or "" if it was cleaned up or never
+	// created. printFailure prints this next to a failed check's ID.
+	workDir string
+}
+
+// resourceLocks hands out one *sync.Mutex per CheckDefinition.Resources name,
+// created lazily on first use. Unlike DependsOn, holding a resource's lock
+// doesn't depend on another check's outcome — it just keeps two checks that
+// name the same resource (e.g. both shelling out to cargo against the same
+// src-tauri target directory) from running at the same time.
+type resourceLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newResourceLocks() *resourceLocks {
+	return &resourceLocks{locks: make(map[string]*sync.Mutex)}
+}
+
+// lock acquires every named resource's mutex, sorted to keep lock order
+// consistent across checks (otherwise two checks sharing two resources could
+// acquire them in opposite order and deadlock), and returns a func to release
+// them all.
+func (r *resourceLocks) lock(names []string) func() {
+	if len(names) == 0 {
+		return func() {}
+	}
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		r.forName(name).Lock()
+	}
+	return func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			r.forName(sorted[i]).Unlock()
+		}
+	}
+}
+
+func (r *resourceLocks) forName(name string) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lock, ok := r.locks[name]
+	if !ok {
+		lock = &sync.Mutex{}
+		r.locks[name] = lock
+	}
+	return lock
+}
+
+// Runner executes a set of checks, respecting DependsOn edges, with up to
+// Workers running concurrently. Results are always printed in the order the
+// checks were given, regardless of completion order.
+type Runner struct {
+	ctx      *checks.CheckContext
+	defs     []checks.CheckDefinition
+	failFast bool
+
+	Workers   int           // 0 means runtime.NumCPU()
+	Timeout   time.Duration // 0 means no per-check timeout
+	Summary   bool          // print a final PASS/WARN/FAIL count table
+	ShowSkips bool          // print each skipped check's reason instead of staying silent about it (surfaced as --show-skips)
+	Quiet     bool          // suppress report()'s printing; Run() still returns failed/failedChecks
+
+	// Keep, when true, retains every check's checks.CheckContext.WorkDir
+	// instead of only a failed one's (surfaced as --keep, following the `-k`
+	// "keep temporary directory" flag in Go's own test/run.go).
+	Keep bool
+
+	// OnStart, when non-nil, is called once for each check right as it
+	// begins running (in start order, not r.defs order) — lets a TTY
+	// renderer show a check as "in progress" instead of only learning about
+	// it once OnResult reports it finished.
+	OnStart func(def checks.CheckDefinition)
+
+	// OnResult, when non-nil, is called once for each check as soon as it
+	// finishes (in completion order, not r.defs order) — used by `cmdr serve`
+	// to stream results over SSE instead of waiting for the whole run.
+	OnResult func(def checks.CheckDefinition, result checks.CheckResult, err error, duration time.Duration)
+
+	// Outcomes holds every check's result after Run() returns, in r.defs
+	// order — used by --format=json/--format=sarif to collect
+	// CheckResult.Diagnostics across all checks once the (possibly
+	// suppressed, see Quiet) report has been produced.
+	Outcomes []checkOutcome
+}
+
+// NewRunner creates a Runner for the given checks.
+func NewRunner(ctx *checks.CheckContext, defs []checks.CheckDefinition, failFast bool) *Runner {
+	return &Runner{
+		ctx:      ctx,
+		defs:     defs,
+		failFast: failFast,
+		Timeout:  defaultCheckTimeout,
+	}
+}
+
+// Run executes all checks and prints their results. It returns whether any
+// check failed, and the IDs of the failed checks (for the rerun hint).
+func (r *Runner) Run() (bool, []string) {
+	workers := r.Workers
+	if r.ctx.Verbose {
+		// Verbose output from multiple checks interleaves unreadably.
+		workers = 1
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	n := len(r.defs)
+	outcomes := make([]checkOutcome, n)
+	done := make([]chan struct{}, n)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	indexByID := make(map[string]int, n)
+	for i, d := range r.defs {
+		indexByID[d.ID] = i
+	}
+
+	sem := make(chan int, workers)
+	for slot := 0; slot < workers; slot++ {
+		sem <- slot
+	}
+	// abortCtx is the parent every in-flight check's context derives from
+	// (see runOne), so a --fail-fast abort cancels their subprocesses too —
+	// not just checks that haven't started their resource/worker-slot wait
+	// yet.
+	abortCtx, abortCancel := context.WithCancel(context.Background())
+	defer abortCancel()
+	var wg sync.WaitGroup
+	resources := newResourceLocks()
+
+	// Launch slow checks' goroutines first so they're first in line for a
+	// worker slot: IsSlow checks tend to dominate tail latency, so starting
+	// them before the (typically numerous, quick) rest shortens the run's
+	// overall wall-clock time versus launching in declaration order.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return r.defs[order[a]].IsSlow && !r.defs[order[b]].IsSlow
+	})
+
+	for _, i := range order {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(done[i])
+
+			def := r.defs[i]
+
+			if depID, reason, blocked := r.waitForDeps(def, indexByID, done, outcomes); blocked {
+				outcomes[i] = checkOutcome{def: def, result: checks.Skipped(fmt.Sprintf("skipped: dependency %q %s", depID, reason))}
+				return
+			}
+
+			select {
+			case <-abortCtx.Done():
+				outcomes[i] = checkOutcome{def: def, err: fmt.Errorf("skipped: stopped early (--fail-fast)")}
+				return
+			default:
+			}
+
+			// Acquire the resource lock before the worker slot: it can be
+			// held for a while waiting on another check's cargo run, and
+			// blocking on it with a semaphore slot already in hand would
+			// sit that slot idle, starving other ready, non-conflicting
+			// checks of concurrency.
+			unlock := resources.lock(def.Resources)
+			defer unlock()
+
+			slot := <-sem
+			defer func() { sem <- slot }()
+
+			if r.OnStart != nil {
+				r.OnStart(def)
+			}
+
+			start := time.Now()
+			result, err, workDir := r.runOne(def, abortCtx)
+			duration := time.Since(start)
+			outcomes[i] = checkOutcome{def: def, result: result, err: err, start: start, duration: duration, workerSlot: slot, workDir: workDir}
+
+			if r.OnResult != nil {
+				r.OnResult(def, result, err, duration)
+			}
+
+			if err != nil && r.failFast {
+				abortCancel()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	r.Outcomes = outcomes
+	return r.report(outcomes)
+}
+
+// waitForDeps blocks until every dependency of def has finished, and reports
+// the first one that didn't pass along with why ("failed" or "skipped"), so
+// the caller can skip def instead of running it. Dependencies that aren't
+// part of the current run (e.g. filtered out by --check) are ignored.
+// Propagating on a skipped dependency (not just a failed one) lets the skip
+// cascade through a whole chain of dependents, not just the one directly
+// depending on the original failure.
+func (r *Runner) waitForDeps(def checks.CheckDefinition, indexByID map[string]int, done []chan struct{}, outcomes []checkOutcome) (depID string, reason string, blocked bool) {
+	for _, depID := range def.DependsOn {
+		depIdx, ok := indexByID[depID]
+		if !ok {
+			continue
+		}
+		<-done[depIdx]
+		dep := outcomes[depIdx]
+		if dep.err != nil {
+			return depID, "failed", true
+		}
+		if dep.result.Code == checks.ResultSkipped {
+			return depID, "was skipped", true
+		}
+	}
+	return "", "", false
+}
+
+// runOne runs a single check, enforcing r.Timeout if set and, either way,
+// deriving the check's context from abortCtx so a --fail-fast abort
+// cancels an already-running check's subprocess too, not just checks that
+// haven't started yet (see Run). Either cancellation kills any subprocess
+// started via checks.RunCommandWithContext and returns an error immediately;
+// the check's own goroutine is left to unwind in the background. When
+// CheckContext.Update is set and def has an Update hook, that hook runs
+// instead of Run (see CheckDefinition.Update). The third return value is
+// the check's retained checks.CheckContext.WorkDir, or "" if it never
+// created one or was cleaned up (see cleanupWorkDir) — by default a
+// successful check's is deleted, a failed one's is kept, and --keep (r.Keep)
+// keeps both.
+func (r *Runner) runOne(def checks.CheckDefinition, abortCtx context.Context) (checks.CheckResult, error, string) {
+	cancelCtx := abortCtx
+	cancel := func() {}
+	if r.Timeout > 0 {
+		cancelCtx, cancel = context.WithTimeout(abortCtx, r.Timeout)
+	}
+	defer cancel()
+
+	checkCtx := r.ctx.CloneForCheck()
+	checkCtx.Cancel = cancelCtx
+	checkCtx.CheckID = def.ID
+
+	type runResult struct {
+		result checks.CheckResult
+		err    error
+	}
+	run := def.Run
+	if checkCtx.Update && def.Update != nil {
+		run = def.Update
+	}
+
+	resCh := make(chan runResult, 1)
+	go func() {
+		result, err := run(checkCtx)
+		resCh <- runResult{result, err}
+	}()
+
+	select {
+	case res := <-resCh:
+		return res.result, res.err, r.cleanupWorkDir(checkCtx, res.err)
+	case <-cancelCtx.Done():
+		// The check's goroutine may still be writing to its work dir in the
+		// background (see the doc comment above), so it's left untouched
+		// here rather than cleaned up or reported as retained.
+		if r.Timeout > 0 && cancelCtx.Err() == context.DeadlineExceeded {
+			return checks.CheckResult{}, fmt.Errorf("timed out after %s", formatDuration(r.Timeout)), ""
+		}
+		return checks.CheckResult{}, fmt.Errorf("stopped early (--fail-fast)"), ""
+	}
+}
+
+// cleanupWorkDir deletes checkCtx's work directory (if WorkDir was ever
+// called during the check) unless the check failed or --keep was passed,
+// and returns the path that survives the decision ("" if deleted or never
+// created).
+func (r *Runner) cleanupWorkDir(checkCtx *checks.CheckContext, checkErr error) string {
+	dir := checkCtx.CreatedWorkDir()
+	if dir == "" {
+		return ""
+	}
+	if checkErr == nil && !r.Keep {
+		_ = os.RemoveAll(dir)
+		return ""
+	}
+	return dir
+}
+
+// report prints each outcome in order and returns whether the run failed
+// overall, plus the IDs to suggest for --check on rerun.
+func (r *Runner) report(outcomes []checkOutcome) (bool, []string) {
+	failed := false
+	var failedChecks []string
+	var passCount, warnCount, failCount, skipCount int
+
+	for _, o := range outcomes {
+		switch {
+		case o.err != nil:
+			failed = true
+			failCount++
+			failedChecks = append(failedChecks, o.def.CLIName())
+			if !r.Quiet {
+				fmt.Printf("%s✗ %s%s (%s)\n", colorRed, o.def.DisplayName, colorReset, formatDuration(o.duration))
+				fmt.Print(indentOutput(o.err.Error(), "  "))
+			}
+		case o.result.Code == checks.ResultSkipped:
+			skipCount++
+			// Unlike warnings and failures, a skip isn't something to act on
+			// by default — test/run.go stays just as quiet about them unless
+			// asked, so a run missing a toolchain doesn't drown in noise.
+			if !r.Quiet && r.ShowSkips {
+				fmt.Printf("%s- %s: %s%s\n", colorYellow, o.def.DisplayName, o.result.Message, colorReset)
+			}
+		case o.result.Code == checks.ResultWarning:
+			warnCount++
+			if !r.Quiet {
+				fmt.Printf("%s⚠ %s%s (%s)\n", colorYellow, o.def.DisplayName, colorReset, formatDuration(o.duration))
+				fmt.Print(indentOutput(o.result.Message, "  "))
+			}
+		default:
+			passCount++
+			if !r.Quiet {
+				fmt.Printf("%s✓ %s%s (%s) %s\n", colorGreen, o.def.DisplayName, colorReset, formatDuration(o.duration), o.result.Message)
+			}
+		}
+	}
+
+	if r.Summary && !r.Quiet {
+		fmt.Println()
+		fmt.Printf("%-6s %d\n", "PASS", passCount)
+		fmt.Printf("%-6s %d\n", "WARN", warnCount)
+		fmt.Printf("%-6s %d\n", "FAIL", failCount)
+		if skipCount > 0 {
+			fmt.Printf("%-6s %d\n", "SKIP", skipCount)
+		}
+	}
+
+	return failed, failedChecks
+}
+
+// indentOutput indents each non-empty line of s with prefix.
+func indentOutput(s, prefix string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	var sb strings.Builder
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		sb.WriteString(prefix)
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// formatDuration renders a duration the way `go test` does: seconds with
+// two decimal places for anything at or above a second, milliseconds below.
+func formatDuration(d time.Duration) string {
+	if d >= time.Second {
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"vmail/scripts/check/checks"
+	"vmail/scripts/check/checks/config"
+	"vmail/scripts/check/checks/report"
+	"vmail/scripts/check/checks/tokens"
+)
+
+// defaultServeAddr is where `cmdr serve` listens by default.
+const defaultServeAddr = "localhost:1313"
+
+// watchDebounce coalesces the burst of fsnotify events a single save
+// generates (write + chmod on most editors) into one rerun.
+const watchDebounce = 200 * time.Millisecond
+
+// serveState holds the dashboard's current results and the set of SSE
+// clients waiting to be notified of new ones.
+type serveState struct {
+	mu   sync.Mutex
+	defs []checks.CheckDefinition
+	byID map[string]int
+	rows []report.CheckView
+
+	subs map[chan struct{}]bool
+}
+
+func newServeState(defs []checks.CheckDefinition) *serveState {
+	byID := make(map[string]int, len(defs))
+	rows := make([]report.CheckView, len(defs))
+	for i, def := range defs {
+		byID[def.ID] = i
+		rows[i] = report.CheckView{Definition: def}
+	}
+	return &serveState{defs: defs, byID: byID, rows: rows, subs: map[chan struct{}]bool{}}
+}
+
+// reset clears every row back to its not-yet-run state, at the start of a rerun.
+func (s *serveState) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, def := range s.defs {
+		s.rows[i] = report.CheckView{Definition: def}
+	}
+}
+
+// record stores a single check's result and wakes any subscribed SSE client.
+func (s *serveState) record(def checks.CheckDefinition, result checks.CheckResult, err error, duration time.Duration) {
+	s.mu.Lock()
+	if i, ok := s.byID[def.ID]; ok {
+		s.rows[i] = report.CheckView{Definition: def, Result: result, Err: err, Duration: duration}
+	}
+	subs := make([]chan struct{}, 0, len(s.subs))
+	for ch := range s.subs {
+		subs = append(subs, ch)
+	}
+	s.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- struct{}{}:
+		default: // a client that's behind just misses this tick; the next one still reloads it
+		}
+	}
+}
+
+func (s *serveState) snapshot() []report.CheckView {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rows := make([]report.CheckView, len(s.rows))
+	copy(rows, s.rows)
+	return rows
+}
+
+func (s *serveState) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.subs[ch] = true
+	s.mu.Unlock()
+	return ch
+}
+
+func (s *serveState) unsubscribe(ch chan struct{}) {
+	s.mu.Lock()
+	delete(s.subs, ch)
+	s.mu.Unlock()
+}
+
+// runServeCommand implements `cmdr serve`: runs the whole check suite, hosts
+// the results as a live HTML dashboard, and re-runs everything whenever a
+// file under rootDir changes.
+//
+// Scope note: unlike `cmdr watch` (see watch.go), this reruns every selected
+// check on every change rather than tracking per-check input globs — the
+// dashboard's job is to stay live, not to minimize rerun cost.
+func runServeCommand(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", defaultServeAddr, "Address to listen on")
+	workers := fs.Int("n", runtime.NumCPU(), "Number of checks to run concurrently")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	rootDir, err := findRootDir()
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(rootDir)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	if _, err := checks.LoadRegistry(filepath.Join(rootDir, "cmdr-checks.toml"), checks.AllChecks); err != nil {
+		printError("Bad check registry: %v", err)
+		os.Exit(1)
+	}
+
+	checksToRun := filterDisabledChecks(checks.FilterSlowChecks(checks.ActiveChecks(), false), cfg)
+	state := newServeState(checksToRun)
+
+	runAll := func() {
+		fmt.Println("🔍 cmdr serve: re-running checks...")
+		state.reset()
+		ctx := &checks.CheckContext{RootDir: rootDir, Config: cfg}
+		runner := NewRunner(ctx, checksToRun, false)
+		runner.Workers = *workers
+		runner.OnResult = state.record
+		runner.Run()
+	}
+	runAll()
+
+	go watchForChanges(rootDir, runAll)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		page, err := report.RenderPage(state.snapshot())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, page)
+	})
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		serveEvents(w, r, state)
+	})
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		serveFileView(w, r, rootDir)
+	})
+
+	fmt.Printf("🔍 cmdr serve listening on http://%s\n", *addr)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+}
+
+// serveEvents is the SSE endpoint the dashboard's <script> subscribes to: it
+// sends one event per rerun, and the page just reloads itself on receipt
+// rather than trying to patch the DOM in place.
+func serveEvents(w http.ResponseWriter, r *http.Request, state *serveState) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := state.subscribe()
+	defer state.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			fmt.Fprint(w, "data: update\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// serveFileView handles /file?path=..., rendering a line-numbered view of a
+// file under rootDir with the same (lines, kB, tokens) summary RunFileLength
+// reports.
+func serveFileView(w http.ResponseWriter, r *http.Request, rootDir string) {
+	relPath := r.URL.Query().Get("path")
+	if relPath == "" || strings.Contains(relPath, "..") {
+		http.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	absPath := filepath.Join(rootDir, relPath)
+	data, err := os.ReadFile(absPath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	tokenCount, err := tokens.CountTokens(strings.NewReader(string(data)), filepath.Ext(relPath))
+	tokenSummary := "unknown tokens"
+	if err == nil {
+		tokenSummary = fmt.Sprintf("%d tokens", tokenCount)
+	}
+
+	html, err := report.RenderFileContent(relPath, string(data), int64(len(data)), tokenSummary)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, html)
+}
+
+// watchForChanges calls rerun whenever a file under rootDir changes,
+// debounced so a single save (which fsnotify usually reports as several
+// events) only triggers one rerun.
+func watchForChanges(rootDir string, rerun func()) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		printError("serve: fsnotify unavailable, live reload disabled: %v", err)
+		return
+	}
+	defer watcher.Close()
+
+	if err := addWatchDirs(watcher, rootDir); err != nil {
+		printError("serve: failed to watch %s: %v", rootDir, err)
+		return
+	}
+
+	var timer *time.Timer
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if strings.Contains(event.Name, ".git"+string(filepath.Separator)) {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(watchDebounce, rerun)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			printError("serve: watch error: %v", err)
+		}
+	}
+}
+
+// addWatchDirs recursively adds rootDir and its subdirectories to watcher,
+// skipping the same directories RunFileLength ignores plus .git — fsnotify
+// only watches the directories it's explicitly told about, not a whole tree.
+func addWatchDirs(watcher *fsnotify.Watcher, rootDir string) error {
+	return filepath.Walk(rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if name != "." && strings.HasPrefix(name, ".") {
+			return filepath.SkipDir
+		}
+		if fileLengthSkipDirName(name) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// fileLengthSkipDirName mirrors checks.fileLengthSkipDirs' default set,
+// kept separate since that map is unexported inside the checks package.
+func fileLengthSkipDirName(name string) bool {
+	switch name {
+	case "build", "dist", "node_modules", "target":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"vmail/scripts/check/checks"
+)
+
+// filterByShard keeps only the checks in checksToRun whose ID hashes (FNV-1a)
+// to shard mod shards, the same deterministic-partitioning approach Go's own
+// test/run.go uses to split a test list across CI workers: every shard's
+// filter runs over the identical input list and ID, so two shards never
+// double-run or skip a check as long as they agree on shards.
+//
+// DependsOn edges that land in a different shard are simply absent from this
+// shard's run; waitForDeps already treats a dependency outside the current
+// run as satisfied, so a shard never deadlocks waiting on a check another
+// shard owns.
+func filterByShard(checksToRun []checks.CheckDefinition, shard, shards int) []checks.CheckDefinition {
+	if shards <= 1 {
+		return checksToRun
+	}
+
+	var result []checks.CheckDefinition
+	for _, check := range checksToRun {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(check.ID))
+		if int(h.Sum32()%uint32(shards)) == shard {
+			result = append(result, check)
+		}
+	}
+	return result
+}
+
+// validateShardFlags checks --shard/--shards for the constraints
+// filterByShard assumes: at least one shard, and shard naming one that
+// exists.
+func validateShardFlags(shard, shards int) error {
+	if shards < 1 {
+		return fmt.Errorf("--shards must be >= 1, got %d", shards)
+	}
+	if shard < 0 || shard >= shards {
+		return fmt.Errorf("--shard must satisfy 0 <= shard < shards (got --shard=%d --shards=%d)", shard, shards)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"vmail/scripts/check/checks"
+	"vmail/scripts/check/checks/toolchain"
+)
+
+// runToolsCommand implements the `cmdr tools` subcommand family.
+func runToolsCommand(args []string) {
+	if len(args) == 0 {
+		printToolsUsage()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "sync":
+		runToolsSyncCommand()
+	case "update":
+		runToolsUpdateCommand(args[1:])
+	default:
+		printToolsUsage()
+		os.Exit(1)
+	}
+}
+
+func printToolsUsage() {
+	fmt.Println("Usage: go run ./scripts/check tools sync")
+	fmt.Println("       go run ./scripts/check tools update <name> <version>")
+}
+
+func runToolsSyncCommand() {
+	rootDir, err := findRootDir()
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	installed, err := toolchain.Sync(rootDir)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	for _, bin := range installed {
+		fmt.Printf("  %s\n", bin)
+	}
+	fmt.Printf("Synced %d %s from tools.lock\n", len(installed), checks.Pluralize(len(installed), "tool", "tools"))
+}
+
+// runToolsUpdateCommand bumps a single tool's pin in tools.lock, then
+// resyncs so the new version is installed (and verified) right away rather
+// than only on the next check run.
+func runToolsUpdateCommand(args []string) {
+	if len(args) != 2 {
+		printToolsUsage()
+		os.Exit(1)
+	}
+	name, version := args[0], args[1]
+
+	rootDir, err := findRootDir()
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	if err := toolchain.UpdateLock(rootDir, name, version); err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	bin, err := toolchain.Ensure(rootDir, name)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Updated %s to %s, installed at %s\n", name, version, bin)
+}
@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"vmail/scripts/check/checks"
 )
 
 // runCommand executes a command and optionally captures its output.
@@ -142,26 +144,14 @@ func addGoPathToPath() {
 	}
 }
 
-// findRootDir finds the project root directory by looking for backend/go.mod and frontend/package.json.
+// findRootDir locates the repository root by delegating to
+// checks.FindRootDir, so `cmdr check` (and `cmdr baseline update`) resolve
+// apps/desktop, scripts, etc. relative to the real root no matter which
+// subdirectory of the repo they're invoked from.
 func findRootDir() (string, error) {
 	dir, err := os.Getwd()
 	if err != nil {
 		return "", err
 	}
-
-	for {
-		// Check if this is the project root by looking for backend/go.mod and frontend/package.json
-		backendGoMod := filepath.Join(dir, "backend", "go.mod")
-		frontendPackageJson := filepath.Join(dir, "frontend", "package.json")
-		if _, err := os.Stat(backendGoMod); err == nil {
-			if _, err := os.Stat(frontendPackageJson); err == nil {
-				return dir, nil
-			}
-		}
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			return "", fmt.Errorf("could not find project root (looking for backend/go.mod and frontend/package.json)")
-		}
-		dir = parent
-	}
+	return checks.FindRootDir(dir)
 }
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"vmail/scripts/check/checks"
+	"vmail/scripts/check/checks/config"
+)
+
+// runWatchCommand implements `cmdr watch`: watches ctx.RootDir with fsnotify
+// (reusing `cmdr serve`'s addWatchDirs/watchDebounce, see serve.go) and
+// reruns only the checks whose CheckDefinition.Inputs glob matches a changed
+// file, instead of the whole suite serve.go's watchForChanges always
+// reruns — the two commands solve different problems (a live dashboard vs.
+// a tight local edit/check loop) and share the watching plumbing but not the
+// rerun policy.
+func runWatchCommand(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	workers := fs.Int("n", runtime.NumCPU(), "Number of checks to run concurrently")
+	includeSlow := fs.Bool("include-slow", false, "Include slow/Docker-gated checks (desktop-e2e-linux, rust-tests-linux)")
+	clear := fs.Bool("clear", false, "Clear the terminal before each rerun")
+	postpone := fs.Bool("postpone", false, "Wait for the first change before running anything")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	rootDir, err := findRootDir()
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(rootDir)
+	if err != nil {
+		printError("Error: %v", err)
+		os.Exit(1)
+	}
+
+	if _, err := checks.LoadRegistry(filepath.Join(rootDir, "cmdr-checks.toml"), checks.AllChecks); err != nil {
+		printError("Bad check registry: %v", err)
+		os.Exit(1)
+	}
+
+	checksToRun := filterDisabledChecks(checks.FilterSlowChecks(checks.ActiveChecks(), *includeSlow), cfg)
+	if len(checksToRun) == 0 {
+		fmt.Println("No checks to run.")
+		os.Exit(0)
+	}
+
+	runSelected := func(defs []checks.CheckDefinition) {
+		if *clear {
+			fmt.Print("\033[H\033[2J")
+		}
+		fmt.Printf("🔍 cmdr watch: running %d %s...\n", len(defs), checks.Pluralize(len(defs), "check", "checks"))
+		ctx := &checks.CheckContext{RootDir: rootDir, Config: cfg}
+		runner := NewRunner(ctx, defs, false)
+		runner.Workers = *workers
+		runner.Summary = true
+		runner.Run()
+	}
+
+	if !*postpone {
+		runSelected(checksToRun)
+	}
+
+	watchAndRerunAffected(rootDir, checksToRun, runSelected)
+}
+
+// watchAndRerunAffected blocks watching rootDir, and once per debounce
+// window (see watchDebounce in serve.go) reruns whichever of checksToRun are
+// affected by the files that changed in that window.
+func watchAndRerunAffected(rootDir string, checksToRun []checks.CheckDefinition, rerun func([]checks.CheckDefinition)) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		printError("watch: fsnotify unavailable: %v", err)
+		os.Exit(1)
+	}
+	defer watcher.Close()
+
+	if err := addWatchDirs(watcher, rootDir); err != nil {
+		printError("watch: failed to watch %s: %v", rootDir, err)
+		os.Exit(1)
+	}
+
+	var mu sync.Mutex
+	changed := map[string]bool{}
+	var timer *time.Timer
+
+	// fire runs on time.AfterFunc's own goroutine, concurrently with the
+	// event loop below still adding to changed for the *next* debounce
+	// window, so both sides of changed need the same mutex.
+	fire := func() {
+		mu.Lock()
+		paths := make([]string, 0, len(changed))
+		for p := range changed {
+			paths = append(paths, p)
+		}
+		changed = map[string]bool{}
+		mu.Unlock()
+
+		if affected := affectedChecks(checksToRun, rootDir, paths); len(affected) > 0 {
+			rerun(affected)
+		}
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if strings.Contains(event.Name, ".git"+string(filepath.Separator)) {
+				continue
+			}
+			mu.Lock()
+			changed[event.Name] = true
+			mu.Unlock()
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(watchDebounce, fire)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			printError("watch: error: %v", err)
+		}
+	}
+}
+
+// affectedChecks returns the subset of checksToRun affected by changedPaths
+// (absolute paths from fsnotify): a check is affected if it declares no
+// Inputs (treated as always-affected, see CheckDefinition.Inputs) or if any
+// of its Inputs globs matches at least one changed path, relativized to
+// rootDir.
+func affectedChecks(checksToRun []checks.CheckDefinition, rootDir string, changedPaths []string) []checks.CheckDefinition {
+	relPaths := make([]string, 0, len(changedPaths))
+	for _, p := range changedPaths {
+		rel, err := filepath.Rel(rootDir, p)
+		if err != nil {
+			continue
+		}
+		relPaths = append(relPaths, filepath.ToSlash(rel))
+	}
+
+	var affected []checks.CheckDefinition
+	for _, def := range checksToRun {
+		if len(def.Inputs) == 0 {
+			affected = append(affected, def)
+			continue
+		}
+		if checkMatchesAny(def, relPaths) {
+			affected = append(affected, def)
+		}
+	}
+	return affected
+}
+
+// checkMatchesAny reports whether any of def.Inputs matches any of relPaths.
+func checkMatchesAny(def checks.CheckDefinition, relPaths []string) bool {
+	for _, input := range def.Inputs {
+		for _, rel := range relPaths {
+			if checks.MatchInputGlob(input, rel) {
+				return true
+			}
+		}
+	}
+	return false
+}
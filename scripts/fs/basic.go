@@ -0,0 +1,141 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+
+	"vmail/scripts/walk"
+)
+
+// BasicFilesystem is a Filesystem backed by a real directory on disk.
+type BasicFilesystem struct {
+	root string
+}
+
+// NewBasicFilesystem returns a Filesystem rooted at root, backed by the
+// real filesystem.
+func NewBasicFilesystem(root string) *BasicFilesystem {
+	return &BasicFilesystem{root: root}
+}
+
+func (b *BasicFilesystem) Type() string { return "basic" }
+
+func (b *BasicFilesystem) URI() string { return "file://" + filepath.ToSlash(b.root) }
+
+func (b *BasicFilesystem) resolve(path string) string {
+	return filepath.Join(b.root, filepath.FromSlash(path))
+}
+
+func (b *BasicFilesystem) List(path string) ([]os.FileInfo, error) {
+	entries, err := os.ReadDir(b.resolve(path))
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+func (b *BasicFilesystem) Stat(path string) (os.FileInfo, error) {
+	return os.Stat(b.resolve(path))
+}
+
+func (b *BasicFilesystem) Lstat(path string) (os.FileInfo, error) {
+	return os.Lstat(b.resolve(path))
+}
+
+func (b *BasicFilesystem) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(b.resolve(path))
+}
+
+func (b *BasicFilesystem) Walk(path string, fn func(path string, info os.FileInfo) error) error {
+	root := b.resolve(path)
+	return walk.Parallel(root, 0, func(absPath string, info os.FileInfo) error {
+		rel, err := filepath.Rel(b.root, absPath)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(rel), info)
+	})
+}
+
+// Watch streams fsnotify events for every directory under path (added
+// recursively up front, same as cmdr watch's addWatchDirs) until stop is
+// closed, translating fsnotify's Op bitmask to the single dominant EventOp
+// each Event reports.
+func (b *BasicFilesystem) Watch(path string, stop <-chan struct{}) (<-chan Event, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	root := b.resolve(path)
+	walkErr := filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		return watcher.Add(p)
+	})
+	if walkErr != nil {
+		watcher.Close()
+		return nil, walkErr
+	}
+
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		defer watcher.Close()
+		for {
+			select {
+			case <-stop:
+				return
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				rel, err := filepath.Rel(b.root, ev.Name)
+				if err != nil {
+					continue
+				}
+				events <- Event{Path: filepath.ToSlash(rel), Op: translateOp(ev.Op)}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				// fsnotify has no way to say which paths it missed - most
+				// often this is the inotify queue overflowing under a big
+				// enough burst - so report it as an OpOverflow instead of
+				// silently dropping it, letting the caller fall back to a
+				// full rescan of path.
+				events <- Event{Op: OpOverflow}
+			}
+		}
+	}()
+	return events, nil
+}
+
+// translateOp picks the single EventOp that best describes op, in priority
+// order remove > rename > write > create — fsnotify's Op is a bitmask, but
+// Event reports one dominant kind of change, same simplification
+// cmdr watch's affectedChecks already makes by treating every fsnotify
+// event identically.
+func translateOp(op fsnotify.Op) EventOp {
+	switch {
+	case op&fsnotify.Remove != 0:
+		return OpRemove
+	case op&fsnotify.Rename != 0:
+		return OpRename
+	case op&fsnotify.Write != 0:
+		return OpWrite
+	default:
+		return OpCreate
+	}
+}
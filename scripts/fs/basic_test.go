@@ -0,0 +1,126 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestBasicFilesystem_ListReturnsDirectChildren(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), "a")
+	writeFile(t, filepath.Join(root, "sub", "b.txt"), "b")
+
+	bfs := NewBasicFilesystem(root)
+	entries, err := bfs.List(".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+}
+
+func TestBasicFilesystem_StatAndReadFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), "hello")
+
+	bfs := NewBasicFilesystem(root)
+	info, err := bfs.Stat("a.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Size() != 5 {
+		t.Errorf("expected size 5, got %d", info.Size())
+	}
+
+	data, err := bfs.ReadFile("a.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "hello" {
+		t.Errorf("expected %q, got %q", "hello", data)
+	}
+}
+
+func TestBasicFilesystem_WalkVisitsEveryEntryWithRootRelativePaths(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), "a")
+	writeFile(t, filepath.Join(root, "sub", "b.txt"), "b")
+
+	bfs := NewBasicFilesystem(root)
+	var got []string
+	err := bfs.Walk(".", func(path string, info os.FileInfo) error {
+		got = append(got, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]bool{"a.txt": true, "sub": true, "sub/b.txt": true}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for _, path := range got {
+		if !want[path] {
+			t.Errorf("unexpected path %q in %v", path, got)
+		}
+	}
+}
+
+func TestBasicFilesystem_WatchReportsWrite(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), "a")
+
+	bfs := NewBasicFilesystem(root)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	events, err := bfs.Watch(".", stop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "a.txt"), []byte("changed"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Path != "a.txt" {
+			t.Errorf("expected event for a.txt, got %q", ev.Path)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a write event")
+	}
+}
+
+func TestParseURI_BarePathAndFileScheme(t *testing.T) {
+	for _, uri := range []string{"/tmp/repo", "file:///tmp/repo"} {
+		f, err := ParseURI(uri)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", uri, err)
+		}
+		if f.Type() != "basic" {
+			t.Errorf("expected a basic filesystem for %q, got type %q", uri, f.Type())
+		}
+	}
+}
+
+func TestParseURI_UnknownSchemeErrors(t *testing.T) {
+	if _, err := ParseURI("s3://bucket/key"); err == nil {
+		t.Fatal("expected an error for an unregistered scheme")
+	}
+}
@@ -0,0 +1,81 @@
+package fs
+
+import "time"
+
+// RefreshReason tells a CoalescedWatch subscriber how much of its state to
+// rebuild in response to a signal.
+type RefreshReason int
+
+const (
+	// RefreshIncremental means the events coalesced into this signal are
+	// known individually - a caller tracking a file list can apply them
+	// directly instead of re-listing from scratch.
+	RefreshIncremental RefreshReason = iota
+
+	// RefreshFull means at least one OpOverflow event coalesced into this
+	// signal, so the caller can no longer trust any incremental state it
+	// was tracking and should re-list path from scratch.
+	RefreshFull
+)
+
+// CoalescedWatch wraps fsys.Watch(path, stop) into the shape a directory
+// model actually wants: not a firehose of per-file Events, but "it's time to
+// refresh" signals debounced by debounce, so a burst of events (an editor's
+// create+write+rename save-storm is the canonical case) collapses into one
+// refresh instead of one per event. Any OpOverflow event folds the whole
+// burst it's part of into a RefreshFull signal instead of RefreshIncremental,
+// so a caller that can't afford to trust an incomplete incremental update
+// falls back to a full rescan.
+//
+// The returned channel is closed once fsys.Watch's Events channel closes or
+// stop fires.
+func CoalescedWatch(fsys Filesystem, path string, debounce time.Duration, stop <-chan struct{}) (<-chan RefreshReason, error) {
+	events, err := fsys.Watch(path, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan RefreshReason)
+	go func() {
+		defer close(out)
+
+		var timer *time.Timer
+		var timerC <-chan time.Time
+		pending := RefreshIncremental
+
+		for {
+			select {
+			case ev, ok := <-events:
+				if !ok {
+					return
+				}
+				if ev.Op == OpOverflow {
+					pending = RefreshFull
+				}
+				if timer == nil {
+					timer = time.NewTimer(debounce)
+					timerC = timer.C
+				} else {
+					if !timer.Stop() {
+						<-timer.C
+					}
+					timer.Reset(debounce)
+				}
+
+			case <-timerC:
+				select {
+				case out <- pending:
+				case <-stop:
+					return
+				}
+				pending = RefreshIncremental
+				timer = nil
+				timerC = nil
+
+			case <-stop:
+				return
+			}
+		}
+	}()
+	return out, nil
+}
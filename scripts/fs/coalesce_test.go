@@ -0,0 +1,93 @@
+package fs
+
+import (
+	"testing"
+	"time"
+)
+
+// stubWatchFilesystem is a minimal Filesystem whose Watch returns a caller-
+// supplied events channel, so CoalescedWatch's debounce/overflow logic can
+// be tested without a real fsnotify watcher.
+type stubWatchFilesystem struct {
+	Filesystem
+	events chan Event
+}
+
+func (s *stubWatchFilesystem) Watch(path string, stop <-chan struct{}) (<-chan Event, error) {
+	return s.events, nil
+}
+
+func TestCoalescedWatch_BurstOfEventsProducesOneIncrementalRefresh(t *testing.T) {
+	stub := &stubWatchFilesystem{events: make(chan Event, 10)}
+	stop := make(chan struct{})
+	defer close(stop)
+
+	out, err := CoalescedWatch(stub, ".", 20*time.Millisecond, stop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stub.events <- Event{Path: "a.txt", Op: OpCreate}
+	stub.events <- Event{Path: "a.txt", Op: OpWrite}
+	stub.events <- Event{Path: "a.txt", Op: OpRename}
+
+	select {
+	case reason := <-out:
+		if reason != RefreshIncremental {
+			t.Errorf("expected RefreshIncremental, got %v", reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a refresh signal")
+	}
+
+	select {
+	case reason := <-out:
+		t.Fatalf("expected the burst to coalesce into a single refresh, got a second one (%v)", reason)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCoalescedWatch_OverflowEventProducesFullRefresh(t *testing.T) {
+	stub := &stubWatchFilesystem{events: make(chan Event, 10)}
+	stop := make(chan struct{})
+	defer close(stop)
+
+	out, err := CoalescedWatch(stub, ".", 20*time.Millisecond, stop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stub.events <- Event{Path: "a.txt", Op: OpWrite}
+	stub.events <- Event{Op: OpOverflow}
+
+	select {
+	case reason := <-out:
+		if reason != RefreshFull {
+			t.Errorf("expected RefreshFull, got %v", reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a refresh signal")
+	}
+}
+
+func TestCoalescedWatch_ClosesWhenUnderlyingWatchCloses(t *testing.T) {
+	stub := &stubWatchFilesystem{events: make(chan Event)}
+	stop := make(chan struct{})
+	defer close(stop)
+
+	out, err := CoalescedWatch(stub, ".", 20*time.Millisecond, stop)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	close(stub.events)
+
+	select {
+	case _, ok := <-out:
+		if ok {
+			t.Error("expected the output channel to be closed, got a value")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the output channel to close")
+	}
+}
@@ -0,0 +1,156 @@
+package fs
+
+import (
+	"os"
+	"testing"
+)
+
+func TestFakeFilesystem_FlatLayoutListsAllFiles(t *testing.T) {
+	f := NewFakeFilesystem(5, 0, 500, 1, 0)
+	entries, err := f.List(".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(entries))
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			t.Errorf("expected a flat layout to contain only files, got dir %q", e.Name())
+		}
+	}
+}
+
+func TestFakeFilesystem_HierarchicalLayoutSplitsFilesAcrossDirs(t *testing.T) {
+	f := NewFakeFilesystem(10, 3, 1000, 1, 0)
+	dirs, err := f.List(".")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dirs) != 3 {
+		t.Fatalf("expected 3 directories, got %d", len(dirs))
+	}
+
+	total := 0
+	for _, d := range dirs {
+		children, err := f.List(d.Name())
+		if err != nil {
+			t.Fatalf("unexpected error listing %q: %v", d.Name(), err)
+		}
+		total += len(children)
+	}
+	if total != 10 {
+		t.Errorf("expected 10 files spread across all dirs, got %d", total)
+	}
+}
+
+func TestFakeFilesystem_SameSeedIsDeterministic(t *testing.T) {
+	a := NewFakeFilesystem(20, 4, 2000, 42, 0)
+	b := NewFakeFilesystem(20, 4, 2000, 42, 0)
+
+	infoA, err := a.Stat("dir-00000/file-000000.dat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	infoB, err := b.Stat("dir-00000/file-000000.dat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if infoA.Size() != infoB.Size() || !infoA.ModTime().Equal(infoB.ModTime()) {
+		t.Errorf("expected identical metadata for the same seed, got %+v vs %+v", infoA, infoB)
+	}
+
+	dataA, err := a.ReadFile("dir-00000/file-000000.dat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dataB, err := b.ReadFile("dir-00000/file-000000.dat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(dataA) != string(dataB) {
+		t.Error("expected identical file contents for the same seed")
+	}
+}
+
+func TestFakeFilesystem_DifferentSeedsDiverge(t *testing.T) {
+	a := NewFakeFilesystem(20, 0, 2000, 1, 0)
+	b := NewFakeFilesystem(20, 0, 2000, 2, 0)
+
+	dataA, _ := a.ReadFile("file-000000.dat")
+	dataB, _ := b.ReadFile("file-000000.dat")
+	if string(dataA) == string(dataB) {
+		t.Error("expected different seeds to diverge")
+	}
+}
+
+func TestFakeFilesystem_ReadFileMatchesStatSize(t *testing.T) {
+	f := NewFakeFilesystem(3, 0, 300, 7, 0)
+	info, err := f.Stat("file-000001.dat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := f.ReadFile("file-000001.dat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if int64(len(data)) != info.Size() {
+		t.Errorf("expected ReadFile to return %d bytes, got %d", info.Size(), len(data))
+	}
+}
+
+func TestFakeFilesystem_MaxSizeCapsPerFileSize(t *testing.T) {
+	f := NewFakeFilesystem(1, 0, 1_000_000, 1, 100)
+	info, err := f.Stat("file-000000.dat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Size() > 100 {
+		t.Errorf("expected size to be capped at 100, got %d", info.Size())
+	}
+}
+
+func TestFakeFilesystem_StatUnknownPathErrors(t *testing.T) {
+	f := NewFakeFilesystem(5, 0, 500, 1, 0)
+	if _, err := f.Stat("does-not-exist.dat"); !os.IsNotExist(err) {
+		t.Errorf("expected a not-exist error, got %v", err)
+	}
+}
+
+func TestFakeFilesystem_WalkVisitsEveryDirAndFileExactlyOnce(t *testing.T) {
+	f := NewFakeFilesystem(10, 3, 1000, 1, 0)
+	seen := map[string]bool{}
+	err := f.Walk(".", func(path string, info os.FileInfo) error {
+		if seen[path] {
+			t.Errorf("visited %q more than once", path)
+		}
+		seen[path] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(seen) != 3+10 {
+		t.Errorf("expected 3 dirs + 10 files = 13 entries, got %d", len(seen))
+	}
+}
+
+func TestParseFakeURI_ParsesAllParameters(t *testing.T) {
+	f, err := ParseFakeURI("?files=100000&dirs=1000&size=2000000000&seed=42&maxsize=65536")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.files != 100000 || f.dirs != 1000 || f.size != 2_000_000_000 || f.seed != 42 || f.maxSize != 65536 {
+		t.Errorf("unexpected parsed FakeFilesystem: %+v", f)
+	}
+}
+
+func TestParseURI_FakeScheme(t *testing.T) {
+	fsys, err := ParseURI("fake://?files=5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fsys.Type() != "fake" {
+		t.Errorf("expected type fake, got %q", fsys.Type())
+	}
+}
@@ -0,0 +1,99 @@
+// Package fs provides a pluggable filesystem abstraction for cmdr's
+// directory-scanning tools (scripts/test-data-generator today; scripts/check
+// can adopt it incrementally), so a scan or scenario can run against a real
+// directory or an in-memory fake tree interchangeably. This mirrors the
+// filesystem abstraction syncthing uses for its folder configs: a type + URI
+// identifies the backend, and every IO operation is routed through the
+// interface instead of calling os.ReadDir/os.Stat/filepath.Walk directly.
+package fs
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Filesystem is the set of directory-tree operations cmdr's tools need:
+// listing, stat'ing, reading, and walking a tree, plus watching it for
+// external changes. BasicFilesystem (basic.go) implements it against the
+// real OS; FakeFilesystem lazily synthesizes a tree in memory instead,
+// without touching disk.
+type Filesystem interface {
+	// Type identifies the backend, e.g. "basic" or "fake" — the scheme half
+	// of the URI a Filesystem was built from.
+	Type() string
+
+	// URI returns the URI ParseURI would need to reconstruct this
+	// Filesystem, e.g. "file:///home/user/repo" or "fake://?files=100000".
+	URI() string
+
+	// List returns the directory entries directly inside path, which is
+	// root-relative and slash-separated regardless of OS.
+	List(path string) ([]os.FileInfo, error)
+
+	// Stat returns path's FileInfo, following symlinks.
+	Stat(path string) (os.FileInfo, error)
+
+	// Lstat returns path's FileInfo, not following a symlink at path itself.
+	Lstat(path string) (os.FileInfo, error)
+
+	// ReadFile returns path's full contents.
+	ReadFile(path string) ([]byte, error)
+
+	// Walk calls fn once per entry (directories included) under path, same
+	// visitation contract as scripts/walk.Parallel: order isn't guaranteed,
+	// and Walk stops and returns fn's error as soon as one occurs.
+	Walk(path string, fn func(path string, info os.FileInfo) error) error
+
+	// Watch streams Events for changes under path until stop is closed.
+	// BasicFilesystem backs this with fsnotify; a backend with no external
+	// source of truth to watch (FakeFilesystem) can return a channel that's
+	// simply never written to.
+	Watch(path string, stop <-chan struct{}) (<-chan Event, error)
+}
+
+// EventOp describes what kind of change an Event reports.
+type EventOp int
+
+const (
+	OpCreate EventOp = iota
+	OpWrite
+	OpRemove
+	OpRename
+
+	// OpOverflow reports that Watch itself lost track of individual
+	// changes - fsnotify surfaced an error, most commonly the kernel's
+	// inotify queue overflowing under a big enough burst of events - rather
+	// than any specific file changing. Event.Path is empty for this op; a
+	// caller should treat it as "assume anything under path may have
+	// changed" and fall back to a full rescan instead of trusting whatever
+	// incremental state it was tracking (see CoalescedWatch).
+	OpOverflow
+)
+
+// Event reports a single change Watch observed at Path. Path is empty for
+// OpOverflow, which isn't about any one file.
+type Event struct {
+	Path string
+	Op   EventOp
+}
+
+// ParseURI builds the Filesystem a URI names. A bare path or a "file://"
+// URI builds a BasicFilesystem rooted at that path; a "fake://" URI builds
+// a FakeFilesystem (see ParseFakeURI for its query parameters); any other
+// scheme is an error.
+func ParseURI(uri string) (Filesystem, error) {
+	scheme, rest, hasScheme := strings.Cut(uri, "://")
+	if !hasScheme {
+		return NewBasicFilesystem(uri), nil
+	}
+
+	switch scheme {
+	case "file":
+		return NewBasicFilesystem(rest), nil
+	case "fake":
+		return ParseFakeURI(rest)
+	default:
+		return nil, fmt.Errorf("fs: unknown filesystem scheme %q in %q", scheme, uri)
+	}
+}
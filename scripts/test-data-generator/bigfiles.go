@@ -3,8 +3,8 @@
 package main
 
 import (
-	cryptoRand "crypto/rand"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 )
@@ -27,6 +27,7 @@ type BigFilesScenario struct {
 	FileCount   int    // Number of files to create
 	DirCount    int    // Number of directories (0 = flat structure)
 	Description string // Human-readable description
+	Sparse      bool   // Allocate files sparsely (see createBigFile) rather than writing every byte
 }
 
 // BigFilesScenarios defines the scenarios to generate.
@@ -45,6 +46,7 @@ var BigFilesScenarios = []BigFilesScenario{
 		FileCount:   1,
 		DirCount:    0,
 		Description: "Single 5GB file",
+		Sparse:      true,
 	},
 	{
 		Name:        "big-files-100k-files-and-dirs-total-2GB",
@@ -52,6 +54,7 @@ var BigFilesScenarios = []BigFilesScenario{
 		FileCount:   100000,
 		DirCount:    1000, // 1000 directories with ~100 files each
 		Description: "100k files in 1000 directories totaling 2GB",
+		Sparse:      true,
 	},
 }
 
@@ -59,8 +62,21 @@ var BigFilesScenarios = []BigFilesScenario{
 // Implementation
 // ============================================================================
 
-// createBigFile creates a file with random data of the specified size.
-func createBigFile(path string, size int64) error {
+// createBigFile creates a file of the specified size, read from randSrc -
+// crypto/rand.Reader by default, or a seeded math/rand.Rand when the caller
+// wants the file's bytes to be reproducible (see main.go's --seed flag).
+//
+// When sparse is true, it delegates to allocateSparseFile (sparse.go):
+// only a small header gets real bytes, and the rest of the file's size is
+// a hole the filesystem never allocates disk blocks for. Otherwise every
+// byte is written for real, which is the only option for filesystems or
+// scenarios that need the full random content (e.g. compression or dedup
+// testing).
+func createBigFile(randSrc io.Reader, path string, size int64, sparse bool) error {
+	if sparse {
+		return allocateSparseFile(randSrc, path, size)
+	}
+
 	f, err := os.Create(path)
 	if err != nil {
 		return err
@@ -80,8 +96,9 @@ func createBigFile(path string, size int64) error {
 			toWrite = int(size - written)
 		}
 
-		// Fill chunk with random data
-		_, _ = cryptoRand.Read(chunk[:toWrite])
+		if _, err := io.ReadFull(randSrc, chunk[:toWrite]); err != nil {
+			return fmt.Errorf("failed to fill chunk: %w", err)
+		}
 
 		n, err := f.Write(chunk[:toWrite])
 		if err != nil {
@@ -93,60 +110,96 @@ func createBigFile(path string, size int64) error {
 	return nil
 }
 
-// bigFilesFolderNeedsRecreation checks if the folder needs to be recreated.
-// Returns true if the folder doesn't exist or has incorrect size.
-func bigFilesFolderNeedsRecreation(folderPath string, scenario BigFilesScenario) bool {
+// bigFilesFolderNeedsRecreation checks if the folder needs to be recreated,
+// comparing it against the manifest writeManifest left behind by the run
+// that created it rather than re-walking and summing every file's size:
+// exact target/file-count equality replaces the old "current size within 5%
+// of target" approximation, and every file's apparent size (os.FileInfo.Size,
+// which reports a sparse file's logical size, not its disk usage) is checked
+// individually against what the manifest recorded, catching a partially
+// written or truncated file the old heuristic could miss as long as the
+// aggregate still landed in range.
+//
+// Using apparent size here - rather than the old heuristic's total-disk-usage
+// sum - is what lets a sparse scenario (see allocatedSize in sparse_unix.go /
+// sparse_windows.go) be recognized as already up to date: its real disk usage
+// is a small fraction of TotalSize by design, so a usage-based comparison
+// would wrongly conclude it needs recreating on every single run.
+//
+// seedRequested reports whether this run asked for a specific --seed (see
+// main.go); when it didn't, a folder generated with a different (or no)
+// seed is still considered up to date as long as its shape matches, since
+// byte-for-byte reproducibility wasn't asked for.
+func bigFilesFolderNeedsRecreation(folderPath string, scenario BigFilesScenario, seed int64, seedRequested bool) bool {
 	info, err := os.Stat(folderPath)
 	if err != nil || !info.IsDir() {
 		return true
 	}
 
-	var currentSize int64
-	var fileCount int
-	err = filepath.Walk(folderPath, func(_ string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if !info.IsDir() {
-			currentSize += info.Size()
-			fileCount++
+	m, err := readManifest(folderPath)
+	if err != nil || m.Target != scenario.TotalSize || len(m.Files) != scenario.FileCount {
+		return true
+	}
+	if seedRequested && (!m.SeedRequested || m.Seed != seed) {
+		return true
+	}
+
+	for _, entry := range m.Files {
+		info, err := os.Stat(filepath.Join(folderPath, entry.Path))
+		if err != nil || info.Size() != entry.Size {
+			return true
 		}
-		return nil
-	})
+	}
 
-	if err != nil {
-		return true
+	if scenario.Sparse {
+		logSparseUsage(folderPath, m, scenario)
 	}
+	fmt.Printf("    Already exists with %d files, %.2f GB - skipping\n",
+		len(m.Files), float64(scenario.TotalSize)/float64(GB))
+	return false
+}
 
-	withinRange := currentSize >= scenario.TotalSize*95/100 && currentSize <= scenario.TotalSize*105/100
-	if withinRange {
-		fmt.Printf("    Already exists with ~%d files, %.2f GB - skipping\n",
-			fileCount, float64(currentSize)/float64(GB))
-		return false
+// logSparseUsage prints a sparse scenario's actual on-disk usage next to
+// its apparent size, so a contributor can see at a glance that it really is
+// occupying a fraction of TotalSize rather than having silently been
+// materialized in full by a filesystem that doesn't support sparse files.
+func logSparseUsage(folderPath string, m *manifest, scenario BigFilesScenario) {
+	var allocated int64
+	for _, entry := range m.Files {
+		size, err := allocatedSize(filepath.Join(folderPath, entry.Path))
+		if err != nil {
+			return
+		}
+		allocated += size
 	}
-	return true
+	fmt.Printf("    Sparse: using %.2f GB on disk for %.2f GB of apparent content\n",
+		float64(allocated)/float64(GB), float64(scenario.TotalSize)/float64(GB))
 }
 
-// createHierarchicalBigFiles creates files organized in directories.
-func createHierarchicalBigFiles(folderPath string, scenario BigFilesScenario) error {
+// createHierarchicalBigFiles creates files organized in directories,
+// returning a manifest entry per file.
+func createHierarchicalBigFiles(randSrc io.Reader, folderPath string, scenario BigFilesScenario, sparse bool) ([]manifestEntry, error) {
 	filesPerDir := scenario.FileCount / scenario.DirCount
 	fileSize := scenario.TotalSize / int64(scenario.FileCount)
 
 	fmt.Printf("    Creating %d directories with ~%d files each (~%d KB per file)...\n",
 		scenario.DirCount, filesPerDir, fileSize/KB)
 
+	entries := make([]manifestEntry, 0, scenario.FileCount)
 	fileIndex := 0
 	for d := range scenario.DirCount {
-		dirPath := filepath.Join(folderPath, fmt.Sprintf("dir-%05d", d))
+		dirName := fmt.Sprintf("dir-%05d", d)
+		dirPath := filepath.Join(folderPath, dirName)
 		if err := os.MkdirAll(dirPath, 0755); err != nil {
-			return fmt.Errorf("failed to create directory %d: %w", d, err)
+			return nil, fmt.Errorf("failed to create directory %d: %w", d, err)
 		}
 
 		for f := 0; f < filesPerDir && fileIndex < scenario.FileCount; f++ {
-			filePath := filepath.Join(dirPath, fmt.Sprintf("file-%06d.dat", fileIndex))
-			if err := createBigFile(filePath, fileSize); err != nil {
-				return fmt.Errorf("failed to create file %d: %w", fileIndex, err)
+			relPath := filepath.Join(dirName, fmt.Sprintf("file-%06d.dat", fileIndex))
+			if err := createBigFile(randSrc, filepath.Join(folderPath, relPath), fileSize, sparse); err != nil {
+				return nil, fmt.Errorf("failed to create file %d: %w", fileIndex, err)
 			}
+			entries = append(entries, manifestEntry{Path: relPath, Size: fileSize, Sparse: sparse})
 			fileIndex++
 			if fileIndex%10000 == 0 {
 				fmt.Printf("    Created %d files...\n", fileIndex)
@@ -158,35 +211,44 @@ func createHierarchicalBigFiles(folderPath string, scenario BigFilesScenario) er
 		}
 	}
 	fmt.Printf("    Created %d files in %d directories\n", fileIndex, scenario.DirCount)
-	return nil
+	return entries, nil
 }
 
-// createFlatBigFiles creates files in a single directory.
-func createFlatBigFiles(folderPath string, scenario BigFilesScenario) error {
+// createFlatBigFiles creates files in a single directory, returning a
+// manifest entry per file.
+func createFlatBigFiles(randSrc io.Reader, folderPath string, scenario BigFilesScenario, sparse bool) ([]manifestEntry, error) {
 	fileSize := scenario.TotalSize / int64(scenario.FileCount)
 	fmt.Printf("    Creating %d files (~%.2f MB each)...\n",
 		scenario.FileCount, float64(fileSize)/float64(MB))
 
+	entries := make([]manifestEntry, 0, scenario.FileCount)
 	for i := range scenario.FileCount {
-		filePath := filepath.Join(folderPath, fmt.Sprintf("file-%06d.dat", i))
-		if err := createBigFile(filePath, fileSize); err != nil {
-			return fmt.Errorf("failed to create file %d: %w", i, err)
+		relPath := fmt.Sprintf("file-%06d.dat", i)
+		if err := createBigFile(randSrc, filepath.Join(folderPath, relPath), fileSize, sparse); err != nil {
+			return nil, fmt.Errorf("failed to create file %d: %w", i, err)
 		}
+		entries = append(entries, manifestEntry{Path: relPath, Size: fileSize, Sparse: sparse})
 
 		if (i+1)%10 == 0 || i == scenario.FileCount-1 {
 			pct := float64(i+1) / float64(scenario.FileCount) * 100
 			fmt.Printf("    Progress: %.0f%% (%d/%d files)\n", pct, i+1, scenario.FileCount)
 		}
 	}
-	return nil
+	return entries, nil
 }
 
-// SyncBigFilesScenario ensures the big-files scenario folder is in the desired state.
-func SyncBigFilesScenario(baseDir string, scenario BigFilesScenario) error {
+// SyncBigFilesScenario ensures the big-files scenario folder is in the
+// desired state, reading randSrc for file content. seedRequested records
+// whether seed came from an explicit --seed flag (see
+// bigFilesFolderNeedsRecreation) rather than main's own fallback seeding.
+// forceSparse makes every scenario sparse regardless of its own Sparse
+// field, for main.go's --sparse flag.
+func SyncBigFilesScenario(randSrc io.Reader, seed int64, seedRequested bool, baseDir string, scenario BigFilesScenario, forceSparse bool) error {
 	folderPath := filepath.Join(baseDir, scenario.Name)
+	sparse := forceSparse || scenario.Sparse
 	fmt.Printf("  %s: %s\n", scenario.Name, scenario.Description)
 
-	if !bigFilesFolderNeedsRecreation(folderPath, scenario) {
+	if !bigFilesFolderNeedsRecreation(folderPath, scenario, seed, seedRequested) {
 		return nil
 	}
 
@@ -198,16 +260,22 @@ func SyncBigFilesScenario(baseDir string, scenario BigFilesScenario) error {
 		return fmt.Errorf("failed to create folder: %w", err)
 	}
 
+	var entries []manifestEntry
 	var err error
 	if scenario.DirCount > 0 {
-		err = createHierarchicalBigFiles(folderPath, scenario)
+		entries, err = createHierarchicalBigFiles(randSrc, folderPath, scenario, sparse)
 	} else {
-		err = createFlatBigFiles(folderPath, scenario)
+		entries, err = createFlatBigFiles(randSrc, folderPath, scenario, sparse)
 	}
 	if err != nil {
 		return err
 	}
 
+	m := manifest{Seed: seed, SeedRequested: seedRequested, Target: scenario.TotalSize, Files: entries}
+	if err := writeManifest(folderPath, m); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
 	fmt.Printf("    Done!\n")
 	return nil
 }
@@ -0,0 +1,170 @@
+// Pluggable per-OS backends for applying a custom icon to a folder, used by
+// CreateIconTestData so the icon test data (and cmdr's icon rendering) can be
+// exercised on CI runners other than macOS.
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+)
+
+// iconAsset is the set of per-platform image files available for one colored
+// folder icon. Any field may be empty if that format wasn't generated.
+type iconAsset struct {
+	icnsPath string // macOS
+	pngPath  string // Linux, Windows
+	svgPath  string // Linux
+}
+
+// IconApplier applies a custom folder icon using whatever mechanism its
+// platform supports. Apply returns whether it actually changed anything
+// (false, nil means "nothing to do here, skip silently" - e.g. the needed
+// asset file doesn't exist - not an error).
+type IconApplier interface {
+	Apply(folderPath string, asset iconAsset) (bool, error)
+}
+
+// newIconApplier returns the IconApplier for the platform this binary is
+// running on.
+func newIconApplier() IconApplier {
+	switch runtime.GOOS {
+	case "darwin":
+		return macIconApplier{}
+	case "windows":
+		return windowsIconApplier{}
+	default:
+		return linuxIconApplier{}
+	}
+}
+
+// commandExists reports whether name is on PATH.
+func commandExists(name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// macIconApplier sets a folder's custom icon the way Finder itself does: an
+// "Icon\r" resource file holding the .icns data, plus the "has custom icon"
+// bit set in the com.apple.FinderInfo extended attribute. It prefers the
+// fileicon CLI (brew install fileicon), which does the same thing, and only
+// falls back to doing it by hand if fileicon isn't installed.
+type macIconApplier struct{}
+
+func (macIconApplier) Apply(folderPath string, asset iconAsset) (bool, error) {
+	if asset.icnsPath == "" {
+		return false, nil
+	}
+	if _, err := os.Stat(asset.icnsPath); err != nil {
+		return false, nil
+	}
+
+	if commandExists("fileicon") {
+		if err := exec.Command("fileicon", "set", folderPath, asset.icnsPath).Run(); err == nil {
+			return true, nil
+		}
+		// fileicon is installed but failed (e.g. permissions) - fall through
+		// to the manual method rather than giving up.
+	}
+
+	return true, setCustomIconAttr(folderPath, asset.icnsPath)
+}
+
+// setCustomIconAttr reproduces what `fileicon set` does without depending on
+// it: write icnsPath's bytes to a resource file named "Icon\r" (carriage
+// return, not newline - that's the name macOS looks for) inside folderPath,
+// then flip bit 0x04 of byte 8 in the com.apple.FinderInfo attribute (the
+// "has custom icon" flag Finder checks before it'll look at that file).
+func setCustomIconAttr(folderPath, icnsPath string) error {
+	icnsData, err := os.ReadFile(icnsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", icnsPath, err)
+	}
+
+	iconFile := filepath.Join(folderPath, "Icon\r")
+	if err := os.WriteFile(iconFile, icnsData, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", iconFile, err)
+	}
+	// The Icon file itself must stay hidden from Finder's listing.
+	_ = exec.Command("chflags", "hidden", iconFile).Run()
+
+	finderInfo := make([]byte, 32)
+	finderInfo[8] = 0x04
+	hexInfo := fmt.Sprintf("%x", finderInfo)
+	if err := exec.Command("xattr", "-wx", "com.apple.FinderInfo", hexInfo, folderPath).Run(); err != nil {
+		return fmt.Errorf("failed to set com.apple.FinderInfo on %s: %w", folderPath, err)
+	}
+	return nil
+}
+
+// linuxIconApplier sets a folder's custom icon for both major desktop
+// environments: a ".directory" file with an Icon= key (KDE/Dolphin), and the
+// metadata::custom-icon GVFS attribute (GNOME/Nautilus). Either can succeed
+// independently, since whichever desktop environment is actually running
+// only reads the one it understands.
+type linuxIconApplier struct{}
+
+func (linuxIconApplier) Apply(folderPath string, asset iconAsset) (bool, error) {
+	imagePath := asset.svgPath
+	if imagePath == "" {
+		imagePath = asset.pngPath
+	}
+	if imagePath == "" {
+		return false, nil
+	}
+	if _, err := os.Stat(imagePath); err != nil {
+		return false, nil
+	}
+
+	absImagePath, err := filepath.Abs(imagePath)
+	if err != nil {
+		return false, err
+	}
+
+	dotDirectory := filepath.Join(folderPath, ".directory")
+	content := fmt.Sprintf("[Desktop Entry]\nIcon=%s\n", absImagePath)
+	if err := os.WriteFile(dotDirectory, []byte(content), 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", dotDirectory, err)
+	}
+
+	if commandExists("gvfs-set-attribute") {
+		// Best-effort: a non-GNOME session with gvfs-set-attribute installed
+		// but no running Nautilus still succeeds at writing the attribute.
+		_ = exec.Command("gvfs-set-attribute", "-t", "string", folderPath, "metadata::custom-icon", "file://"+absImagePath).Run()
+	}
+
+	return true, nil
+}
+
+// windowsIconApplier sets a folder's custom icon via desktop.ini, the same
+// mechanism Explorer itself uses: a desktop.ini naming the icon resource,
+// with desktop.ini hidden+system and the folder marked read-only (the flag
+// Explorer checks before it'll honor a folder's desktop.ini).
+type windowsIconApplier struct{}
+
+func (windowsIconApplier) Apply(folderPath string, asset iconAsset) (bool, error) {
+	if asset.pngPath == "" {
+		return false, nil
+	}
+	if _, err := os.Stat(asset.pngPath); err != nil {
+		return false, nil
+	}
+
+	absImagePath, err := filepath.Abs(asset.pngPath)
+	if err != nil {
+		return false, err
+	}
+
+	desktopIni := filepath.Join(folderPath, "desktop.ini")
+	content := fmt.Sprintf("[.ShellClassInfo]\nIconResource=%s,0\n", absImagePath)
+	if err := os.WriteFile(desktopIni, []byte(content), 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", desktopIni, err)
+	}
+
+	_ = exec.Command("attrib", "+s", "+h", desktopIni).Run()
+	_ = exec.Command("attrib", "+r", folderPath).Run()
+
+	return true, nil
+}
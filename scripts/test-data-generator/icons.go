@@ -3,13 +3,25 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 )
 
+// iconManifestEntry records what CreateIconTestData did for one folder, so
+// E2E tests can assert against the actual outcome instead of assuming the
+// custom icon was applied - applying a custom icon depends on host tooling
+// (fileicon, gvfs-set-attribute, ...) that may not be present on a given CI
+// runner.
+type iconManifestEntry struct {
+	Path    string `json:"path"`
+	Color   string `json:"color,omitempty"`
+	Type    string `json:"type"`
+	Applied bool   `json:"applied"`
+}
+
 // CreateIconTestData creates a folder with various file types for testing icons.
 // Includes: fake files with different extensions, symlinks, and folders with custom icons.
 func CreateIconTestData(baseDir string) error {
@@ -75,9 +87,13 @@ func CreateIconTestData(baseDir string) error {
 		return fmt.Errorf("failed to create symlink to folder: %w", err)
 	}
 
-	// Create folders with custom icons
+	// Create folders with custom icons, applied via whichever IconApplier
+	// fits the host OS (see icon_applier.go).
 	assetsDir := "scripts/test-data-generator/assets/icons"
 	iconColors := []string{"red", "blue", "green", "yellow"}
+	applier := newIconApplier()
+
+	var manifest []iconManifestEntry
 
 	fmt.Printf("  Creating %d folders with custom icons...\n", len(iconColors))
 	for _, color := range iconColors {
@@ -96,23 +112,51 @@ func CreateIconTestData(baseDir string) error {
 			return fmt.Errorf("failed to create README in %s: %w", folderName, err)
 		}
 
-		// Apply custom icon using fileicon CLI (macOS only)
-		icnsPath := filepath.Join(assetsDir, fmt.Sprintf("%s-circle.icns", color))
-		if _, err := os.Stat(icnsPath); err == nil {
-			// fileicon is available via: brew install fileicon
-			cmd := exec.Command("fileicon", "set", folderPath, icnsPath)
-			if err := cmd.Run(); err != nil {
-				fmt.Printf("  Warning: failed to set icon for %s (install fileicon: brew install fileicon)\n", folderName)
-			}
+		asset := iconAsset{
+			icnsPath: filepath.Join(assetsDir, fmt.Sprintf("%s-circle.icns", color)),
+			pngPath:  filepath.Join(assetsDir, fmt.Sprintf("%s-circle.png", color)),
+			svgPath:  filepath.Join(assetsDir, fmt.Sprintf("%s-circle.svg", color)),
 		}
+		applied, err := applier.Apply(folderPath, asset)
+		if err != nil {
+			fmt.Printf("  Warning: failed to set icon for %s: %v\n", folderName, err)
+			applied = false
+		}
+
+		manifest = append(manifest, iconManifestEntry{
+			Path:    filepath.Join("icons", folderName),
+			Color:   color,
+			Type:    "custom-folder-icon",
+			Applied: applied,
+		})
 	}
 
 	// Add README to regular folder (already created earlier as symlink target)
-	readmeContent := "# Regular folder\n\nThis folder has the default macOS folder icon.\n"
+	readmeContent := "# Regular folder\n\nThis folder has the default folder icon.\n"
 	if err := os.WriteFile(filepath.Join(regularFolder, "README.md"), []byte(readmeContent), 0644); err != nil {
 		return fmt.Errorf("failed to create README in regular folder: %w", err)
 	}
+	manifest = append(manifest, iconManifestEntry{
+		Path:    filepath.Join("icons", "regular-folder"),
+		Type:    "default-folder-icon",
+		Applied: true,
+	})
+
+	if err := writeIconManifest(iconDir, manifest); err != nil {
+		return fmt.Errorf("failed to write icon manifest: %w", err)
+	}
 
 	fmt.Println("  Icon test data created successfully!")
 	return nil
 }
+
+// writeIconManifest writes manifest as JSON to iconDir/manifest.json, so E2E
+// tests can load it and assert the rendered icon for each path matches what
+// this run actually managed to apply (see iconManifestEntry.Applied).
+func writeIconManifest(iconDir string, manifest []iconManifestEntry) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(iconDir, "manifest.json"), data, 0644)
+}
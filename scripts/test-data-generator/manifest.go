@@ -0,0 +1,53 @@
+// Manifest records what a big-files scenario run actually wrote to its
+// folder - the requested seed and target size, plus every file's exact size
+// - so a later run can verify "this folder already matches" by comparing
+// recorded state instead of guessing from a total-size heuristic.
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const manifestFileName = ".cmdr-manifest.json"
+
+// manifestEntry records one file SyncBigFilesScenario created, path relative
+// to the scenario folder.
+type manifestEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Sparse bool   `json:"sparse,omitempty"`
+}
+
+// manifest is the JSON document writeManifest writes alongside a generated
+// big-files scenario folder.
+type manifest struct {
+	Seed          int64           `json:"seed"`
+	SeedRequested bool            `json:"seed_requested"`
+	Target        int64           `json:"target"`
+	Files         []manifestEntry `json:"files"`
+}
+
+// writeManifest writes m to folderPath/.cmdr-manifest.json.
+func writeManifest(folderPath string, m manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(folderPath, manifestFileName), data, 0644)
+}
+
+// readManifest reads the manifest folderPath's last generator run wrote, if
+// any.
+func readManifest(folderPath string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(folderPath, manifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
@@ -29,12 +29,13 @@ var ManyFilesTargets = map[string]int{
 // Implementation
 // ============================================================================
 
-// deleteFilesToTarget deletes random files from a folder to reach the target count.
-func deleteFilesToTarget(folderPath string, existingFiles []string, deleteCount int) error {
+// deleteFilesToTarget deletes random files from a folder to reach the target
+// count, picking which ones via rng so a seeded run is reproducible.
+func deleteFilesToTarget(rng *rand.Rand, folderPath string, existingFiles []string, deleteCount int) error {
 	fmt.Printf("  Deleting %d files", deleteCount)
 
 	// Shuffle and pick first N to delete
-	rand.Shuffle(len(existingFiles), func(i, j int) {
+	rng.Shuffle(len(existingFiles), func(i, j int) {
 		existingFiles[i], existingFiles[j] = existingFiles[j], existingFiles[i]
 	})
 
@@ -51,13 +52,14 @@ func deleteFilesToTarget(folderPath string, existingFiles []string, deleteCount
 	return nil
 }
 
-// createFilesToTarget creates files with random timestamps to reach the target count.
-func createFilesToTarget(folderPath string, usedTimestamps map[string]bool, createCount int) error {
+// createFilesToTarget creates files with random timestamps to reach the
+// target count, drawing timestamps and content from rng.
+func createFilesToTarget(rng *rand.Rand, folderPath string, usedTimestamps map[string]bool, createCount int) error {
 	fmt.Printf("  Creating %d files", createCount)
 
 	created := 0
 	for created < createCount {
-		ts := generateTimestamp()
+		ts := generateTimestamp(rng)
 		filename := ts.Format("2006-01-02 15-04-05") + ".md"
 
 		if usedTimestamps[filename] {
@@ -66,7 +68,7 @@ func createFilesToTarget(folderPath string, usedTimestamps map[string]bool, crea
 		usedTimestamps[filename] = true
 
 		filePath := filepath.Join(folderPath, filename)
-		content := generateSentence()
+		content := generateSentence(rng)
 
 		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
 			return fmt.Errorf("failed to write %s: %w", filePath, err)
@@ -81,8 +83,10 @@ func createFilesToTarget(folderPath string, usedTimestamps map[string]bool, crea
 	return nil
 }
 
-// SyncManyFilesFolder ensures a folder has exactly targetCount files, creating or deleting as needed.
-func SyncManyFilesFolder(folderPath string, targetCount int) error {
+// SyncManyFilesFolder ensures a folder has exactly targetCount files,
+// creating or deleting as needed. File selection and content are drawn from
+// rng, so a seeded caller gets reproducible output.
+func SyncManyFilesFolder(rng *rand.Rand, folderPath string, targetCount int) error {
 	if err := os.MkdirAll(folderPath, 0755); err != nil {
 		return fmt.Errorf("failed to create folder %s: %w", folderPath, err)
 	}
@@ -104,13 +108,13 @@ func SyncManyFilesFolder(folderPath string, targetCount int) error {
 
 	switch {
 	case currentCount > targetCount:
-		return deleteFilesToTarget(folderPath, existingFiles, currentCount-targetCount)
+		return deleteFilesToTarget(rng, folderPath, existingFiles, currentCount-targetCount)
 	case currentCount < targetCount:
 		usedTimestamps := make(map[string]bool)
 		for _, name := range existingFiles {
 			usedTimestamps[name] = true
 		}
-		return createFilesToTarget(folderPath, usedTimestamps, targetCount-currentCount)
+		return createFilesToTarget(rng, folderPath, usedTimestamps, targetCount-currentCount)
 	default:
 		fmt.Println("  Already at target, no changes needed")
 		return nil
@@ -0,0 +1,32 @@
+// Sparse-file allocation for the big-files scenarios. allocateSparseFile and
+// allocatedSize are implemented per-OS in sparse_unix.go and
+// sparse_windows.go; this file holds the part shared between them.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// sparseHeaderSize is how much of a sparse file's front gets real,
+// non-zero bytes, so a file manager preview, `file`, or a thumbnailer that
+// only peeks at the start of a file still sees plausible content instead of
+// an obvious run of zeros.
+const sparseHeaderSize = 64 * KB
+
+// writeSparseHeader writes min(sparseHeaderSize, size) bytes of randSrc into
+// f, leaving f's offset just past the header ready for the caller to extend
+// the file the rest of the way to size.
+func writeSparseHeader(randSrc io.Reader, f *os.File, size int64) error {
+	n := int64(sparseHeaderSize)
+	if size < n {
+		n = size
+	}
+	header := make([]byte, n)
+	if _, err := io.ReadFull(randSrc, header); err != nil {
+		return fmt.Errorf("failed to fill sparse header: %w", err)
+	}
+	_, err := f.Write(header)
+	return err
+}
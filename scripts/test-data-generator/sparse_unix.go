@@ -0,0 +1,45 @@
+//go:build !windows
+
+package main
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+// allocateSparseFile creates path as a sparse file: a real header is
+// written up front (writeSparseHeader, sparse.go), then Truncate extends
+// the file the rest of the way to size. Every mainstream Unix filesystem
+// (ext4, APFS, xfs, ...) leaves the truncate-extended region as an
+// unallocated hole rather than zero-filling it on disk, which is exactly
+// the sparse behavior this scenario wants - no separate "mark sparse" call
+// is needed the way Windows' NTFS requires one.
+func allocateSparseFile(randSrc io.Reader, path string, size int64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := writeSparseHeader(randSrc, f, size); err != nil {
+		return err
+	}
+	return f.Truncate(size)
+}
+
+// allocatedSize reports how many bytes path actually occupies on disk,
+// which is far less than its apparent size (os.FileInfo.Size) for a sparse
+// file. stat(2)'s st_blocks always counts 512-byte blocks regardless of the
+// filesystem's actual block size.
+func allocatedSize(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.Size(), nil
+	}
+	return stat.Blocks * 512, nil
+}
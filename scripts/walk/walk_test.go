@@ -0,0 +1,184 @@
+package walk
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func collect(t *testing.T, root string, workers int) []string {
+	t.Helper()
+	var mu sync.Mutex
+	var got []string
+	err := Parallel(root, workers, func(path string, info os.FileInfo) error {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		got = append(got, filepath.ToSlash(rel))
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Parallel returned error: %v", err)
+	}
+	sort.Strings(got)
+	return got
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestParallel_VisitsEveryFileAndDir(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), "a")
+	writeFile(t, filepath.Join(root, "sub", "b.txt"), "b")
+	writeFile(t, filepath.Join(root, "sub", "nested", "c.txt"), "c")
+
+	got := collect(t, root, 2)
+
+	want := []string{"a.txt", "sub", "sub/b.txt", "sub/nested", "sub/nested/c.txt"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+			break
+		}
+	}
+}
+
+func TestParallel_SkipsGitignoredDirs(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".gitignore"), "node_modules/\n*.log\n")
+	writeFile(t, filepath.Join(root, "src", "main.go"), "package main")
+	writeFile(t, filepath.Join(root, "node_modules", "pkg", "index.js"), "module.exports = {}")
+	writeFile(t, filepath.Join(root, "debug.log"), "oops")
+
+	got := collect(t, root, 4)
+
+	for _, path := range got {
+		if path == "node_modules" || path == "debug.log" {
+			t.Errorf("expected %q to be skipped, got %v", path, got)
+		}
+	}
+	found := false
+	for _, path := range got {
+		if path == "src/main.go" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected src/main.go to be visited, got %v", got)
+	}
+}
+
+func TestParallel_CmdrignoreIsAlsoRespected(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".cmdrignore"), "target/\n")
+	writeFile(t, filepath.Join(root, "target", "debug", "build.bin"), "binary")
+	writeFile(t, filepath.Join(root, "src.rs"), "fn main() {}")
+
+	got := collect(t, root, 4)
+
+	for _, path := range got {
+		if path == "target" {
+			t.Errorf("expected target to be skipped, got %v", got)
+		}
+	}
+}
+
+func TestParallel_IgnorePatternsInheritIntoSubdirectories(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".gitignore"), "*.log\n")
+	writeFile(t, filepath.Join(root, "sub", "debug.log"), "oops")
+	writeFile(t, filepath.Join(root, "sub", "keep.txt"), "ok")
+
+	got := collect(t, root, 4)
+
+	for _, path := range got {
+		if path == "sub/debug.log" {
+			t.Errorf("expected the root .gitignore pattern to apply to sub/, got %v", got)
+		}
+	}
+}
+
+func TestParallel_ReturnsErrorFromFn(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "a.txt"), "a")
+
+	wantErr := os.ErrInvalid
+	err := Parallel(root, 2, func(path string, info os.FileInfo) error {
+		if !info.IsDir() {
+			return wantErr
+		}
+		return nil
+	})
+	if err != wantErr {
+		t.Errorf("expected %v, got %v", wantErr, err)
+	}
+}
+
+func TestParallel_SingleFileRoot(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, "a.txt")
+	writeFile(t, file, "a")
+
+	var visited string
+	err := Parallel(file, 2, func(path string, info os.FileInfo) error {
+		visited = path
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if visited != file {
+		t.Errorf("expected to visit %q, got %q", file, visited)
+	}
+}
+
+// makeManyFilesTree builds a tree of dirCount directories with filesPerDir
+// files each, for the parallel-vs-sequential benchmark below.
+func makeManyFilesTree(b *testing.B, dirCount, filesPerDir int) string {
+	b.Helper()
+	root := b.TempDir()
+	for d := 0; d < dirCount; d++ {
+		dir := filepath.Join(root, "dir", string(rune('a'+d%26))+string(rune('0'+d/26)))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			b.Fatalf("mkdir: %v", err)
+		}
+		for f := 0; f < filesPerDir; f++ {
+			if err := os.WriteFile(filepath.Join(dir, "file"+string(rune('0'+f%10))+".dat"), nil, 0o644); err != nil {
+				b.Fatalf("write: %v", err)
+			}
+		}
+	}
+	return root
+}
+
+func BenchmarkParallel(b *testing.B) {
+	root := makeManyFilesTree(b, 200, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Parallel(root, 0, func(path string, info os.FileInfo) error { return nil })
+	}
+}
+
+func BenchmarkSequentialWalk(b *testing.B) {
+	root := makeManyFilesTree(b, 200, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = filepath.Walk(root, func(path string, info os.FileInfo, err error) error { return err })
+	}
+}